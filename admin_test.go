@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/matthewmcneely/modusgraph"
 	"github.com/stretchr/testify/require"
 )
 
@@ -130,6 +131,66 @@ func TestDropAll(t *testing.T) {
 	}
 }
 
+func TestDropAllThenReinsert(t *testing.T) {
+
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "DropAllThenReinsertWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "DropAllThenReinsertWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			for round := range 3 {
+				old := []*TestEntity{{Name: "Old Entity 1"}, {Name: "Old Entity 2"}}
+				require.NoError(t, client.Insert(ctx, old), "Insert should succeed in round %d", round)
+
+				require.NoError(t, client.DropAll(ctx), "DropAll should succeed in round %d", round)
+
+				fresh := TestEntity{Name: "New Entity", Description: "inserted after DropAll"}
+				require.NoError(t, client.Insert(ctx, &fresh), "Insert after DropAll should succeed")
+				for _, o := range old {
+					require.NotEqual(t, o.UID, fresh.UID, "A UID from before DropAll should not be reissued")
+				}
+
+				var all []TestEntity
+				require.NoError(t, client.Query(ctx, TestEntity{}).Nodes(&all))
+				require.Len(t, all, 1, "Only the new data should be visible")
+				require.Equal(t, fresh.UID, all[0].UID)
+
+				var got TestEntity
+				require.NoError(t, client.Get(ctx, &got, fresh.UID))
+				require.Equal(t, "inserted after DropAll", got.Description)
+
+				var stale TestEntity
+				err := client.Get(ctx, &stale, old[0].UID)
+				require.Error(t, err, "A node dropped by DropAll should stay gone")
+
+				require.NoError(t, client.DropAll(ctx))
+			}
+		})
+	}
+}
+
 type Struct1 struct {
 	UID   string   `json:"uid,omitempty"`
 	Name  string   `json:"name,omitempty" dgraph:"index=term"`
@@ -201,3 +262,26 @@ func TestCreateSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestClientWithSchema(t *testing.T) {
+	ctx := context.Background()
+	client, err := modusgraph.NewClient("file://"+GetTempDir(t), modusgraph.WithSchema(TestEntity{}))
+	require.NoError(t, err)
+	defer func() {
+		client.Close()
+		modusgraph.Shutdown()
+	}()
+
+	// Nothing has been written, so the schema can only come from WithSchema.
+	schema, err := client.GetSchema(ctx)
+	require.NoError(t, err, "GetSchema should succeed")
+	require.Contains(t, schema, "type TestEntity")
+	require.Contains(t, schema, "name: string @index(term,exact) @upsert @unique")
+	require.Contains(t, schema, "description: string @index(term)")
+
+	// Without auto-schema, mutations rely on the schema applied at startup.
+	entity := TestEntity{Name: "Startup"}
+	require.NoError(t, client.Insert(ctx, &entity))
+	err = client.Insert(ctx, &TestEntity{Name: "Startup"})
+	require.Error(t, err, "The unique index from the startup schema should be enforced")
+}
@@ -0,0 +1,113 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Blob is binary data stored in a single predicate. It is written as a
+// base64-encoded string, which Dgraph keeps byte for byte; a []byte field
+// instead maps to a list of ints, which Dgraph stores as an unordered set.
+// Blob is a string so dgman passes it to the encoder whole rather than
+// element by element; convert with Blob(data) and []byte(b). Base64 makes the
+// stored value, and every response that reads it, about a third larger than
+// the data.
+//
+//	type Attachment struct {
+//	    UID     string   `json:"uid,omitempty"`
+//	    Name    string   `json:"name,omitempty" dgraph:"index=exact"`
+//	    Content Blob     `json:"content,omitempty"`
+//	    DType   []string `json:"dgraph.type,omitempty"`
+//	}
+type Blob string
+
+// MarshalJSON implements json.Marshaler. A Blob serializes as a base64 JSON
+// string, since its raw bytes need not be valid UTF-8.
+func (b Blob) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.StdEncoding.EncodeToString([]byte(b)))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A Blob deserializes from a
+// base64 JSON string.
+func (b *Blob) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*b = Blob(decoded)
+	return nil
+}
+
+// SchemaType implements the dgman SchemaType interface so that dgman emits
+// "string" as the Dgraph predicate type for Blob fields.
+func (b Blob) SchemaType() string {
+	return "string"
+}
+
+// ErrBlobTooLarge is returned, wrapped with the field and its size, when a
+// mutation carries a Blob or []byte field longer than the client's
+// WithMaxBlobSize limit.
+var ErrBlobTooLarge = errors.New("blob exceeds the maximum size")
+
+// blobType is the reflect.Type of Blob.
+var blobType = reflect.TypeFor[Blob]()
+
+// checkBlobSizes returns an ErrBlobTooLarge error for the first Blob or []byte
+// field of val, or of a node reached over its edges, longer than max bytes.
+func checkBlobSizes(val reflect.Value, max int) error {
+	return walkBlobs(val, max, make(map[uintptr]bool))
+}
+
+func walkBlobs(val reflect.Value, max int, seen map[uintptr]bool) error {
+	switch val.Kind() {
+	case reflect.Pointer:
+		if val.IsNil() || seen[val.Pointer()] {
+			return nil
+		}
+		seen[val.Pointer()] = true
+		return walkBlobs(val.Elem(), max, seen)
+	case reflect.Slice:
+		for i := 0; i < val.Len(); i++ {
+			if err := walkBlobs(val.Index(i), max, seen); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		t := val.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fv := val.Field(i)
+			if isBlobType(field.Type) {
+				if fv.Len() > max {
+					return fmt.Errorf("%w: %s.%s is %d bytes, limit %d", ErrBlobTooLarge,
+						t.Name(), field.Name, fv.Len(), max)
+				}
+				continue
+			}
+			if err := walkBlobs(fv, max, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isBlobType reports whether t holds binary data: Blob or a byte slice.
+func isBlobType(t reflect.Type) bool {
+	return t == blobType || (t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8)
+}
@@ -0,0 +1,81 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+type Attachment struct {
+	UID     string          `json:"uid,omitempty"`
+	Name    string          `json:"name,omitempty" dgraph:"index=exact"`
+	Content modusgraph.Blob `json:"content,omitempty"`
+	DType   []string        `json:"dgraph.type,omitempty"`
+}
+
+func TestClientBlobSizeLimit(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "BlobWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "BlobWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	const limit = 1024
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, err := modusgraph.NewClient(tc.uri, modusgraph.WithAutoSchema(true),
+				modusgraph.WithMaxBlobSize(limit))
+			require.NoError(t, err)
+			ctx := context.Background()
+			defer func() {
+				_ = client.DropAll(ctx)
+				client.Close()
+				modusgraph.Shutdown()
+			}()
+
+			// Repeated and out-of-order bytes must come back exactly.
+			content := bytes.Repeat([]byte{3, 0, 255, 3, 1}, limit/5)
+			small := Attachment{Name: "small", Content: modusgraph.Blob(content)}
+			require.NoError(t, client.Insert(ctx, &small), "A blob under the limit should be stored")
+			var got Attachment
+			require.NoError(t, client.Get(ctx, &got, small.UID))
+			require.Equal(t, content, []byte(got.Content), "The blob should round-trip byte for byte")
+
+			large := Attachment{Name: "large", Content: modusgraph.Blob(make([]byte, limit+1))}
+			err = client.Insert(ctx, &large)
+			require.ErrorIs(t, err, modusgraph.ErrBlobTooLarge)
+			require.Contains(t, err.Error(), "Attachment.Content")
+			require.Empty(t, large.UID, "A rejected blob should not be inserted")
+
+			got.Content = modusgraph.Blob(make([]byte, limit+1))
+			require.ErrorIs(t, client.Update(ctx, &got), modusgraph.ErrBlobTooLarge,
+				"Updates should be checked too")
+			require.NoError(t, client.Get(ctx, &got, small.UID))
+			require.Equal(t, content, []byte(got.Content), "A rejected update should write nothing")
+		})
+	}
+}
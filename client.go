@@ -7,14 +7,19 @@ package modusgraph
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/url"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/dgraph-io/dgo/v250"
 	"github.com/dgraph-io/dgo/v250/protos/api"
@@ -29,6 +34,8 @@ import (
 type Client interface {
 	// Insert adds a new object or slice of objects to the database.
 	// The object must be a pointer to a struct with appropriate dgraph tags.
+	// A nested node with no UID that sets only fields tagged unique refers
+	// to the existing node holding those values, which the edge then links to.
 	Insert(context.Context, any) error
 
 	// InsertRaw adds a new object or slice of objects to the database.
@@ -43,7 +50,8 @@ type Client interface {
 	// This operation requires a field with a unique directive in the dgraph tag.
 	// If no predicates are specified, the first predicate with the `upsert` tag will be used.
 	// If none are specified in the predicates argument, the first predicate with the `upsert` tag
-	// will be used.
+	// will be used. An object whose UID is already set updates that node instead
+	// of matching on the predicates.
 	Upsert(context.Context, any, ...string) error
 
 	// LoadOrStore stores the object only if no node matches the upsert
@@ -51,26 +59,107 @@ type Client interface {
 	// (the object is then populated from it). Insert-if-absent.
 	LoadOrStore(ctx context.Context, obj any, predicates ...string) (loaded bool, err error)
 
+	// InsertIfAbsent inserts obj unless a node already holds one of its
+	// unique predicate values, in which case it returns false and no error.
+	InsertIfAbsent(ctx context.Context, obj any) (inserted bool, err error)
+
+	// UpsertReturnOld upserts obj like Upsert and, when an existing node
+	// matched, loads that node's state from before the update into old (a
+	// pointer to the same type as obj). created reports that no node matched
+	// and obj was inserted; old is then zeroed.
+	UpsertReturnOld(ctx context.Context, obj any, old any, predicates ...string) (created bool, err error)
+
+	// UpsertIf upserts obj on predicate like Upsert, but updates an existing
+	// node only when it satisfies condition, a DQL filter expression such as
+	// "lt(budget, 500)" evaluated against the stored node. applied is false when
+	// the node exists and the condition does not hold; obj is then not written.
+	// A node that does not exist yet is inserted.
+	UpsertIf(ctx context.Context, obj any, predicate, condition string) (applied bool, err error)
+
 	// LoadAndDelete atomically reads the node whose key predicate equals key
 	// into obj and deletes it, returning loaded=false when none matched.
 	// Read-and-consume; concurrent callers elect one winner.
 	LoadAndDelete(ctx context.Context, obj any, key any, predicates ...string) (loaded bool, err error)
 
+	// DeleteAndReturn reads the node with the given UID into obj and deletes
+	// it in one transaction, so obj holds exactly what was removed. It returns
+	// dgman's ErrNodeNotFound when no such node exists.
+	DeleteAndReturn(ctx context.Context, obj any, uid string) error
+
+	// Increment atomically adds delta to the integer predicate of the node
+	// uid and returns the new value; a missing value counts from zero.
+	Increment(ctx context.Context, uid, predicate string, delta int64) (int64, error)
+
 	// Update modifies an existing object in the database.
 	// The object must be a pointer to a struct and must have a UID field set.
+	// If the struct has an integer field tagged dgraph:"version", the update
+	// only applies when the stored version matches and increments it;
+	// otherwise it fails with ErrVersionConflict.
 	Update(context.Context, any) error
 
+	// UpdateCount is Update, returning the number of nodes it updated: one
+	// for a struct, the length of a slice of structs.
+	UpdateCount(ctx context.Context, obj any) (int, error)
+
+	// UpdateWhere applies patch, a map of predicates to their new values, to
+	// every node of model's type that satisfies the DQL filter expression,
+	// returning how many were updated. A nil value deletes the predicate.
+	UpdateWhere(ctx context.Context, model any, filter string, patch map[string]any) (int, error)
+
+	// Modify reads the node uid into obj, calls fn to change obj, and writes
+	// obj back, all in one transaction that is retried when a concurrent
+	// writer aborts it. obj must be a pointer to a struct; fn, which sees the
+	// fresh value on every attempt, returning an error abandons the change.
+	Modify(ctx context.Context, obj any, uid string, fn func() error) error
+
 	// Get retrieves a single object by its UID and populates the provided object.
 	// The object parameter must be a pointer to a struct.
 	Get(context.Context, any, string) error
 
+	// GetMap retrieves the nodes with the given UIDs, each decoded into a
+	// fresh value returned by factory, which must be a pointer to a struct.
+	// The values are keyed by UID; UIDs with no node are left out.
+	GetMap(ctx context.Context, uids []string, factory func() any) (map[string]any, error)
+
 	// Query creates a new query builder for retrieving data from the database.
 	// Returns a *dg.Query that can be further refined with filters, pagination, etc.
 	Query(context.Context, any) *dg.Query
 
+	// ModifiedSince creates a query for the nodes of model's type whose
+	// timestamp predicate field is at or after since, oldest first.
+	ModifiedSince(ctx context.Context, model any, field string, since time.Time) *dg.Query
+
+	// QueryInterface retrieves every node of the Dgraph type typeName without
+	// a concrete Go model. Each node is decoded into a fresh value returned by
+	// resultFactory, which must be a pointer; the decoded values are returned.
+	QueryInterface(ctx context.Context, typeName string, resultFactory func() any) ([]any, error)
+
+	// MultiQuery runs several queries built with Query as named blocks of a
+	// single request and returns each block's raw JSON results keyed by its
+	// name. Each query is renamed to its key in queries.
+	MultiQuery(ctx context.Context, queries map[string]*dg.Query) (map[string]json.RawMessage, error)
+
 	// Delete removes objects with the specified UIDs from the database.
 	Delete(context.Context, []string) error
 
+	// DeleteCount removes the nodes with the specified UIDs like Delete,
+	// returning how many of them existed and were deleted.
+	DeleteCount(ctx context.Context, uids []string) (int, error)
+
+	// DeleteBy removes every node of model's type whose predicate field
+	// equals value, returning how many were deleted.
+	DeleteBy(ctx context.Context, model any, field, value string) (int, error)
+
+	// ImportGeoJSON inserts each feature of a GeoJSON FeatureCollection as a
+	// node of Dgraph type typeName, storing its geometry and properties, and
+	// returns the number of nodes created.
+	ImportGeoJSON(ctx context.Context, featureCollection []byte, typeName string) (int, error)
+
+	// ExportRDF writes every triple in the database to w as N-Quads, which
+	// together with GetSchema can be loaded by Engine.Load or Dgraph's live
+	// and bulk loaders.
+	ExportRDF(ctx context.Context, w io.Writer) error
+
 	// Close releases all resources used by the client.
 	// It should be called when the client is no longer needed.
 	Close()
@@ -79,12 +168,48 @@ type Client interface {
 	// Pass one or more objects that will be used as templates for the schema.
 	UpdateSchema(context.Context, ...any) error
 
+	// RegisterModels applies the schema of every model type of an application
+	// in one UpdateSchema call, reverse edges and embedding vector predicates
+	// included. Models passed more than once are registered once. Before
+	// changing anything it checks that the models, and the edge types they
+	// reference, agree on the definition of each predicate they share, and
+	// returns a SchemaConflictError for each one they do not.
+	RegisterModels(ctx context.Context, models ...any) error
+
 	// AlterSchema applies a raw Dgraph Schema Definition Language string directly,
 	// bypassing the object-template inference of UpdateSchema. Use it when you need
 	// full control over predicate types, indexes, and directives — for example,
 	// schema migrations that declare predicates no Go type models yet.
 	AlterSchema(ctx context.Context, schema string) error
 
+	// ApplySchemaString applies a hand-written Dgraph schema (predicates and
+	// type definitions) as-is. It is equivalent to AlterSchema and is provided
+	// alongside ApplySchemaFile for teams that maintain their schema outside Go.
+	ApplySchemaString(ctx context.Context, schema string) error
+
+	// ApplySchemaFile reads a Dgraph schema file (for example a .schema or
+	// .graphql file holding DQL schema definitions) and applies it as-is.
+	ApplySchemaFile(ctx context.Context, path string) error
+
+	// ValidateSchemaCompatibility reports, without changing anything, the
+	// predicates whose type in the models' schema conflicts with the data
+	// already stored under them, such as a field now declared int whose
+	// existing values are strings. UpdateSchema leaves existing predicates as
+	// they are, so such a change only reaches the database through an explicit
+	// alter; check it with this first.
+	ValidateSchemaCompatibility(ctx context.Context, models ...any) ([]Incompatibility, error)
+
+	// Introspect describes the schema in structured form: every type with its
+	// fields and every predicate with its value type and directives.
+	Introspect(ctx context.Context) (Introspection, error)
+
+	// ListPredicates returns the name of every predicate in the schema,
+	// sorted, leaving out Dgraph's own dgraph.* predicates.
+	ListPredicates(ctx context.Context) ([]string, error)
+
+	// HasPredicate reports whether the schema declares predicate.
+	HasPredicate(ctx context.Context, predicate string) (bool, error)
+
 	// GetSchema retrieves the current schema definition from the database.
 	// Returns a string containing the full schema in Dgraph Schema Definition Language.
 	GetSchema(context.Context) (string, error)
@@ -95,11 +220,48 @@ type Client interface {
 	// DropData removes all data from the database but keeps the schema intact.
 	DropData(context.Context) error
 
+	// DropPredicate removes one predicate from the schema together with its
+	// values on every node, leaving the other predicates intact. Dgraph's
+	// pre-defined dgraph.* predicates cannot be dropped.
+	DropPredicate(ctx context.Context, predicate string) error
+
 	// QueryRaw executes a raw Dgraph query with optional query variables.
 	// The `query` parameter is the Dgraph query string.
 	// The `vars` parameter is a map of variable names to their values, used to parameterize the query.
+	// Results may come from the WithQueryCache cache; see NoCache.
 	QueryRaw(context.Context, string, map[string]string) ([]byte, error)
 
+	// QueryRawWithMetrics executes a raw Dgraph query like QueryRaw and also
+	// returns the server-side latency breakdown and UID counts of the query.
+	// It bypasses the WithQueryCache cache.
+	QueryRawWithMetrics(context.Context, string, map[string]string) ([]byte, ResponseMetrics, error)
+
+	// ReadTs returns the timestamp of the embedded engine's latest commit, a
+	// read timestamp QueryAt can later read the graph as of. Remote clients
+	// get ErrEmbeddedOnly.
+	ReadTs(ctx context.Context) (uint64, error)
+
+	// QueryAt executes a raw Dgraph query like QueryRaw, reading the graph as
+	// it stood at readTs, a timestamp from ReadTs or CommitInfo.CommitTs:
+	// writes committed after it are not visible. readTs must not be ahead of
+	// the latest commit, and versions a garbage collection pass has discarded
+	// cannot be read back. Remote clients get ErrEmbeddedOnly, since Dgraph's
+	// client API does not take a read timestamp.
+	QueryAt(ctx context.Context, readTs uint64, q string, vars map[string]string) ([]byte, error)
+
+	// ShortestPath returns the UIDs on a shortest path from one node to
+	// another along edge, both ends included, with at most maxDepth edges
+	// (unbounded when maxDepth <= 0). It is empty when there is no path.
+	ShortestPath(ctx context.Context, from, to string, edge string, maxDepth int) ([]string, error)
+
+	// Tree fetches a node and the subtree below it along childEdge into obj,
+	// recursing at most maxDepth levels (unbounded when maxDepth <= 0).
+	Tree(ctx context.Context, rootUID, childEdge string, maxDepth int, obj any) error
+
+	// CountDistinct returns the number of distinct values field holds across
+	// the nodes of model's type.
+	CountDistinct(ctx context.Context, model any, field string) (int, error)
+
 	// DgraphClient returns a gRPC Dgraph client from the connection pool and a cleanup function.
 	// The cleanup function must be called when finished with the client to return it to the pool.
 	DgraphClient() (*dgo.Dgraph, func(), error)
@@ -151,17 +313,52 @@ type SelfValidator interface {
 // logger: the logger for the client.
 // validator: the validator instance for struct validation.
 // embeddingProvider: optional provider for automatic SimString vector embeddings.
+// tagName: an alternate struct tag read alongside the dgraph tag.
+// codec: optional serializer that bypasses dgman's reflection for the types it handles.
+// baseCtx: the parent context of background work done on the client's behalf.
+// recoverPanics: whether embedded engine panics are returned as errors.
+// errorTranslator: optional mapping applied to every error a Client method returns.
+// maxBlobSize: the largest Blob or []byte field a mutation may carry; 0 = no limit.
+// queryCacheSize, queryCacheTTL: the capacity and lifetime of cached QueryRaw results; 0 = no cache.
+// schemaModels: models whose schema NewClient applies once before returning the client.
+// decodePooling: whether Get and QueryInterface reuse their intermediate decode buffers.
+// sortedSchema: whether generated schema lists its predicates and types in name order.
+// indexProfile: the profile selecting which profile-tagged indexes generated schema declares.
+// skipUniqueCheck: whether embedded mutations skip the engine's @unique check.
+// edgeTimestampFacet: the facet the engine stamps with each new edge's creation time; "" = none.
+// lockWait: how long an embedded client waits for another process to release the data directory.
+// commitHook: optional function the engine calls after each commit with what it wrote.
+// namingStrategy: the convention the predicates of models are stored under.
 type clientOptions struct {
-	autoSchema        bool
-	poolSize          int
-	maxEdgeTraversal  int
-	cacheSizeMB       int
-	maxRecvMsgSize    int
-	grpcDialOptions   []grpc.DialOption
-	namespace         string
-	logger            logr.Logger
-	validator         StructValidator
-	embeddingProvider EmbeddingProvider
+	autoSchema         bool
+	poolSize           int
+	maxEdgeTraversal   int
+	cacheSizeMB        int
+	maxRecvMsgSize     int
+	grpcDialOptions    []grpc.DialOption
+	namespace          string
+	logger             logr.Logger
+	validator          StructValidator
+	embeddingProvider  EmbeddingProvider
+	tagName            string
+	codec              Codec
+	baseCtx            context.Context
+	recoverPanics      bool
+	blankNodePrefix    string
+	duplicatePolicy    DuplicatePolicy
+	errorTranslator    ErrorTranslator
+	maxBlobSize        int
+	queryCacheSize     int
+	queryCacheTTL      time.Duration
+	schemaModels       []any
+	decodePooling      bool
+	sortedSchema       bool
+	indexProfile       string
+	skipUniqueCheck    bool
+	edgeTimestampFacet string
+	lockWait           time.Duration
+	commitHook         func(context.Context, CommitInfo)
+	namingStrategy     NamingStrategy
 }
 
 // ClientOpt is a function that configures a client
@@ -235,6 +432,16 @@ func WithGRPCDialOption(opt grpc.DialOption) ClientOpt {
 	}
 }
 
+// WithDialer sets the function that opens the network connections of a
+// remote (dgraph://) client, for environments gRPC's own dialer cannot reach
+// directly: a SOCKS proxy, a service-mesh sidecar, or a unix socket. dial
+// receives the resolved address of the Dgraph endpoint. It is applied as a
+// grpc.WithContextDialer option alongside any WithGRPCDialOption. Ignored for
+// embedded (file://) URIs.
+func WithDialer(dial func(ctx context.Context, addr string) (net.Conn, error)) ClientOpt {
+	return WithGRPCDialOption(grpc.WithContextDialer(dial))
+}
+
 // WithValidator sets a validator instance for struct validation.
 // The validator will be used to validate structs before insert, upsert, and update operations.
 // If no validator is provided, validation will be skipped.
@@ -257,6 +464,232 @@ func WithEmbeddingProvider(p EmbeddingProvider) ClientOpt {
 	}
 }
 
+// WithTagName sets an alternate struct tag whose directives are honored
+// alongside the `dgraph` tag — for example "db", so structs written for the
+// modusDB API (`db:"constraint=unique"`) carry the same meaning through this
+// client. A `constraint=<name>` token maps to the dgraph directive of the same
+// name; other tokens are read as dgraph directives. UpdateSchema (and
+// therefore AutoSchema) applies the resulting indexes and constraints, and
+// LoadAndDelete resolves its default key from an alternate-tag upsert field.
+func WithTagName(name string) ClientOpt {
+	return func(o *clientOptions) {
+		o.tagName = name
+	}
+}
+
+// WithCodec sets a Codec that serializes the types it handles without dgman's
+// per-call reflection walk. Insert encodes values of those types through the
+// codec and Get decodes them through it; every other type is unaffected. See
+// NewCachedCodec for a codec that precompiles its reflection per type.
+func WithCodec(codec Codec) ClientOpt {
+	return func(o *clientOptions) {
+		o.codec = codec
+	}
+}
+
+// WithBaseContext sets the parent context of the work done on the client's
+// behalf outside any call that takes a context — for embedded (file://)
+// databases, the engine's background tasks such as periodic GC. Canceling ctx
+// stops that work; the client stays usable until Close.
+func WithBaseContext(ctx context.Context) ClientOpt {
+	return func(o *clientOptions) {
+		o.baseCtx = ctx
+	}
+}
+
+// WithRecover makes an embedded (file://) client recover from panics raised
+// inside the engine while serving a call, returning them as errors wrapping
+// ErrEnginePanic instead of crashing the process. It has no effect on remote
+// clients.
+func WithRecover(enable bool) ClientOpt {
+	return func(o *clientOptions) {
+		o.recoverPanics = enable
+	}
+}
+
+// WithBlankNodePrefix makes InsertRaw prefix every caller-set blank node
+// name, so "_:entity-1" is sent as "_:<prefix>entity-1". Dgraph scopes blank
+// nodes to a single mutation: within one InsertRaw call a repeated name refers
+// to one node, while the same name in two calls always creates two nodes. The
+// prefix keeps names from separate batches distinct wherever they meet
+// outside that scope, such as in logs or when batches are later combined.
+func WithBlankNodePrefix(prefix string) ClientOpt {
+	return func(o *clientOptions) {
+		o.blankNodePrefix = prefix
+	}
+}
+
+// WithDuplicatePolicy sets what Insert does when a slice repeats a value for a
+// predicate tagged dgraph:"unique": fail the batch (DuplicateError, the
+// default), insert only the first element carrying it (DuplicateSkip), or
+// merge the duplicates into that first element (DuplicateMerge). Dropped
+// elements receive the UID of the element they duplicated.
+func WithDuplicatePolicy(policy DuplicatePolicy) ClientOpt {
+	return func(o *clientOptions) {
+		o.duplicatePolicy = policy
+	}
+}
+
+// WithNamingStrategy stores the predicates of models under the names strategy
+// derives from their json names (or predicate= names), so every predicate
+// follows one convention: under NamingSnakeCase a workspaceID field is the
+// workspace_id predicate. Generated schema declares the derived names,
+// mutations write them, and query results are decoded back into the models.
+// Queries built from models, and their filters, may name a predicate by
+// either name; hand-written schema, DropPredicate, and queries that name a
+// predicate of a model the client has not yet read or written use the
+// derived name. The default, NamingAsIs, derives no names.
+func WithNamingStrategy(strategy NamingStrategy) ClientOpt {
+	return func(o *clientOptions) {
+		o.namingStrategy = strategy
+	}
+}
+
+// WithErrorTranslator passes every non-nil error a Client method returns
+// through translate before the caller sees it, so backend errors whose text
+// differs between Dgraph versions can be mapped to domain errors in one place.
+// Translation happens only at the Client boundary; the client's internal
+// checks, such as retrying aborted transactions, see the original errors. A
+// translator that wraps its input with %w keeps errors.Is and errors.As
+// working on the original error, which WithRetry relies on to recognize
+// aborted transactions returned by fn.
+func WithErrorTranslator(translate ErrorTranslator) ClientOpt {
+	return func(o *clientOptions) {
+		o.errorTranslator = translate
+	}
+}
+
+// WithMaxBlobSize makes Insert, InsertRaw, Upsert, Update, and the other
+// writes that validate their object reject one whose Blob or []byte fields,
+// including those of nodes reached over its edges, hold more than size bytes.
+// The error wraps ErrBlobTooLarge and names the field. A size of zero, the
+// default, sets no limit. Blobs are stored base64-encoded, so a remote client
+// reading back a blob of n bytes receives more than 4n/3; keep the limit
+// below the gRPC receive limit (4 MB by default, see WithMaxRecvMsgSize). The
+// embedded engine has no message limit.
+func WithMaxBlobSize(size int) ClientOpt {
+	return func(o *clientOptions) {
+		o.maxBlobSize = size
+	}
+}
+
+// WithQueryCache caches the results of QueryRaw, and of QueryInterface and
+// MultiQuery, which run on it, for read-heavy workloads that repeat the same
+// queries. Up to size results are kept, keyed by namespace, query text, and
+// variables, each for ttl after it was fetched; the least recently used is
+// evicted first. A cached result does not see writes made since it was
+// fetched, including this client's own, so read through NoCache(ctx) where a
+// fresh result is required. A size or ttl of zero, the default, disables
+// the cache.
+func WithQueryCache(size int, ttl time.Duration) ClientOpt {
+	return func(o *clientOptions) {
+		o.queryCacheSize = size
+		o.queryCacheTTL = ttl
+	}
+}
+
+// WithSchema applies the schema for the given models once, when NewClient
+// creates the client, so the intended predicates, indexes, and types are in
+// place before the first mutation instead of being derived per mutation by
+// WithAutoSchema or left to a manual UpdateSchema call. NewClient fails if the
+// schema cannot be applied.
+func WithSchema(models ...any) ClientOpt {
+	return func(o *clientOptions) {
+		o.schemaModels = append(o.schemaModels, models...)
+	}
+}
+
+// WithDecodePooling makes Get and QueryInterface reuse, through a sync.Pool,
+// the buffers that hold the nodes' JSON between reading it and decoding it
+// into the caller's values, rather than allocating them on every call. Get
+// holds such a buffer only for the types a Codec decodes. The decoded values
+// never share memory with the pooled buffers. The saving grows with the size
+// of the result; it is off by default.
+func WithDecodePooling(enable bool) ClientOpt {
+	return func(o *clientOptions) {
+		o.decodePooling = enable
+	}
+}
+
+// WithSortedSchema makes the schema the client generates from Go types,
+// through UpdateSchema and WithAutoSchema, list its predicates, types, and
+// type fields in name order rather than in Go's map order, so the same models
+// always produce the same schema text, which keeps logged or captured DQL
+// reproducible between runs. The applied schema is the same either way, and
+// mutations are not reordered.
+func WithSortedSchema(enable bool) ClientOpt {
+	return func(o *clientOptions) {
+		o.sortedSchema = enable
+	}
+}
+
+// WithIndexProfile selects the indexes the schema generated from Go types
+// declares. A field whose dgraph tag carries a profile= directive keeps its
+// index only under the profiles it names, so an expensive index can be left
+// out of test or development databases:
+//
+//	Embedding *dg.VectorFloat32 `json:"embedding,omitempty" dgraph:"index=hnsw(metric:\"cosine\") profile=prod"`
+//
+// Under WithIndexProfile("test") the predicate is declared without its HNSW
+// index. Several profiles are separated by commas (profile=prod,staging).
+// Fields without a profile= directive are indexed under every profile, and
+// without WithIndexProfile every index applies.
+func WithIndexProfile(name string) ClientOpt {
+	return func(o *clientOptions) {
+		o.indexProfile = name
+	}
+}
+
+// WithUniquenessCheck(false) makes an embedded (file://) client skip the
+// engine's @unique check on its mutations. The check looks up every unique
+// value written, which is a large share of the cost of batch inserts; turn it
+// off for bulk imports whose caller guarantees the values are unique.
+// Duplicates written with the check off are stored as they are. The check is
+// on by default. Remote Dgraph clusters enforce @unique themselves and ignore
+// this option.
+func WithUniquenessCheck(enable bool) ClientOpt {
+	return func(o *clientOptions) {
+		o.skipUniqueCheck = !enable
+	}
+}
+
+// WithEdgeTimestamps makes an embedded (file://) client's engine stamp every
+// edge a mutation creates with a datetime facet named facet, holding the time
+// the engine applied the mutation, so relationship history is kept without the
+// application writing it. An edge that is written again keeps its original
+// stamp, and an edge whose mutation already sets the facet keeps that value.
+// Read the stamps back with @facets, or with Facets on a typed edge query.
+// Remote Dgraph clusters cannot stamp edges, so NewClient rejects the option
+// for dgraph:// URIs.
+func WithEdgeTimestamps(facet string) ClientOpt {
+	return func(o *clientOptions) {
+		o.edgeTimestampFacet = facet
+	}
+}
+
+// WithLockWait makes an embedded (file://) client wait up to d for another
+// process to release the database directory before giving up with
+// ErrDataDirLocked, so a test run can start while the binary of the previous
+// run is still exiting. Without it NewClient fails at once; remote clients
+// ignore the option.
+func WithLockWait(d time.Duration) ClientOpt {
+	return func(o *clientOptions) {
+		o.lockWait = d
+	}
+}
+
+// WithCommitHook makes an embedded (file://) client call hook after each
+// commit with the UIDs and predicates it wrote, to feed a change-data-capture
+// pipeline such as a search index or a cache; see CommitInfo. A transaction's
+// writes are reported together when it commits. hook runs on the committing
+// goroutine after the engine releases its locks, so it may read the database
+// but delays the write that triggered it. Remote clients ignore the option.
+func WithCommitHook(hook func(ctx context.Context, info CommitInfo)) ClientOpt {
+	return func(o *clientOptions) {
+		o.commitHook = hook
+	}
+}
+
 // NewValidator creates a new validator instance with default settings.
 // This is a convenience function for creating a validator to use with WithValidator.
 // It returns a *validator.Validate from github.com/go-playground/validator/v10.
@@ -278,6 +711,16 @@ func NewValidator() *validator.Validate {
 //   - WithLogger(logr.Logger) - Configure structured logging with custom verbosity levels
 //   - WithCacheSizeMB(int) - Set the memory cache size in MB (only applicable for embedded databases)
 //   - WithValidator(*validator.Validate) - Set a validator instance for struct validation before mutations
+//   - WithTagName(string) - Honor an alternate struct tag (e.g. "db") alongside the dgraph tag
+//   - WithCodec(Codec) - Serialize specific types without per-call reflection
+//   - WithDialer(func(context.Context, string) (net.Conn, error)) - Open remote connections through a custom dialer
+//   - WithBaseContext(context.Context) - Set the parent context of background work
+//   - WithRecover(bool) - Return embedded engine panics as errors
+//   - WithUniquenessCheck(bool) - Skip the embedded engine's @unique check for trusted bulk imports
+//   - WithEdgeTimestamps(string) - Stamp each new edge with its creation time in a facet (embedded only)
+//   - WithLockWait(time.Duration) - Wait for another process to release the database directory (embedded only)
+//   - WithCommitHook(func(context.Context, CommitInfo)) - Report each commit's writes (embedded only)
+//   - WithNamingStrategy(NamingStrategy) - Derive predicate names following a naming convention
 //
 // The returned Client provides a consistent interface regardless of whether you're
 // connected to a remote Dgraph cluster or a local embedded database. This abstraction
@@ -294,6 +737,7 @@ func NewClient(uri string, opts ...ClientOpt) (Client, error) {
 		maxEdgeTraversal: 10,
 		cacheSizeMB:      64,             // 64 MB
 		logger:           logr.Discard(), // No-op logger by default
+		baseCtx:          context.Background(),
 	}
 
 	// Apply provided options
@@ -301,6 +745,15 @@ func NewClient(uri string, opts ...ClientOpt) (Client, error) {
 		opt(&options)
 	}
 
+	if options.edgeTimestampFacet != "" {
+		if !facetNameRegex.MatchString(options.edgeTimestampFacet) {
+			return nil, fmt.Errorf("invalid edge timestamp facet %q", options.edgeTimestampFacet)
+		}
+		if !strings.HasPrefix(uri, fileURIPrefix) {
+			return nil, errors.New("edge timestamps require an embedded (file://) client")
+		}
+	}
+
 	// TODO: implement namespace support for v25
 	if options.namespace != "" {
 		options.logger.Info("Warning, namespace is set, but it is not supported in this version")
@@ -312,6 +765,10 @@ func NewClient(uri string, opts ...ClientOpt) (Client, error) {
 		logger:    options.logger,
 		consumeMu: &sync.Mutex{},
 	}
+	if options.queryCacheSize > 0 && options.queryCacheTTL > 0 {
+		client.queryCache = newQueryCache(options.queryCacheSize, options.queryCacheTTL)
+	}
+	client.names = newPredicateNames(options.namingStrategy, options.tagName)
 
 	clientMapLock.Lock()
 	defer clientMapLock.Unlock()
@@ -334,6 +791,9 @@ func NewClient(uri string, opts ...ClientOpt) (Client, error) {
 				grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(options.maxRecvMsgSize)))
 		}
 		dialOpts = append(dialOpts, options.grpcDialOptions...)
+		if client.names != nil {
+			dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(client.names.unaryInterceptor))
+		}
 		if len(dialOpts) > 0 {
 			endpoint, dgoOpts, err := parseDgraphURI(uri)
 			if err != nil {
@@ -351,19 +811,23 @@ func NewClient(uri string, opts ...ClientOpt) (Client, error) {
 		}
 		client.pool = newClientPool(options.poolSize, factory, client.logger)
 		dg.SetLogger(client.logger)
-		clientMap[key] = client
-		return client, nil
+		if err := client.applyStartupSchema(); err != nil {
+			return nil, err
+		}
+		clientMap[key] = client.public()
+		return clientMap[key], nil
 	case strings.HasPrefix(uri, fileURIPrefix):
 		// parse off the file:// prefix
 		uri = uri[len(fileURIPrefix):]
 		if _, err := os.Stat(uri); err != nil {
 			return nil, err
 		}
-		engine, err := NewEngine(Config{
-			dataDir:     uri,
-			logger:      client.logger,
-			cacheSizeMB: options.cacheSizeMB,
-		})
+		engine, err := NewEngine(NewDefaultConfig(uri).
+			WithLogger(client.logger).
+			WithCacheSizeMB(options.cacheSizeMB).
+			WithBaseContext(options.baseCtx).
+			WithLockWait(options.lockWait).
+			WithCommitHook(options.commitHook))
 		if err != nil {
 			return nil, err
 		}
@@ -383,18 +847,40 @@ func NewClient(uri string, opts ...ClientOpt) (Client, error) {
 			}
 		}
 		client.pool = newClientPool(1, func() (*dgo.Dgraph, error) {
-			embeddedClient := newEmbeddedDgraphClient(engine, ns)
+			var embeddedClient api.DgraphClient = newEmbeddedDgraphClient(engine, ns, options.recoverPanics,
+				options.skipUniqueCheck, options.edgeTimestampFacet)
+			if client.names != nil {
+				embeddedClient = namingDgraphClient{embeddedClient, client.names}
+			}
 			//nolint:staticcheck // dgo.NewDgraphClient is deprecated but required for embedded client
 			return dgo.NewDgraphClient(embeddedClient), nil
 		}, client.logger)
 		dg.SetLogger(client.logger)
-		clientMap[key] = client
-		return client, nil
+		if err := client.applyStartupSchema(); err != nil {
+			return nil, err
+		}
+		clientMap[key] = client.public()
+		return clientMap[key], nil
 	}
 	return nil, errors.New("invalid uri")
 
 }
 
+// applyStartupSchema applies the schema for the models given to WithSchema,
+// closing the client if that fails so NewClient does not leak it.
+func (c client) applyStartupSchema() error {
+	if len(c.options.schemaModels) == 0 {
+		return nil
+	}
+	// UpdateSchema unwraps its arguments in place; keep the options intact.
+	models := append([]any(nil), c.options.schemaModels...)
+	if err := c.UpdateSchema(c.options.baseCtx, models...); err != nil {
+		c.Close()
+		return fmt.Errorf("failed to apply startup schema: %w", err)
+	}
+	return nil
+}
+
 // parseDgraphURI mirrors dgo.Open's connection-string parsing so callers can
 // route through dgo.NewClient with additional dgo.ClientOption values (e.g.
 // custom grpc.DialOption settings). It returns the host:port endpoint and the
@@ -485,6 +971,12 @@ type client struct {
 	// single-winner semantics against the embedded engine, whose commit path
 	// performs no optimistic-concurrency conflict check.
 	consumeMu *sync.Mutex
+	// queryCache holds QueryRaw results when WithQueryCache is set; nil
+	// otherwise.
+	queryCache *queryCache
+	// names derives predicate names when WithNamingStrategy is set; nil
+	// otherwise.
+	names *predicateNames
 }
 
 func (c client) key() string {
@@ -496,6 +988,18 @@ func (c client) key() string {
 	if c.options.embeddingProvider != nil {
 		embeddingKey = fmt.Sprintf("%p", c.options.embeddingProvider)
 	}
+	codecKey := "nil"
+	if c.options.codec != nil {
+		codecKey = fmt.Sprintf("%p", c.options.codec)
+	}
+	translatorKey := "nil"
+	if c.options.errorTranslator != nil {
+		translatorKey = fmt.Sprintf("%p", c.options.errorTranslator)
+	}
+	commitHookKey := "nil"
+	if c.options.commitHook != nil {
+		commitHookKey = fmt.Sprintf("%p", c.options.commitHook)
+	}
 	// Custom gRPC dial options only apply to remote (dgraph://) connections;
 	// they are ignored for embedded (file://) URIs, so they only contribute to
 	// the dedup key for remote clients — matching that documented behavior.
@@ -503,9 +1007,36 @@ func (c client) key() string {
 	if strings.HasPrefix(c.uri, dgraphURIPrefix) {
 		dialKey = dialOptionsKey(c.options.grpcDialOptions)
 	}
-	return fmt.Sprintf("%s:%t:%d:%d:%d:%d:%s:%s:%s:%s", c.uri, c.options.autoSchema, c.options.poolSize,
-		c.options.maxEdgeTraversal, c.options.cacheSizeMB, c.options.maxRecvMsgSize,
-		c.options.namespace, validatorKey, embeddingKey, dialKey)
+	schemaKey := make([]string, len(c.options.schemaModels))
+	for i, m := range c.options.schemaModels {
+		schemaKey[i] = fmt.Sprintf("%T", m)
+	}
+	// Derived contexts are pointers, so %p tells two parents apart;
+	// context.Background() is a value, the same for every client.
+	baseCtxKey := "nil"
+	if ctx := c.options.baseCtx; ctx != nil {
+		baseCtxKey = fmt.Sprintf("%T", ctx)
+		if reflect.ValueOf(ctx).Kind() == reflect.Pointer {
+			baseCtxKey = fmt.Sprintf("%p", ctx)
+		}
+	}
+	return fmt.Sprintf("%s:%t:%d:%d:%d:%d:%s:%s:%s:%s:%s:%s:%s:%d:%s:%d:%d:%s:%s:%t:%t:%s:%t:%s:%s:%s:%t:%s:%s", c.uri, c.options.autoSchema,
+		c.options.poolSize, c.options.maxEdgeTraversal, c.options.cacheSizeMB, c.options.maxRecvMsgSize,
+		c.options.namespace, validatorKey, embeddingKey, dialKey, c.options.tagName, codecKey,
+		c.options.blankNodePrefix, c.options.duplicatePolicy, translatorKey, c.options.maxBlobSize,
+		c.options.queryCacheSize, c.options.queryCacheTTL, strings.Join(schemaKey, ","), c.options.decodePooling,
+		c.options.sortedSchema, c.options.indexProfile, c.options.skipUniqueCheck,
+		c.options.edgeTimestampFacet, c.options.namingStrategy, baseCtxKey, c.options.recoverPanics,
+		commitHookKey, c.options.lockWait)
+}
+
+// public returns the Client NewClient hands out for c: c itself, or c
+// wrapped to translate its errors when WithErrorTranslator is set.
+func (c client) public() Client {
+	if c.options.errorTranslator != nil {
+		return translatingClient{c}
+	}
+	return c
 }
 
 // dialOptionsKey identifies a set of custom gRPC dial options for the client
@@ -556,12 +1087,21 @@ func checkPointer(obj any) error {
 func (c client) validateStruct(ctx context.Context, obj any) error {
 	// Handle both single structs and slices
 	val := reflect.ValueOf(obj)
+	// The checks below, the validator, and dgman all walk the edges.
+	if err := checkCycles(val); err != nil {
+		return err
+	}
 	if val.Kind() == reflect.Pointer {
 		if val.IsNil() {
 			return fmt.Errorf("cannot validate nil pointer")
 		}
 		val = val.Elem()
 	}
+	if c.options.maxBlobSize > 0 {
+		if err := checkBlobSizes(val, c.options.maxBlobSize); err != nil {
+			return err
+		}
+	}
 
 	if val.Kind() == reflect.Slice {
 		for i := 0; i < val.Len(); i++ {
@@ -604,22 +1144,45 @@ func (c client) validateOne(ctx context.Context, val reflect.Value) error {
 
 // Insert implements inserting an object or slice of objects in the database.
 // Passed object must be a pointer to a struct with appropriate dgraph tags.
+// Objects the configured Codec handles are encoded by it instead of dgman.
+// A slice is first reduced under the client's DuplicatePolicy. An edge target
+// with no UID that sets only unique fields links to the existing node holding
+// those values, if there is one, rather than inserting a duplicate.
 func (c client) Insert(ctx context.Context, obj any) error {
 	obj = UnwrapSchema(obj)
 	// Validate struct before insertion
 	if err := c.validateStruct(ctx, obj); err != nil {
 		return err
 	}
+	obj, finish := c.applyDuplicatePolicy(obj)
 
-	return c.process(ctx, obj, "Insert", func(tx *dg.TxnContext, obj any) ([]string, error) {
-		return tx.MutateBasic(obj)
-	})
+	var err error
+	if nodes, ok := c.codecNodes(obj); ok {
+		commitNow := !c.embeds(obj) && len(presetDTypes(obj)) == 0 && pendingTxnFrom(ctx) == nil
+		err = c.process(ctx, obj, "Insert", func(tx *dg.TxnContext, _ any) ([]string, error) {
+			return c.mutateCodec(tx, nodes, commitNow)
+		})
+	} else {
+		err = c.process(ctx, obj, "Insert", func(tx *dg.TxnContext, obj any) ([]string, error) {
+			if err := linkUniqueRefs(tx, obj, c.options.tagName); err != nil {
+				return nil, err
+			}
+			return tx.MutateBasic(obj)
+		})
+	}
+	if err != nil {
+		return err
+	}
+	finish()
+	return nil
 }
 
 // InsertRaw adds a new object or slice of objects to the database.
 // The object must be a pointer to a struct with appropriate dgraph tags.
 // The `UID` field for any objects must be set using the Dgraph blank node
 // prefix concept (e.g. "_:user1") to allow the engine to generate a UID for the object.
+// Blank node names are scoped to the one mutation InsertRaw sends; WithBlankNodePrefix
+// prefixes them.
 //
 // Deprecated: InsertRaw is now identical to Insert. Use Insert instead.
 func (c client) InsertRaw(ctx context.Context, obj any) error {
@@ -628,16 +1191,23 @@ func (c client) InsertRaw(ctx context.Context, obj any) error {
 	if err := c.validateStruct(ctx, obj); err != nil {
 		return err
 	}
+	restore := prefixBlankNodes(obj, c.options.blankNodePrefix)
 
-	return c.process(ctx, obj, "Insert", func(tx *dg.TxnContext, obj any) ([]string, error) {
+	err := c.process(ctx, obj, "Insert", func(tx *dg.TxnContext, obj any) ([]string, error) {
 		return tx.MutateBasic(obj)
 	})
+	if err != nil {
+		restore()
+	}
+	return err
 }
 
 // Upsert implements inserting or updating an object or slice of objects in the database.
 // Note that the struct tag `upsert` must be used. One or more predicates can be specified
 // to be used for upserting. If none are specified, the first predicate with the `upsert` tag
-// will be used.
+// will be used. When an object's UID is already a node's UID, that node is
+// updated and the upsert predicates are not matched; unique predicates are
+// still checked against every other node.
 func (c client) Upsert(ctx context.Context, obj any, predicates ...string) error {
 	obj = UnwrapSchema(obj)
 	// Validate struct before upsert
@@ -645,89 +1215,358 @@ func (c client) Upsert(ctx context.Context, obj any, predicates ...string) error
 		return err
 	}
 
+	if nodes := sliceNodes(obj); len(nodes) > 1 && strings.HasPrefix(c.uri, dgraphURIPrefix) {
+		return c.upsertSlice(ctx, obj, nodes, predicates...)
+	}
 	return c.process(ctx, obj, "Upsert", func(tx *dg.TxnContext, obj any) ([]string, error) {
 		return tx.Upsert(obj, predicates...)
 	})
 }
 
-// LoadOrStore stores obj only if no node already matches the upsert predicates,
-// reporting whether one already existed (loaded == true). Built on dgman
-// MutateOrGet, which returns the UIDs of newly created nodes only: an empty
-// result means an existing node matched, and obj is populated with its fields.
-// With no predicates, the first field tagged dgraph:"upsert" is used.
-func (c client) LoadOrStore(ctx context.Context, obj any, predicates ...string) (loaded bool, err error) {
+// upsertSlice upserts the elements of a slice one at a time within a single
+// transaction, for remote clients, where Dgraph rejects dgman's combined
+// upsert of a slice. A transaction aborted by a concurrent writer is retried
+// per DefaultRetryPolicy, after clearing the UIDs it assigned.
+func (c client) upsertSlice(ctx context.Context, obj any, nodes []any, predicates ...string) error {
+	uids := make([]string, len(nodes))
+	for i, n := range nodes {
+		uids[i] = uidOf(n)
+	}
+	return c.WithRetry(ctx, DefaultRetryPolicy, func() error {
+		err := c.processTxn(ctx, obj, "Upsert", true, func(tx *dg.TxnContext, _ any) ([]string, error) {
+			var assigned []string
+			for _, n := range nodes {
+				got, err := tx.Upsert(n, predicates...)
+				if err != nil {
+					return nil, err
+				}
+				assigned = append(assigned, got...)
+			}
+			return assigned, nil
+		})
+		if err != nil {
+			for i, n := range nodes {
+				setUIDValue(n, uids[i])
+			}
+		}
+		return err
+	})
+}
+
+// sliceNodes returns the elements of obj when it is a slice, or a pointer to
+// a slice, of struct pointers or structs, each struct addressed in the
+// caller's slice.
+func sliceNodes(obj any) []any {
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Slice {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return nil
+	}
+	nodes := make([]any, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		switch {
+		case elem.Kind() == reflect.Struct:
+			// Addressed in place, as checkObject does, so UIDs written to
+			// the node reach the caller's element.
+			nodes = append(nodes, elem.Addr().Interface())
+		case elem.Kind() == reflect.Pointer && !elem.IsNil():
+			nodes = append(nodes, elem.Interface())
+		default:
+			return nil
+		}
+	}
+	return nodes
+}
+
+// UpsertReturnOld upserts obj and captures the matched node's prior state in
+// old. The read and the upsert share one transaction, so old is the state the
+// upsert overwrote; on the embedded engine, which does no commit-time conflict
+// check, concurrent callers are serialized like LoadAndDelete. With no
+// predicates, the first field tagged dgraph:"upsert" is used.
+func (c client) UpsertReturnOld(ctx context.Context, obj any, old any, predicates ...string) (created bool, err error) {
 	obj = UnwrapSchema(obj)
-	// Reject nil / non-pointer input up front, as LoadAndDelete and Get do:
-	// validateStruct is a no-op when no validator is configured, so without this
-	// a typed nil or non-pointer would reach MutateOrGet and panic or silently
-	// fail to hydrate on the loaded=true path.
+	old = UnwrapSchema(old)
 	if err := checkPointer(obj); err != nil {
 		return false, err
 	}
+	if err := checkPointer(old); err != nil {
+		return false, err
+	}
+	if reflect.TypeOf(obj) != reflect.TypeOf(old) {
+		return false, fmt.Errorf("UpsertReturnOld: old must be a %T, got %T", obj, old)
+	}
 	if err := c.validateStruct(ctx, obj); err != nil {
 		return false, err
 	}
 
-	dgClient, err := c.pool.get()
+	if len(predicates) == 0 {
+		if pred := firstUpsertPredicate(obj, c.options.tagName); pred != "" {
+			predicates = []string{pred}
+		}
+	}
+	if len(predicates) == 0 {
+		return false, fmt.Errorf("UpsertReturnOld: no upsert predicate (pass one or tag a field dgraph:\"upsert\")")
+	}
+	conds := make([]string, 0, len(predicates))
+	params := make([]any, 0, len(predicates))
+	for _, pred := range predicates {
+		if !isValidPredicateName(pred) {
+			return false, fmt.Errorf("UpsertReturnOld: invalid upsert predicate %q", pred)
+		}
+		value, ok := predicateValue(obj, pred, c.options.tagName)
+		if !ok {
+			return false, fmt.Errorf("UpsertReturnOld: %T has no value for upsert predicate %q", obj, pred)
+		}
+		params = append(params, value)
+		conds = append(conds, fmt.Sprintf("eq(%s, $%d)", pred, len(params)))
+	}
+	filter := strings.Join(conds, " OR ")
+
+	if c.engine != nil && c.consumeMu != nil {
+		c.consumeMu.Lock()
+		defer c.consumeMu.Unlock()
+	}
+
+	err = c.process(ctx, obj, "UpsertReturnOld", func(tx *dg.TxnContext, obj any) ([]string, error) {
+		zeroValue(old)
+		getErr := tx.Get(old).
+			Filter(filter, params...).
+			All(c.options.maxEdgeTraversal).
+			Node()
+		switch {
+		case errors.Is(getErr, dg.ErrNodeNotFound):
+			created = true
+			zeroValue(old)
+		case getErr != nil:
+			return nil, getErr
+		}
+		return tx.Upsert(obj, predicates...)
+	})
 	if err != nil {
-		c.logger.Error(err, "Failed to get client from pool")
 		return false, err
 	}
-	defer c.pool.put(dgClient)
+	return created, nil
+}
 
-	tx := dg.NewTxnContext(ctx, dgClient).SetCommitNow()
-	uids, err := tx.MutateOrGet(obj, predicates...)
-	if err != nil {
-		if uniqueErr := parseUniqueError(err); uniqueErr != nil {
-			return false, uniqueErr
+// UpsertIf implements a compare-and-set upsert. The condition check and the
+// upsert share one transaction, so a concurrent writer that changes the node
+// in between makes the commit abort rather than slip past the condition; on
+// the embedded engine, which does no commit-time conflict check, concurrent
+// callers are serialized like LoadAndDelete. condition is interpolated into
+// the query's @filter verbatim, so it must not be built from untrusted input.
+// With an empty predicate, the first field tagged dgraph:"upsert" is used.
+func (c client) UpsertIf(ctx context.Context, obj any, predicate, condition string) (applied bool, err error) {
+	obj = UnwrapSchema(obj)
+	if err := checkPointer(obj); err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(condition) == "" {
+		return false, errors.New("UpsertIf: condition must not be empty")
+	}
+	if err := c.validateStruct(ctx, obj); err != nil {
+		return false, err
+	}
+
+	if predicate == "" {
+		predicate = firstUpsertPredicate(obj, c.options.tagName)
+	}
+	if predicate == "" {
+		return false, fmt.Errorf("UpsertIf: no upsert predicate (pass one or tag a field dgraph:\"upsert\")")
+	}
+	if !isValidPredicateName(predicate) {
+		return false, fmt.Errorf("UpsertIf: invalid upsert predicate %q", predicate)
+	}
+	value, ok := predicateValue(obj, predicate, c.options.tagName)
+	if !ok {
+		return false, fmt.Errorf("UpsertIf: %T has no value for upsert predicate %q", obj, predicate)
+	}
+	match := "eq(" + predicate + ", $1)"
+
+	if c.engine != nil && c.consumeMu != nil {
+		c.consumeMu.Lock()
+		defer c.consumeMu.Unlock()
+	}
+
+	err = c.process(ctx, obj, "UpsertIf", func(tx *dg.TxnContext, obj any) ([]string, error) {
+		var existing []struct {
+			UID string `json:"uid"`
+		}
+		if err := tx.Get(obj).Filter(match, value).Query("{ uid }").Nodes(&existing); err != nil {
+			return nil, err
+		}
+		if len(existing) > 0 {
+			var satisfied []struct {
+				UID string `json:"uid"`
+			}
+			err := tx.Get(obj).
+				Filter(match+" AND ("+condition+")", value).
+				Query("{ uid }").
+				Nodes(&satisfied)
+			if err != nil {
+				return nil, err
+			}
+			if len(satisfied) == 0 {
+				return nil, errConditionNotMet
+			}
 		}
+		return tx.Upsert(obj, predicate)
+	})
+	if errors.Is(err, errConditionNotMet) {
+		return false, nil
+	}
+	if err != nil {
 		return false, err
 	}
-	// MutateOrGet returns created UIDs only; empty => an existing node matched.
-	return len(uids) == 0, nil
+	return true, nil
 }
 
-// firstUpsertPredicate returns the Dgraph predicate name of the first field
-// tagged dgraph:"...upsert...". The predicate defaults to the json tag name
-// unless an explicit predicate= token is present. It returns "" if no upsert
-// field exists.
-func firstUpsertPredicate(obj any) string {
+// errConditionNotMet stops UpsertIf's transaction before anything is written
+// when the stored node fails the condition.
+var errConditionNotMet = errors.New("upsert condition not met")
+
+// predicateValue returns the value obj holds for the predicate pred, reporting
+// false when no field maps to pred or the field is empty.
+func predicateValue(obj any, pred, altTag string) (any, bool) {
 	v := reflect.ValueOf(obj)
 	for v.Kind() == reflect.Pointer {
 		v = v.Elem()
 	}
-	// A nil pointer (or nil interface) dereferences to an invalid Value, and a
-	// non-struct has no fields; either way there is no upsert predicate, and
-	// calling Type()/NumField() on them would panic.
 	if !v.IsValid() || v.Kind() != reflect.Struct {
-		return ""
+		return nil, false
 	}
 	t := v.Type()
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
-		dgTag := f.Tag.Get("dgraph")
-		if !strings.Contains(dgTag, "upsert") {
+		if predicateName(f, fieldDirectives(f, altTag)) != pred {
 			continue
 		}
-		// Explicit predicate=<name> wins.
-		for _, directive := range strings.Fields(dgTag) {
-			if strings.HasPrefix(directive, "predicate=") {
-				return strings.TrimPrefix(directive, "predicate=")
-			}
-		}
-		// Otherwise fall back to the json tag name.
-		if jsonTag := f.Tag.Get("json"); jsonTag != "" {
-			return strings.Split(jsonTag, ",")[0]
+		if v.Field(i).IsZero() {
+			return nil, false
 		}
-		return f.Name
+		return v.Field(i).Interface(), true
 	}
-	return ""
+	return nil, false
 }
 
-// isValidPredicateName reports whether pred is safe to concatenate into a DQL
-// filter. LoadAndDelete builds "eq(<pred>, $1)" by string concatenation; the key
-// value is parameterized, but the predicate name is not, so a name containing DQL
-// metacharacters (parentheses, commas, whitespace, quotes, angle brackets, ...)
+// LoadOrStore stores obj only if no node already matches the upsert predicates,
+// reporting whether one already existed (loaded == true). Built on dgman
+// MutateOrGet, which returns the UIDs of newly created nodes only: an empty
+// result means an existing node matched, and obj is populated with its fields.
+// With no predicates, the first field tagged dgraph:"upsert" is used.
+func (c client) LoadOrStore(ctx context.Context, obj any, predicates ...string) (loaded bool, err error) {
+	obj = UnwrapSchema(obj)
+	// Reject nil / non-pointer input up front, as LoadAndDelete and Get do:
+	// validateStruct is a no-op when no validator is configured, so without this
+	// a typed nil or non-pointer would reach MutateOrGet and panic or silently
+	// fail to hydrate on the loaded=true path.
+	if err := checkPointer(obj); err != nil {
+		return false, err
+	}
+	if err := c.validateStruct(ctx, obj); err != nil {
+		return false, err
+	}
+
+	var tx *dg.TxnContext
+	if pending := pendingTxnFrom(ctx); pending != nil {
+		if tx, err = pending.join(ctx, c.pool); err != nil {
+			return false, err
+		}
+	} else {
+		dgClient, err := c.pool.get()
+		if err != nil {
+			c.logger.Error(err, "Failed to get client from pool")
+			return false, err
+		}
+		defer c.pool.put(dgClient)
+		tx = dg.NewTxnContext(ctx, dgClient).SetCommitNow()
+	}
+	uids, err := tx.MutateOrGet(obj, predicates...)
+	if err != nil {
+		if uniqueErr := parseUniqueError(err); uniqueErr != nil {
+			return false, uniqueErr
+		}
+		return false, err
+	}
+	// MutateOrGet returns created UIDs only; empty => an existing node matched.
+	return len(uids) == 0, nil
+}
+
+// InsertIfAbsent implements inserting obj only if no node already holds one of
+// its unique values. obj must be a struct pointer with at least one non-empty
+// field tagged dgraph:"unique". The insert relies on the uniqueness check
+// every insert runs — the embedded engine's, or Dgraph's @unique — so two
+// concurrent calls for one value insert exactly once: the other sees the
+// UniqueError it would return from Insert and reports inserted == false.
+// When the error names the existing node, as the embedded engine's does, obj's
+// UID is set to it. A conflict on a unique value of a nested node is still
+// returned as a UniqueError.
+func (c client) InsertIfAbsent(ctx context.Context, obj any) (inserted bool, err error) {
+	obj = UnwrapSchema(obj)
+	if err := checkPointer(obj); err != nil {
+		return false, err
+	}
+	unique := getUniquePredicates(obj)
+	hasValue := false
+	for _, val := range unique {
+		if val != nil && !reflect.ValueOf(val).IsZero() {
+			hasValue = true
+			break
+		}
+	}
+	if !hasValue {
+		return false, errors.New("InsertIfAbsent: object has no unique predicate value")
+	}
+
+	err = c.Insert(ctx, obj)
+	var uniqueErr *UniqueError
+	if errors.As(err, &uniqueErr) {
+		if _, own := unique[uniqueErr.Field]; own {
+			if uniqueErr.UID != "" {
+				setUIDValue(obj, uniqueErr.UID)
+			}
+			return false, nil
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// firstUpsertPredicate returns the Dgraph predicate name of the first field
+// tagged dgraph:"...upsert..." (or carrying upsert in the altTag tag, see
+// WithTagName). The predicate defaults to the json tag name unless an explicit
+// predicate= token is present. It returns "" if no upsert field exists.
+func firstUpsertPredicate(obj any, altTag string) string {
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	// A nil pointer (or nil interface) dereferences to an invalid Value, and a
+	// non-struct has no fields; either way there is no upsert predicate, and
+	// calling Type()/NumField() on them would panic.
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return ""
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		dgTag := fieldDirectives(f, altTag)
+		if !strings.Contains(dgTag, "upsert") {
+			continue
+		}
+		return predicateName(f, dgTag)
+	}
+	return ""
+}
+
+// isValidPredicateName reports whether pred is safe to concatenate into a DQL
+// filter. LoadAndDelete builds "eq(<pred>, $1)" by string concatenation; the key
+// value is parameterized, but the predicate name is not, so a name containing DQL
+// metacharacters (parentheses, commas, whitespace, quotes, angle brackets, ...)
 // could corrupt or inject the query. Dgraph predicate names are plain identifiers
 // over letters, digits, '_', '.', and '-'; anything outside that set is rejected
 // rather than trusted.
@@ -800,7 +1639,7 @@ func (c client) LoadAndDelete(ctx context.Context, obj any, key any, predicates
 	if len(predicates) > 0 {
 		pred = predicates[0]
 	} else {
-		pred = firstUpsertPredicate(obj)
+		pred = firstUpsertPredicate(obj, c.options.tagName)
 	}
 	if pred == "" {
 		return false, fmt.Errorf("LoadAndDelete: no key predicate (pass one or tag a field dgraph:\"upsert\")")
@@ -813,6 +1652,16 @@ func (c client) LoadAndDelete(ctx context.Context, obj any, key any, predicates
 		return false, fmt.Errorf("LoadAndDelete: invalid key predicate %q (allowed: letters, digits, '_', '.', '-')", pred)
 	}
 
+	// In a PendingTxn the read and delete commit with the rest of it, so
+	// there is nothing to retry here: a conflict is reported by Commit.
+	if pending := pendingTxnFrom(ctx); pending != nil {
+		tx, err := pending.join(ctx, c.pool)
+		if err != nil {
+			return false, err
+		}
+		return c.loadAndDeleteIn(tx, obj, pred, key)
+	}
+
 	dgClient, err := c.pool.get()
 	if err != nil {
 		c.logger.Error(err, "Failed to get client from pool")
@@ -839,37 +1688,10 @@ func (c client) LoadAndDelete(ctx context.Context, obj any, key any, predicates
 	const maxAttempts = 10
 	for attempt := 0; ; attempt++ {
 		tx := dg.NewTxnContext(ctx, dgClient)
-		getErr := tx.Get(obj).
-			Filter("eq("+pred+", $1)", key).
-			All(c.options.maxEdgeTraversal).
-			Node()
-		if getErr != nil {
-			_ = tx.Discard()
-			// dgman returns ErrNodeNotFound when nothing matches.
-			if errors.Is(getErr, dg.ErrNodeNotFound) {
-				// Honor the documented contract: obj is zero when loaded=false.
-				// A prior attempt's Get (before a commit abort) may have hydrated
-				// obj, and the caller may have passed a pre-populated struct.
-				zeroValue(obj)
-				return false, nil
-			}
-			return false, getErr
-		}
-
-		uid := uidOf(obj)
-		if uid == "" {
-			// A genuine miss already returned via ErrNodeNotFound above, so the
-			// Get here matched and hydrated a node. An empty UID therefore means
-			// the model has no readable string UID field, not that nothing existed
-			// -- reporting loaded=false would silently skip a matched node, so
-			// surface it as an error instead.
-			_ = tx.Discard()
-			return false, fmt.Errorf("LoadAndDelete: matched a node but read no UID; the model needs a string UID field")
-		}
-
-		if delErr := tx.DeleteNode(uid); delErr != nil {
+		loaded, err := c.loadAndDeleteIn(tx, obj, pred, key)
+		if err != nil || !loaded {
 			_ = tx.Discard()
-			return false, delErr
+			return loaded, err
 		}
 
 		if cErr := tx.Commit(); cErr != nil {
@@ -887,6 +1709,111 @@ func (c client) LoadAndDelete(ctx context.Context, obj any, key any, predicates
 	}
 }
 
+// loadAndDeleteIn reads the node whose pred equals key into obj and deletes
+// it within tx, reporting whether one matched; obj is left zero when none did.
+func (c client) loadAndDeleteIn(tx *dg.TxnContext, obj any, pred string, key any) (bool, error) {
+	getErr := tx.Get(obj).
+		Filter("eq("+pred+", $1)", key).
+		All(c.options.maxEdgeTraversal).
+		Node()
+	if getErr != nil {
+		// dgman returns ErrNodeNotFound when nothing matches.
+		if errors.Is(getErr, dg.ErrNodeNotFound) {
+			// Honor the documented contract: obj is zero when loaded=false.
+			// A prior attempt's Get (before a commit abort) may have hydrated
+			// obj, and the caller may have passed a pre-populated struct.
+			zeroValue(obj)
+			return false, nil
+		}
+		return false, getErr
+	}
+
+	uid := uidOf(obj)
+	if uid == "" {
+		// A genuine miss already returned via ErrNodeNotFound above, so the
+		// Get here matched and hydrated a node. An empty UID therefore means
+		// the model has no readable string UID field, not that nothing existed
+		// -- reporting loaded=false would silently skip a matched node, so
+		// surface it as an error instead.
+		return false, fmt.Errorf("LoadAndDelete: matched a node but read no UID; the model needs a string UID field")
+	}
+	if err := tx.DeleteNode(uid); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteAndReturn reads the node with the given UID into obj and deletes it
+// within one transaction, closing the window a Get followed by a Delete leaves
+// for the node to change in between; obj ends up holding exactly the state
+// that was removed, for logging or auditing. Like LoadAndDelete, the embedded
+// engine serializes the read and delete, and a commit conflict against a
+// remote cluster retries the pair. A missing node returns dg.ErrNodeNotFound
+// and leaves obj zero.
+func (c client) DeleteAndReturn(ctx context.Context, obj any, uid string) error {
+	obj = UnwrapSchema(obj)
+	if err := checkPointer(obj); err != nil {
+		return err
+	}
+	if uid == "" {
+		return fmt.Errorf("DeleteAndReturn: empty UID")
+	}
+
+	// As in LoadAndDelete, a PendingTxn commits the pair with the rest of it.
+	if pending := pendingTxnFrom(ctx); pending != nil {
+		tx, err := pending.join(ctx, c.pool)
+		if err != nil {
+			return err
+		}
+		return c.getAndDeleteIn(tx, obj, uid)
+	}
+
+	dgClient, err := c.pool.get()
+	if err != nil {
+		c.logger.Error(err, "Failed to get client from pool")
+		return err
+	}
+	defer c.pool.put(dgClient)
+
+	// See LoadAndDelete: only the embedded engine lacks a commit-time
+	// conflict check, so only it needs the read and delete serialized.
+	if c.engine != nil && c.consumeMu != nil {
+		c.consumeMu.Lock()
+		defer c.consumeMu.Unlock()
+	}
+
+	const maxAttempts = 10
+	for attempt := 0; ; attempt++ {
+		tx := dg.NewTxnContext(ctx, dgClient)
+		if err := c.getAndDeleteIn(tx, obj, uid); err != nil {
+			_ = tx.Discard()
+			return err
+		}
+
+		if cErr := tx.Commit(); cErr != nil {
+			_ = tx.Discard()
+			if isAbortedErr(cErr) && attempt < maxAttempts {
+				continue
+			}
+			return cErr
+		}
+		return nil
+	}
+}
+
+// getAndDeleteIn reads the node uid into obj and deletes it within tx. A
+// missing node returns dg.ErrNodeNotFound and leaves obj zero.
+func (c client) getAndDeleteIn(tx *dg.TxnContext, obj any, uid string) error {
+	if err := tx.Get(obj).UID(uid).All(c.options.maxEdgeTraversal).Node(); err != nil {
+		if errors.Is(err, dg.ErrNodeNotFound) {
+			// A prior attempt may have hydrated obj before its commit aborted.
+			zeroValue(obj)
+		}
+		return err
+	}
+	return tx.DeleteNode(uid)
+}
+
 // isAbortedErr reports whether err is a Dgraph transaction-conflict abort,
 // matching both dgo's ErrAborted sentinel and the underlying message in case a
 // wrapped or stringified form reaches us.
@@ -900,8 +1827,48 @@ func isAbortedErr(err error) bool {
 	return strings.Contains(strings.ToLower(err.Error()), "aborted")
 }
 
+// Modify implements a read-modify-write of a single node. The read and the
+// write share a transaction: against a Dgraph cluster a concurrent writer of
+// the same predicates aborts the loser's commit, which is retried per
+// DefaultRetryPolicy from a fresh read, and the embedded engine, which does
+// no commit-time conflict check, serializes modifications as LoadAndDelete
+// does. obj is zeroed before each read, so fn always changes the node's
+// current state, and is validated after fn returns. fn must not call back
+// into the client's Modify, Increment, or LoadAndDelete.
+func (c client) Modify(ctx context.Context, obj any, uid string, fn func() error) error {
+	obj = UnwrapSchema(obj)
+	if err := checkPointer(obj); err != nil {
+		return err
+	}
+	if uid == "" {
+		return errors.New("Modify: empty UID")
+	}
+
+	if c.engine != nil && c.consumeMu != nil {
+		c.consumeMu.Lock()
+		defer c.consumeMu.Unlock()
+	}
+
+	return c.WithRetry(ctx, DefaultRetryPolicy, func() error {
+		reflect.ValueOf(obj).Elem().SetZero()
+		return c.processTxn(ctx, obj, "Modify", true, func(tx *dg.TxnContext, obj any) ([]string, error) {
+			if err := tx.Get(obj).UID(uid).All(c.options.maxEdgeTraversal).Node(); err != nil {
+				return nil, err
+			}
+			if err := fn(); err != nil {
+				return nil, err
+			}
+			if err := c.validateStruct(ctx, obj); err != nil {
+				return nil, err
+			}
+			return tx.MutateBasic(obj)
+		})
+	})
+}
+
 // Update implements updating an existing object in the database.
-// Passed object must be a pointer to a struct.
+// Passed object must be a pointer to a struct. An object with a version field
+// (dgraph:"version") is updated under optimistic locking; see updateVersioned.
 func (c client) Update(ctx context.Context, obj any) error {
 	obj = UnwrapSchema(obj)
 	// Validate struct before update
@@ -909,13 +1876,46 @@ func (c client) Update(ctx context.Context, obj any) error {
 		return err
 	}
 
+	field, pred, ok, err := versionField(obj, c.options.tagName)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return c.updateVersioned(ctx, obj, field, pred)
+	}
+
 	return c.process(ctx, obj, "Update", func(tx *dg.TxnContext, obj any) ([]string, error) {
 		return tx.MutateBasic(obj)
 	})
 }
 
+// UpdateCount implements Update with a count of the nodes updated. Update
+// writes every node it is given or none, so the count is of the top-level
+// structs in obj.
+func (c client) UpdateCount(ctx context.Context, obj any) (int, error) {
+	if err := c.Update(ctx, obj); err != nil {
+		return 0, err
+	}
+	v := reflect.ValueOf(UnwrapSchema(obj))
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice {
+		return v.Len(), nil
+	}
+	return 1, nil
+}
+
 // Delete implements removing objects with the specified UIDs.
 func (c client) Delete(ctx context.Context, uids []string) error {
+	if pending := pendingTxnFrom(ctx); pending != nil {
+		tx, err := pending.join(ctx, c.pool)
+		if err != nil {
+			return err
+		}
+		return tx.DeleteNode(uids...)
+	}
+
 	client, err := c.pool.get()
 	if err != nil {
 		c.logger.Error(err, "Failed to get client from pool")
@@ -927,6 +1927,138 @@ func (c client) Delete(ctx context.Context, uids []string) error {
 	return txn.DeleteNode(uids...)
 }
 
+// DeleteCount implements Delete with a count of the nodes deleted. Of uids,
+// those naming a node with a dgraph.type are read and deleted in one
+// transaction, as DeleteBy does, so a UID with no node, or one given twice,
+// is not counted.
+func (c client) DeleteCount(ctx context.Context, uids []string) (int, error) {
+	if len(uids) == 0 {
+		return 0, nil
+	}
+	roots := make([]string, len(uids))
+	for i, uid := range uids {
+		n, err := strconv.ParseUint(strings.TrimPrefix(uid, "0x"), 16, 64)
+		if err != nil || !strings.HasPrefix(uid, "0x") {
+			return 0, fmt.Errorf("DeleteCount: invalid UID %q", uid)
+		}
+		roots[i] = fmt.Sprintf("%#x", n)
+	}
+	query := "{ q(func: uid(" + strings.Join(roots, ", ") + ")) @filter(has(dgraph.type)) { uid } }"
+	n, err := c.deleteMatched(ctx, query, nil)
+	if err == nil {
+		c.logger.V(2).Info("DeleteCount successful", "count", n)
+	}
+	return n, err
+}
+
+// DeleteBy implements deleting the nodes of model's Dgraph type that match
+// eq(field, value) — a delete by external key, where the key need not be
+// unique. The matching nodes are read and deleted in one transaction, which
+// is retried from a fresh read when a concurrent writer aborts it, so the
+// count is of the nodes this call deleted. field should be indexed for eq.
+func (c client) DeleteBy(ctx context.Context, model any, field, value string) (int, error) {
+	model = UnwrapSchema(model)
+	if !isValidPredicateName(field) {
+		return 0, fmt.Errorf("DeleteBy: invalid predicate %q", field)
+	}
+	nodeType := getNodeType(model)
+	if !isValidPredicateName(nodeType) {
+		return 0, fmt.Errorf("DeleteBy: invalid type name %q", nodeType)
+	}
+	query := "query q($v: string) { q(func: type(" + nodeType + ")) @filter(eq(" + field + ", $v)) { uid } }"
+	n, err := c.deleteMatched(ctx, query, map[string]string{"$v": value})
+	if err == nil {
+		c.logger.V(2).Info("DeleteBy successful", "field", field, "count", n)
+	}
+	return n, err
+}
+
+// deleteMatched deletes the nodes query's q block returns, reading and
+// deleting them in one transaction that is retried from a fresh read when a
+// concurrent writer aborts it, and returns how many it deleted.
+func (c client) deleteMatched(ctx context.Context, query string, vars map[string]string) (int, error) {
+	return c.mutateMatched(ctx, query, vars, func(tx *dg.TxnContext, uids []string) error {
+		return tx.DeleteNode(uids...)
+	})
+}
+
+// mutateMatched reads the UIDs of the nodes query's q block returns and calls
+// mutate with them, in one transaction that is retried from a fresh read when
+// a concurrent writer aborts it. It returns how many nodes were matched and
+// written; mutate is not called when none match. In a PendingTxn both run in
+// it, once, and commit with the rest of it.
+func (c client) mutateMatched(ctx context.Context, query string, vars map[string]string,
+	mutate func(tx *dg.TxnContext, uids []string) error) (int, error) {
+	if pending := pendingTxnFrom(ctx); pending != nil {
+		tx, err := pending.join(ctx, c.pool)
+		if err != nil {
+			return 0, err
+		}
+		return matchAndMutate(ctx, tx, query, vars, mutate)
+	}
+
+	dgClient, err := c.pool.get()
+	if err != nil {
+		c.logger.Error(err, "Failed to get client from pool")
+		return 0, err
+	}
+	defer c.pool.put(dgClient)
+
+	// As in LoadAndDelete, the embedded engine does no commit-time conflict
+	// check, so concurrent callers are serialized to keep the counts exact.
+	if c.engine != nil && c.consumeMu != nil {
+		c.consumeMu.Lock()
+		defer c.consumeMu.Unlock()
+	}
+
+	const maxAttempts = 10
+	for attempt := 0; ; attempt++ {
+		tx := dg.NewTxnContext(ctx, dgClient)
+		n, err := matchAndMutate(ctx, tx, query, vars, mutate)
+		if err != nil || n == 0 {
+			_ = tx.Discard()
+			return 0, err
+		}
+		if err := tx.Commit(); err != nil {
+			_ = tx.Discard()
+			if isAbortedErr(err) && attempt < maxAttempts {
+				continue
+			}
+			return 0, err
+		}
+		return n, nil
+	}
+}
+
+// matchAndMutate runs query within tx and calls mutate with the UIDs of the
+// nodes its q block returns, if any, returning how many it matched.
+func matchAndMutate(ctx context.Context, tx *dg.TxnContext, query string, vars map[string]string,
+	mutate func(tx *dg.TxnContext, uids []string) error) (int, error) {
+	resp, err := tx.Txn().QueryWithVars(ctx, query, vars)
+	if err != nil {
+		return 0, err
+	}
+	var matched struct {
+		Q []struct {
+			UID string `json:"uid"`
+		} `json:"q"`
+	}
+	if err := json.Unmarshal(resp.GetJson(), &matched); err != nil {
+		return 0, err
+	}
+	if len(matched.Q) == 0 {
+		return 0, nil
+	}
+	uids := make([]string, len(matched.Q))
+	for i, m := range matched.Q {
+		uids[i] = m.UID
+	}
+	if err := mutate(tx, uids); err != nil {
+		return 0, err
+	}
+	return len(uids), nil
+}
+
 // Get implements retrieving a single object by its UID.
 // Passed object must be a pointer to a struct.
 func (c client) Get(ctx context.Context, obj any, uid string) error {
@@ -935,6 +2067,15 @@ func (c client) Get(ctx context.Context, obj any, uid string) error {
 	if err != nil {
 		return err
 	}
+	c.names.learn(obj)
+
+	if pending := pendingReadFrom(ctx); pending != nil {
+		txn, err := pending.join(ctx, c.pool)
+		if err != nil {
+			return err
+		}
+		return c.getNode(txn, obj, uid)
+	}
 
 	client, err := c.pool.get()
 	if err != nil {
@@ -943,13 +2084,80 @@ func (c client) Get(ctx context.Context, obj any, uid string) error {
 	defer c.pool.put(client)
 
 	txn := dg.NewReadOnlyTxnContext(ctx, client)
-	return txn.Get(obj).UID(uid).All(c.options.maxEdgeTraversal).Node()
+	return c.getNode(txn, obj, uid)
+}
+
+// getNode reads the node uid into obj within txn, decoding it with the
+// configured Codec when that handles obj's type, and fills its readFrom
+// fields.
+func (c client) getNode(txn *dg.TxnContext, obj any, uid string) error {
+	if codec := c.options.codec; codec != nil && codec.Handles(reflect.TypeOf(obj).Elem()) {
+		bufs := c.decodeBuffers()
+		defer c.releaseDecodeBuffers(bufs)
+		if err := c.expandAll(txn.Context(), txn.Get(obj).UID(uid), obj).Node(&bufs.node); err != nil {
+			return err
+		}
+		if err := codec.Unmarshal(bufs.node, obj); err != nil {
+			return err
+		}
+		return c.populateReadFrom(txn, obj, uid)
+	}
+	if err := c.expandAll(txn.Context(), txn.Get(obj).UID(uid), obj).Node(); err != nil {
+		return err
+	}
+	return c.populateReadFrom(txn, obj, uid)
+}
+
+// GetMap implements retrieving several objects by UID, keyed by UID. All
+// nodes are read in one read-only transaction, so they reflect the same
+// snapshot. Each node is decoded into a fresh value returned by factory, which
+// must be a pointer to a struct. UIDs with no node are left out of the map.
+func (c client) GetMap(ctx context.Context, uids []string, factory func() any) (map[string]any, error) {
+	if factory == nil {
+		return nil, errors.New("factory must not be nil")
+	}
+	var txn *dg.TxnContext
+	if pending := pendingReadFrom(ctx); pending != nil {
+		joined, err := pending.join(ctx, c.pool)
+		if err != nil {
+			return nil, err
+		}
+		txn = joined
+	} else {
+		client, err := c.pool.get()
+		if err != nil {
+			return nil, err
+		}
+		defer c.pool.put(client)
+		txn = dg.NewReadOnlyTxnContext(ctx, client)
+	}
+	nodes := make(map[string]any, len(uids))
+	for _, uid := range uids {
+		if _, ok := nodes[uid]; ok {
+			continue
+		}
+		obj := UnwrapSchema(factory())
+		if err := checkPointer(obj); err != nil {
+			return nil, err
+		}
+		c.names.learn(obj)
+		err := c.getNode(txn, obj, uid)
+		if errors.Is(err, dg.ErrNodeNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		nodes[uid] = obj
+	}
+	return nodes, nil
 }
 
 // Returns a *dg.Query that can be further refined with filters, pagination, etc.
 // The returned query will be limited to the maximum number of edges specified in the options.
 func (c client) Query(ctx context.Context, model any) *dg.Query {
 	model = UnwrapSchema(model)
+	c.names.learn(model)
 	client, err := c.pool.get()
 	if err != nil {
 		return nil
@@ -957,7 +2165,147 @@ func (c client) Query(ctx context.Context, model any) *dg.Query {
 	defer c.pool.put(client)
 
 	txn := dg.NewReadOnlyTxnContext(ctx, client)
-	return txn.Get(model).All(c.options.maxEdgeTraversal)
+	return c.expandAll(ctx, txn.Get(model), model)
+}
+
+// ModifiedSince implements querying the nodes changed since a point in time,
+// for incremental sync: Query(model) filtered to ge(field, since) and ordered
+// ascending by field, so a poller can resume from the last timestamp it saw.
+// field is the predicate of a datetime field the application sets on every
+// write, such as updatedAt. Like Query, it returns nil on failure, including
+// when field is not a valid predicate name.
+func (c client) ModifiedSince(ctx context.Context, model any, field string, since time.Time) *dg.Query {
+	if !isValidPredicateName(field) {
+		c.logger.Error(fmt.Errorf("invalid predicate %q", field), "ModifiedSince failed")
+		return nil
+	}
+	q := c.Query(ctx, model)
+	if q == nil {
+		return nil
+	}
+	return q.Filter("ge("+field+", $1)", since).OrderAsc(field)
+}
+
+// QueryInterface implements querying nodes by Dgraph type name. The type's
+// predicates are fetched with expand(_all_), so typeName must be a type known
+// to the schema, and each node is decoded with encoding/json (or the configured
+// Codec, for the types it handles) into the value resultFactory returns.
+// Because keys are predicate names, fields renamed with predicate= decode only
+// through a Codec.
+func (c client) QueryInterface(ctx context.Context, typeName string, resultFactory func() any) ([]any, error) {
+	if !isValidPredicateName(typeName) {
+		return nil, fmt.Errorf("invalid type name %q", typeName)
+	}
+	if resultFactory == nil {
+		return nil, errors.New("resultFactory must not be nil")
+	}
+
+	q := fmt.Sprintf(`{ nodes(func: type(%s)) { uid dgraph.type expand(_all_) } }`, typeName)
+	data, err := c.QueryRaw(ctx, q, nil)
+	if err != nil {
+		return nil, err
+	}
+	bufs := c.decodeBuffers()
+	defer c.releaseDecodeBuffers(bufs)
+	resp := struct {
+		Nodes *[]json.RawMessage `json:"nodes"`
+	}{&bufs.nodes}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+
+	results := make([]any, 0, len(bufs.nodes))
+	for _, raw := range bufs.nodes {
+		obj := resultFactory()
+		if err := checkPointer(obj); err != nil {
+			return nil, err
+		}
+		codec := c.options.codec
+		if codec != nil && codec.Handles(reflect.TypeOf(obj).Elem()) {
+			err = codec.Unmarshal(raw, obj)
+		} else {
+			err = json.Unmarshal(raw, obj)
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, obj)
+	}
+	return results, nil
+}
+
+// MultiQuery implements running several model queries in one round trip. The
+// blocks are rendered in name order and sent through QueryRaw, so the queries'
+// own transactions are not used. Queries with GraphQL variables (Vars) cannot
+// be combined, since their variable declarations would collide. A block that
+// matched nothing is returned as an empty JSON array.
+func (c client) MultiQuery(ctx context.Context, queries map[string]*dg.Query) (map[string]json.RawMessage, error) {
+	names := make([]string, 0, len(queries))
+	for name, q := range queries {
+		if !isValidBlockName(name) {
+			return nil, fmt.Errorf("invalid block name %q", name)
+		}
+		if q == nil {
+			return nil, fmt.Errorf("query for block %q is nil", name)
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return map[string]json.RawMessage{}, nil
+	}
+	sort.Strings(names)
+
+	blocks := make([]*dg.Query, 0, len(names))
+	for _, name := range names {
+		q := queries[name]
+		if strings.HasPrefix(q.String(), "query ") {
+			return nil, fmt.Errorf("query for block %q declares variables; run it on its own", name)
+		}
+		blocks = append(blocks, q.Name(name))
+	}
+
+	data, err := c.QueryRaw(ctx, dg.NewQueryBlock(blocks...).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]json.RawMessage, len(names))
+	for _, name := range names {
+		raw, ok := resp[name]
+		if !ok {
+			raw = json.RawMessage("[]")
+		}
+		results[name] = raw
+	}
+	return results, nil
+}
+
+// isValidBlockName reports whether name can be used as a DQL query block name:
+// letters, digits and underscores, not starting with a digit, and not a
+// keyword that would make the block something other than a result block.
+func isValidBlockName(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z',
+			r >= 'A' && r <= 'Z',
+			r >= '0' && r <= '9',
+			r == '_':
+		default:
+			return false
+		}
+	}
+	switch strings.ToLower(name) {
+	case "var", "query", "mutation", "schema":
+		return false
+	}
+	return true
 }
 
 // AlterSchema applies a raw DQL schema string directly via Dgraph Alter,
@@ -973,6 +2321,20 @@ func (c client) AlterSchema(ctx context.Context, schema string) error {
 	return dgClient.Alter(ctx, &api.Operation{Schema: schema})
 }
 
+// ApplySchemaString implements applying a hand-written schema string.
+func (c client) ApplySchemaString(ctx context.Context, schema string) error {
+	return c.AlterSchema(ctx, schema)
+}
+
+// ApplySchemaFile implements applying a hand-written schema read from path.
+func (c client) ApplySchemaFile(ctx context.Context, path string) error {
+	schema, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading schema file [%v]: %w", path, err)
+	}
+	return c.AlterSchema(ctx, string(schema))
+}
+
 // UpdateSchema implements updating the Dgraph schema. Pass one or more
 // objects that will be used to generate the schema.
 // If any object contains SimString fields tagged `dgraph:"embedding"`, the
@@ -981,6 +2343,7 @@ func (c client) UpdateSchema(ctx context.Context, obj ...any) error {
 	for i := range obj {
 		obj[i] = UnwrapSchema(obj[i])
 	}
+	c.names.learn(obj...)
 	dgClient, err := c.pool.get()
 	if err != nil {
 		c.logger.Error(err, "Failed to get client from pool")
@@ -988,7 +2351,14 @@ func (c client) UpdateSchema(ctx context.Context, obj ...any) error {
 	}
 	defer c.pool.put(dgClient)
 
-	if _, err = dg.CreateSchema(dgClient, obj...); err != nil {
+	if (c.options.tagName != "" && c.options.tagName != "dgraph") || len(computedPredicates(obj...)) > 0 ||
+		c.options.sortedSchema || c.options.indexProfile != "" || c.names != nil {
+		err = createTaggedSchema(ctx, dgClient, c.options.tagName, c.options.indexProfile,
+			c.options.sortedSchema, c.options.namingStrategy, obj...)
+	} else {
+		_, err = dg.CreateSchema(dgClient, obj...)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -996,6 +2366,7 @@ func (c client) UpdateSchema(ctx context.Context, obj ...any) error {
 	var vecSchema strings.Builder
 	for _, o := range obj {
 		for _, info := range collectSimFields(o) {
+			info.vecPredicate = c.names.derive(info.vecPredicate)
 			vecSchema.WriteString(buildVecSchemaStatement(info))
 			vecSchema.WriteString("\n")
 		}
@@ -1007,7 +2378,9 @@ func (c client) UpdateSchema(ctx context.Context, obj ...any) error {
 	return dgClient.Alter(ctx, &api.Operation{Schema: vecSchema.String()})
 }
 
-// GetSchema implements retrieving the Dgraph schema.
+// GetSchema implements retrieving the Dgraph schema. The embedded engine's
+// schema {} query lists only types, so embedded clients render the predicate
+// definitions from Introspect ahead of them.
 func (c client) GetSchema(ctx context.Context) (string, error) {
 	client, err := c.pool.get()
 	if err != nil {
@@ -1016,7 +2389,20 @@ func (c client) GetSchema(ctx context.Context) (string, error) {
 	}
 	defer c.pool.put(client)
 
-	return dg.GetSchema(client)
+	schema, err := dg.GetSchema(client)
+	if err != nil || c.engine == nil {
+		return schema, err
+	}
+	in, err := c.Introspect(ctx)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, p := range in.Predicates {
+		sb.WriteString(p.schema().String())
+		sb.WriteString("\n")
+	}
+	return sb.String() + schema, nil
 }
 
 // DropAll implements dropping all data and schema from the database.
@@ -1043,23 +2429,73 @@ func (c client) DropData(ctx context.Context) error {
 	return client.Alter(ctx, &api.Operation{DropOp: api.Operation_DATA})
 }
 
-// QueryRaw implements raw querying (DQL syntax) and optional variables.
-func (c client) QueryRaw(ctx context.Context, q string, vars map[string]string) ([]byte, error) {
+// DropPredicate implements dropping a single predicate and its data.
+func (c client) DropPredicate(ctx context.Context, predicate string) error {
+	if predicate == "" {
+		return errors.New("DropPredicate: empty predicate")
+	}
 	client, err := c.pool.get()
 	if err != nil {
 		c.logger.Error(err, "Failed to get client from pool")
-		return nil, err
+		return err
 	}
 	defer c.pool.put(client)
 
-	txn := dg.NewReadOnlyTxnContext(ctx, client)
-	resp, err := txn.Txn().QueryWithVars(ctx, q, vars)
+	return client.Alter(ctx, &api.Operation{DropAttr: predicate})
+}
+
+// QueryRaw implements raw querying (DQL syntax) and optional variables. With
+// WithQueryCache, an unexpired cached result is returned without running the
+// query.
+func (c client) QueryRaw(ctx context.Context, q string, vars map[string]string) ([]byte, error) {
+	if c.queryCache == nil || ctx.Value(noCacheKey{}) != nil {
+		return c.queryRaw(ctx, q, vars)
+	}
+	key := queryCacheKey(c.options.namespace, q, vars)
+	if data, ok := c.queryCache.get(key); ok {
+		return data, nil
+	}
+	data, err := c.queryRaw(ctx, q, vars)
+	if err != nil {
+		return nil, err
+	}
+	c.queryCache.put(key, data)
+	return data, nil
+}
+
+// queryRaw runs a raw query without consulting the query cache.
+func (c client) queryRaw(ctx context.Context, q string, vars map[string]string) ([]byte, error) {
+	resp, err := c.queryResponse(ctx, q, vars)
 	if err != nil {
 		return nil, err
 	}
 	return resp.GetJson(), nil
 }
 
+// QueryRawInto runs a raw query with QueryRaw and decodes the results of the
+// block named blockName into a []T. A block absent from the response, as when
+// nothing matched, yields an empty slice.
+func QueryRawInto[T any](ctx context.Context, client Client, q string, vars map[string]string,
+	blockName string) ([]T, error) {
+	data, err := client.QueryRaw(ctx, q, vars)
+	if err != nil {
+		return nil, err
+	}
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	results := []T{}
+	raw, ok := resp[blockName]
+	if !ok {
+		return results, nil
+	}
+	if err := json.Unmarshal(raw, &results); err != nil {
+		return nil, fmt.Errorf("decoding block %q: %w", blockName, err)
+	}
+	return results, nil
+}
+
 // Close releases resources used by the client.
 func (c client) Close() {
 	// Add nil check to prevent panic if pool is nil
@@ -0,0 +1,430 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// Codec serializes values of specific types without dgman's reflection walk.
+// Insert hands the values of a type the codec handles straight to it and sends
+// the result as a single set mutation; Get decodes them through it. Types the
+// codec does not handle keep going through dgman.
+//
+// A codec is responsible for a node's "uid" and "dgraph.type" keys. Before
+// Marshal is called, Insert gives every value with an empty UID a blank node
+// ("_:..."), and it writes the assigned UIDs back once the mutation succeeds.
+type Codec interface {
+	// Handles reports whether the codec serializes values of t, a struct type.
+	Handles(t reflect.Type) bool
+
+	// Marshal appends the JSON object of obj, a pointer to a struct of a
+	// handled type, to buf.
+	Marshal(buf []byte, obj any) ([]byte, error)
+
+	// Unmarshal decodes the JSON object of one node into obj, a pointer to a
+	// struct of a handled type.
+	Unmarshal(data []byte, obj any) error
+}
+
+// NewCachedCodec returns a Codec for the struct types of models that reflects
+// over each type once, up front, instead of on every Insert. It handles flat
+// node types: fields holding scalars, time.Time and slices or maps of those.
+// A model with an edge (a field holding another struct) is rejected, as edges
+// need dgman's mutation walk.
+//
+// Like dgman, Marshal omits fields tagged omitempty that hold their zero value,
+// writes the node's dgraph.type and fills an empty DType field with it.
+func NewCachedCodec(models ...any) (Codec, error) {
+	codec := &cachedCodec{types: make(map[reflect.Type]*codecType, len(models))}
+	for _, m := range models {
+		t := reflect.TypeOf(m)
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("codec model must be a struct, got %T", m)
+		}
+		ct, err := compileCodecType(t)
+		if err != nil {
+			return nil, err
+		}
+		codec.types[t] = ct
+	}
+	return codec, nil
+}
+
+// cachedCodec is the Codec returned by NewCachedCodec. Its type table is
+// built once and only read afterwards, so it is safe for concurrent use.
+type cachedCodec struct {
+	types map[reflect.Type]*codecType
+}
+
+// codecType is the precompiled plan for one struct type.
+type codecType struct {
+	nodeType string
+	uid      int // index of the UID field, -1 if none
+	dtype    int // index of the DType field, -1 if none
+	fields   []codecField
+	byName   map[string]int // predicate name -> index into fields
+}
+
+// codecField is one stored predicate of a codecType.
+type codecField struct {
+	index     int
+	key       []byte // `"<predicate>":`
+	predicate string
+	omitEmpty bool
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+)
+
+func compileCodecType(t reflect.Type) (*codecType, error) {
+	ct := &codecType{
+		nodeType: t.Name(),
+		uid:      -1,
+		dtype:    -1,
+		byName:   make(map[string]int),
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(jsonTag, ",")
+		switch name {
+		case "uid":
+			ct.uid = i
+			continue
+		case "dgraph.type":
+			ct.dtype = i
+			if tag := field.Tag.Get("dgraph"); tag != "" {
+				ct.nodeType = tag
+			}
+			continue
+		}
+		if isComputedField(field) {
+			continue
+		}
+		if !isCodecValue(field.Type) {
+			return nil, fmt.Errorf("codec: %s.%s: unsupported field type %s", t.Name(), field.Name, field.Type)
+		}
+		pred := predicateName(field, field.Tag.Get("dgraph"))
+		ct.byName[pred] = len(ct.fields)
+		ct.fields = append(ct.fields, codecField{
+			index:     i,
+			key:       append(appendJSONString(nil, pred), ':'),
+			predicate: pred,
+			omitEmpty: strings.Contains(opts, "omitempty") || strings.Contains(opts, "omitzero"),
+		})
+	}
+	return ct, nil
+}
+
+// isCodecValue reports whether values of t are predicate values a cached codec
+// can write: anything that is not, and does not contain, a node.
+func isCodecValue(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return isCodecValue(t.Elem())
+	case reflect.Map:
+		return isCodecValue(t.Key()) && isCodecValue(t.Elem())
+	case reflect.Struct:
+		return t == timeType || t.Implements(jsonMarshalerType)
+	case reflect.Interface, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return false
+	}
+	return true
+}
+
+// Handles implements Codec.
+func (c *cachedCodec) Handles(t reflect.Type) bool {
+	_, ok := c.types[t]
+	return ok
+}
+
+// Marshal implements Codec.
+func (c *cachedCodec) Marshal(buf []byte, obj any) ([]byte, error) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return buf, fmt.Errorf("codec: Marshal needs a non-nil struct pointer, got %T", obj)
+	}
+	v = v.Elem()
+	ct, ok := c.types[v.Type()]
+	if !ok {
+		return buf, fmt.Errorf("codec: type %s not registered", v.Type())
+	}
+
+	buf = append(buf, '{')
+	if ct.uid >= 0 {
+		if uid := v.Field(ct.uid).String(); uid != "" {
+			buf = append(buf, `"uid":`...)
+			buf = appendJSONString(buf, uid)
+			buf = append(buf, ',')
+		}
+	}
+	buf = append(buf, `"dgraph.type":[`...)
+	if dt, ok := ct.dtypeField(v); ok && dt.Len() > 0 {
+		buf = appendJSONString(buf, dt.Index(0).String())
+	} else {
+		if ok && dt.CanSet() {
+			dt.Set(reflect.ValueOf([]string{ct.nodeType}))
+		}
+		buf = appendJSONString(buf, ct.nodeType)
+	}
+	buf = append(buf, ']')
+
+	for i := range ct.fields {
+		f := &ct.fields[i]
+		fv := v.Field(f.index)
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+		buf = append(buf, ',')
+		buf = append(buf, f.key...)
+		var err error
+		if buf, err = appendJSONValue(buf, fv); err != nil {
+			return buf, fmt.Errorf("codec: %s: %w", f.predicate, err)
+		}
+	}
+	return append(buf, '}'), nil
+}
+
+// dtypeField returns v's DType field when it is a []string.
+func (ct *codecType) dtypeField(v reflect.Value) (reflect.Value, bool) {
+	if ct.dtype < 0 {
+		return reflect.Value{}, false
+	}
+	dt := v.Field(ct.dtype)
+	return dt, dt.Kind() == reflect.Slice && dt.Type().Elem().Kind() == reflect.String
+}
+
+// Unmarshal implements Codec. Keys are matched against predicate names, so
+// fields renamed with predicate= decode without a separate remapping pass.
+func (c *cachedCodec) Unmarshal(data []byte, obj any) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("codec: Unmarshal needs a non-nil struct pointer, got %T", obj)
+	}
+	v = v.Elem()
+	ct, ok := c.types[v.Type()]
+	if !ok {
+		return fmt.Errorf("codec: type %s not registered", v.Type())
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for key, val := range raw {
+		var dst reflect.Value
+		switch {
+		case key == "uid" && ct.uid >= 0:
+			dst = v.Field(ct.uid)
+		case key == "dgraph.type" && ct.dtype >= 0:
+			dst = v.Field(ct.dtype)
+		default:
+			i, ok := ct.byName[key]
+			if !ok {
+				continue
+			}
+			dst = v.Field(ct.fields[i].index)
+		}
+		if err := json.Unmarshal(val, dst.Addr().Interface()); err != nil {
+			return fmt.Errorf("codec: %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// codecNodes returns the struct pointers held by obj (a pointer, a slice of
+// pointers or a pointer to one) when a codec is configured and handles every
+// one of them.
+func (c client) codecNodes(obj any) ([]any, bool) {
+	codec := c.options.codec
+	if codec == nil {
+		return nil, false
+	}
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Slice {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		if v.Kind() != reflect.Pointer || v.IsNil() || !codec.Handles(v.Type().Elem()) {
+			return nil, false
+		}
+		return []any{obj}, true
+	}
+	nodes := make([]any, v.Len())
+	for i := range nodes {
+		elem := v.Index(i)
+		if elem.Kind() != reflect.Pointer || elem.IsNil() || !codec.Handles(elem.Type().Elem()) {
+			return nil, false
+		}
+		nodes[i] = elem.Interface()
+	}
+	return nodes, len(nodes) > 0
+}
+
+// mutateCodec writes nodes as one set mutation encoded by the configured
+// codec. Nodes without a UID get a blank node, replaced by the UID Dgraph
+// assigns once the mutation succeeds and cleared again if it fails.
+func (c client) mutateCodec(tx *dg.TxnContext, nodes []any, commitNow bool) ([]string, error) {
+	blanks := make(map[int]string)
+	for i, n := range nodes {
+		if getUIDValue(n) == "" {
+			blank := "codec" + strconv.Itoa(i)
+			setUIDValue(n, "_:"+blank)
+			blanks[i] = blank
+		}
+	}
+	reset := func() {
+		for i := range blanks {
+			setUIDValue(nodes[i], "")
+		}
+	}
+
+	buf := make([]byte, 0, 256*len(nodes))
+	buf = append(buf, '[')
+	for i, n := range nodes {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		var err error
+		if buf, err = c.options.codec.Marshal(buf, n); err != nil {
+			reset()
+			return nil, err
+		}
+	}
+	buf = append(buf, ']')
+
+	resp, err := tx.Txn().Mutate(tx.Context(), &api.Mutation{SetJson: buf, CommitNow: commitNow})
+	if err != nil {
+		reset()
+		return nil, err
+	}
+	for i, blank := range blanks {
+		setUIDValue(nodes[i], resp.Uids[blank])
+	}
+	uids := make([]string, 0, len(resp.Uids))
+	for _, uid := range resp.Uids {
+		uids = append(uids, uid)
+	}
+	return uids, nil
+}
+
+// setUIDValue sets the UID field of a dgraph struct pointer.
+func setUIDValue(obj any, uid string) {
+	v := reflect.ValueOf(obj).Elem()
+	if f := v.FieldByName("UID"); f.IsValid() && f.Kind() == reflect.String && f.CanSet() {
+		f.SetString(uid)
+	}
+}
+
+// appendJSONValue appends the JSON encoding of v. The common scalar kinds are
+// written directly; anything else goes through encoding/json.
+func appendJSONValue(buf []byte, v reflect.Value) ([]byte, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return appendJSONString(buf, v.String()), nil
+	case reflect.Bool:
+		return strconv.AppendBool(buf, v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(buf, v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.AppendUint(buf, v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return buf, fmt.Errorf("unsupported value %v", f)
+		}
+		bits := 64
+		if v.Kind() == reflect.Float32 {
+			bits = 32
+		}
+		return strconv.AppendFloat(buf, f, 'g', -1, bits), nil
+	case reflect.Struct:
+		if v.Type() == timeType {
+			t := v.Interface().(time.Time)
+			buf = append(buf, '"')
+			buf = t.AppendFormat(buf, time.RFC3339Nano)
+			return append(buf, '"'), nil
+		}
+	}
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return buf, err
+	}
+	return append(buf, b...), nil
+}
+
+// appendJSONString appends s as a JSON string, escaping as encoding/json does
+// apart from its HTML-safe escapes, which a mutation does not need.
+func appendJSONString(buf []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	buf = append(buf, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		b := s[i]
+		if b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' {
+				i++
+				continue
+			}
+			buf = append(buf, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				buf = append(buf, '\\', b)
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, '\\', 'u', '2', '0', '2', hex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf = append(buf, s[start:]...)
+	return append(buf, '"')
+}
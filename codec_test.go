@@ -0,0 +1,170 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mg "github.com/matthewmcneely/modusgraph"
+)
+
+type CodecEntity struct {
+	UID       string    `json:"uid,omitempty"`
+	Name      string    `json:"name,omitempty" dgraph:"index=exact"`
+	Count     int       `json:"count,omitempty"`
+	Score     float64   `json:"score,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	DType     []string  `json:"dgraph.type,omitempty"`
+}
+
+type CodecWithEdge struct {
+	UID    string       `json:"uid,omitempty"`
+	Name   string       `json:"name,omitempty"`
+	Friend *CodecEntity `json:"friend,omitempty"`
+	DType  []string     `json:"dgraph.type,omitempty"`
+}
+
+func TestNewCachedCodec(t *testing.T) {
+	_, err := mg.NewCachedCodec(CodecWithEdge{})
+	require.Error(t, err, "a model with an edge should be rejected")
+
+	_, err = mg.NewCachedCodec("not a struct")
+	require.Error(t, err)
+
+	codec, err := mg.NewCachedCodec(&CodecEntity{})
+	require.NoError(t, err)
+
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	entity := &CodecEntity{Name: "a \"quoted\"\nname", Count: 3, CreatedAt: created, Tags: []string{"x"}}
+	buf, err := codec.Marshal(nil, entity)
+	require.NoError(t, err)
+	require.Equal(t, []string{"CodecEntity"}, entity.DType, "Marshal should fill DType")
+
+	// The codec's output must be what encoding/json produces for the same value.
+	var got, want map[string]any
+	require.NoError(t, json.Unmarshal(buf, &got))
+	expected, err := json.Marshal(entity)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(expected, &want))
+	require.Equal(t, want, got)
+
+	var decoded CodecEntity
+	require.NoError(t, codec.Unmarshal(buf, &decoded))
+	require.Equal(t, entity.Name, decoded.Name)
+	require.Equal(t, entity.Count, decoded.Count)
+	require.True(t, created.Equal(decoded.CreatedAt))
+	require.Equal(t, entity.Tags, decoded.Tags)
+}
+
+func TestClientWithCodec(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "CodecWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "CodecWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			codec, err := mg.NewCachedCodec(CodecEntity{})
+			require.NoError(t, err)
+
+			client, err := mg.NewClient(tc.uri, mg.WithAutoSchema(true), mg.WithCodec(codec))
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, client.DropAll(context.Background()))
+				client.Close()
+				mg.Shutdown()
+			}()
+
+			ctx := context.Background()
+			entities := []*CodecEntity{
+				{Name: "first", Count: 1, Score: 1.5, CreatedAt: time.Now().UTC(), Tags: []string{"a", "b"}},
+				{Name: "second", Count: 2},
+			}
+			require.NoError(t, client.Insert(ctx, entities))
+			for _, e := range entities {
+				require.NotEmpty(t, e.UID, "UID should be assigned")
+				require.NotContains(t, e.UID, "_:", "blank node should be replaced")
+			}
+
+			var got CodecEntity
+			require.NoError(t, client.Get(ctx, &got, entities[0].UID))
+			require.Equal(t, entities[0].UID, got.UID)
+			require.Equal(t, "first", got.Name)
+			require.Equal(t, 1, got.Count)
+			require.Equal(t, 1.5, got.Score)
+			require.ElementsMatch(t, []string{"a", "b"}, got.Tags)
+			require.Equal(t, []string{"CodecEntity"}, got.DType)
+
+			// Nodes written by the codec are visible to regular queries.
+			var results []CodecEntity
+			require.NoError(t, client.Query(ctx, CodecEntity{}).Filter(`eq(name, "second")`).Nodes(&results))
+			require.Len(t, results, 1)
+			require.Equal(t, entities[1].UID, results[0].UID)
+		})
+	}
+}
+
+func TestClientDecodePooling(t *testing.T) {
+	codec, err := mg.NewCachedCodec(CodecEntity{})
+	require.NoError(t, err)
+
+	client, err := mg.NewClient("file://"+GetTempDir(t), mg.WithAutoSchema(true), mg.WithCodec(codec),
+		mg.WithDecodePooling(true))
+	require.NoError(t, err)
+	defer func() {
+		client.Close()
+		mg.Shutdown()
+	}()
+
+	ctx := context.Background()
+	entities := []*CodecEntity{
+		{Name: "a much longer first name", Count: 1, Tags: []string{"x", "y", "z"}},
+		{Name: "second", Count: 2},
+	}
+	require.NoError(t, client.Insert(ctx, entities))
+
+	// Reads through reused buffers must not leak data into one another or
+	// into values decoded earlier.
+	var first, second CodecEntity
+	require.NoError(t, client.Get(ctx, &first, entities[0].UID))
+	require.NoError(t, client.Get(ctx, &second, entities[1].UID))
+	require.Equal(t, "a much longer first name", first.Name)
+	require.ElementsMatch(t, []string{"x", "y", "z"}, first.Tags)
+	require.Equal(t, "second", second.Name)
+	require.Empty(t, second.Tags)
+
+	for i := 0; i < 2; i++ {
+		nodes, err := client.QueryInterface(ctx, "CodecEntity", func() any { return &CodecEntity{} })
+		require.NoError(t, err)
+		require.Len(t, nodes, 2)
+		names := []string{nodes[0].(*CodecEntity).Name, nodes[1].(*CodecEntity).Name}
+		require.ElementsMatch(t, []string{"a much longer first name", "second"}, names)
+	}
+	require.Equal(t, "a much longer first name", first.Name, "Pooled reads should not change decoded values")
+}
@@ -0,0 +1,77 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/dgraph-io/dgraph/v25/protos/pb"
+	"github.com/dgraph-io/dgraph/v25/x"
+)
+
+// CommitInfo describes what one commit wrote, for a change-data-capture
+// pipeline to act on: the nodes it set or deleted predicates of, and those
+// predicates. A deleted node is listed with each predicate it held. Both
+// lists are sorted; UIDs are in hex form, such as "0x2a".
+type CommitInfo struct {
+	Namespace  uint64
+	CommitTs   uint64
+	UIDs       []string
+	Predicates []string
+}
+
+// commitChanges accumulates the nodes and predicates written by the
+// mutations of one transaction.
+type commitChanges struct {
+	ns    uint64
+	uids  map[uint64]struct{}
+	preds map[string]struct{}
+}
+
+func newCommitChanges(ns uint64) *commitChanges {
+	return &commitChanges{
+		ns:    ns,
+		uids:  make(map[uint64]struct{}),
+		preds: make(map[string]struct{}),
+	}
+}
+
+// add records the subject and predicate of each of edges.
+func (c *commitChanges) add(edges []*pb.DirectedEdge) {
+	for _, edge := range edges {
+		c.uids[edge.Entity] = struct{}{}
+		c.preds[x.ParseAttr(edge.Attr)] = struct{}{}
+	}
+}
+
+// info returns the changes as the CommitInfo of the commit at commitTs.
+func (c *commitChanges) info(commitTs uint64) *CommitInfo {
+	info := &CommitInfo{
+		Namespace:  c.ns,
+		CommitTs:   commitTs,
+		UIDs:       make([]string, 0, len(c.uids)),
+		Predicates: slices.Sorted(maps.Keys(c.preds)),
+	}
+	for _, uid := range slices.Sorted(maps.Keys(c.uids)) {
+		info.UIDs = append(info.UIDs, fmt.Sprintf("%#x", uid))
+	}
+	return info
+}
+
+// notifyCommit passes info to the commit hook, if a commit wrote anything
+// to report. It must be called without the engine's lock held.
+func (engine *Engine) notifyCommit(ctx context.Context, info *CommitInfo) {
+	if info == nil || engine.commitHook == nil {
+		return
+	}
+	// A committed PendingTxn finishes through a context that carries it;
+	// the hook's own operations must not try to join it.
+	ctx = context.WithValue(ctx, pendingTxnKey{}, (*PendingTxn)(nil))
+	engine.commitHook(ctx, *info)
+}
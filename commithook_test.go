@@ -0,0 +1,93 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+type HookedNote struct {
+	UID   string   `json:"uid,omitempty"`
+	Title string   `json:"hooked_title,omitempty" dgraph:"index=exact"`
+	Body  string   `json:"hooked_body,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+func TestClientWithCommitHook(t *testing.T) {
+	ctx := context.Background()
+	var (
+		mu      sync.Mutex
+		commits []modusgraph.CommitInfo
+		client  modusgraph.Client
+	)
+	hook := func(ctx context.Context, info modusgraph.CommitInfo) {
+		// The hook may read what the commit wrote
+		var note HookedNote
+		require.NoError(t, client.Get(ctx, &note, info.UIDs[0]))
+		mu.Lock()
+		defer mu.Unlock()
+		commits = append(commits, info)
+	}
+	taken := func() []modusgraph.CommitInfo {
+		mu.Lock()
+		defer mu.Unlock()
+		got := commits
+		commits = nil
+		return got
+	}
+
+	var err error
+	client, err = modusgraph.NewClient("file://"+GetTempDir(t), modusgraph.WithAutoSchema(true),
+		modusgraph.WithCommitHook(hook))
+	require.NoError(t, err)
+	defer func() {
+		client.Close()
+		modusgraph.Shutdown()
+	}()
+
+	notes := []*HookedNote{{Title: "a", Body: "first"}, {Title: "b", Body: "second"}}
+	require.NoError(t, client.Insert(ctx, notes))
+	got := taken()
+	require.Len(t, got, 1, "One insert should be one commit")
+	require.ElementsMatch(t, []string{notes[0].UID, notes[1].UID}, got[0].UIDs)
+	require.Equal(t, []string{"dgraph.type", "hooked_body", "hooked_title"}, got[0].Predicates)
+	require.NotZero(t, got[0].CommitTs)
+
+	notes[1].Body = "revised"
+	require.NoError(t, client.Update(ctx, notes[1]))
+	got = taken()
+	require.Len(t, got, 1, "One update should be one commit")
+	require.Equal(t, []string{notes[1].UID}, got[0].UIDs, "Only the updated note was written")
+	require.Contains(t, got[0].Predicates, "hooked_body")
+
+	// A deferred transaction reports its writes once, when it commits
+	txCtx, txn := modusgraph.DeferCommit(ctx)
+	third := &HookedNote{Title: "c", Body: "third"}
+	require.NoError(t, client.Insert(txCtx, third))
+	notes[0].Body = "revised"
+	require.NoError(t, client.Update(txCtx, notes[0]))
+	require.Empty(t, taken(), "Nothing is reported before the commit")
+	require.NoError(t, txn.Commit(ctx))
+	got = taken()
+	require.Len(t, got, 1)
+	require.ElementsMatch(t, []string{notes[0].UID, third.UID}, got[0].UIDs)
+
+	txCtx, txn = modusgraph.DeferCommit(ctx)
+	require.NoError(t, client.Insert(txCtx, &HookedNote{Title: "d"}))
+	require.NoError(t, txn.Discard(ctx))
+	require.Empty(t, taken(), "A discarded transaction reports nothing")
+
+	// A mutation that fails reports nothing
+	_, err = client.UpdateWhere(ctx, HookedNote{}, `eq(hooked_title, "a")`,
+		map[string]any{"hooked_body": map[string]any{"not": "a string"}})
+	require.Error(t, err)
+	require.Empty(t, taken())
+}
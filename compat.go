@@ -0,0 +1,177 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/dgraph-io/dgraph/v25/types"
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// Incompatibility describes a predicate whose type in a proposed schema
+// conflicts with the data the database already holds for it.
+type Incompatibility struct {
+	Predicate string
+	Current   string // the type in the database's schema, such as "string" or "[uid]"
+	Proposed  string // the type the models declare
+	Reason    string
+	Count     int    // the number of existing values affected
+	UID       string // a node holding one of them
+	Value     string // that node's value, in its JSON form; empty for uid edges
+}
+
+func (i Incompatibility) String() string {
+	return fmt.Sprintf("%s: %s to %s: %s (%d values, e.g. %s)",
+		i.Predicate, i.Current, i.Proposed, i.Reason, i.Count, i.UID)
+}
+
+// ValidateSchemaCompatibility implements checking the models' schema against
+// the existing data without altering anything. For every predicate whose
+// value type the models change, it reads the stored values and tries to
+// convert each to the new type with Dgraph's own conversion rules; a change
+// between uid and a scalar type is reported whenever the predicate holds any
+// data, since Dgraph refuses it. Predicates that are new, or keep their type,
+// are not reported.
+func (c client) ValidateSchemaCompatibility(ctx context.Context, models ...any) ([]Incompatibility, error) {
+	models = slices.Clone(models)
+	for i := range models {
+		models[i] = UnwrapSchema(models[i])
+	}
+	ts := dg.NewTypeSchema()
+	ts.Marshal("", models...)
+	if c.options.tagName != "" && c.options.tagName != "dgraph" {
+		overlayAltTag(ts.Schema, c.options.tagName, models...)
+	}
+	for _, pred := range computedPredicates(models...) {
+		delete(ts.Schema, pred)
+	}
+	c.options.namingStrategy.deriveTypeSchema(ts)
+
+	in, err := c.Introspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var found []Incompatibility
+	for _, name := range slices.Sorted(maps.Keys(ts.Schema)) {
+		proposed := ts.Schema[name]
+		current, ok := in.Predicate(name)
+		if !ok || current.Type == proposed.Type {
+			continue
+		}
+		inc, err := c.checkTypeChange(ctx, current, proposed)
+		if err != nil {
+			return nil, fmt.Errorf("ValidateSchemaCompatibility: %s: %w", name, err)
+		}
+		if inc != nil {
+			found = append(found, *inc)
+		}
+	}
+	return found, nil
+}
+
+// checkTypeChange reads the values stored under current and reports the ones
+// that cannot take proposed's type, or nil when all of them can.
+func (c client) checkTypeChange(ctx context.Context, current PredicateInfo,
+	proposed *dg.Schema) (*Incompatibility, error) {
+	inc := &Incompatibility{
+		Predicate: current.Name,
+		Current:   schemaTypeName(current.Type, current.List),
+		Proposed:  schemaTypeName(proposed.Type, proposed.List),
+	}
+	if current.Type == "password" {
+		// Stored passwords cannot be read back, only checked.
+		return nil, nil
+	}
+	fromID, fromOK := types.TypeForName(current.Type)
+	toID, toOK := types.TypeForName(proposed.Type)
+	if !fromOK || !toOK {
+		return nil, fmt.Errorf("unknown type %s or %s", current.Type, proposed.Type)
+	}
+
+	query := fmt.Sprintf("{ q(func: has(%s)) { uid %s } }", current.Name, current.Name)
+	if fromID == types.UidID || toID == types.UidID {
+		query = fmt.Sprintf("{ q(func: has(%s)) { uid } }", current.Name)
+	}
+	data, err := c.queryRaw(ctx, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Q []map[string]json.RawMessage `json:"q"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+
+	if fromID == types.UidID || toID == types.UidID {
+		if len(resp.Q) == 0 {
+			return nil, nil
+		}
+		inc.Reason = "Dgraph does not change a predicate between uid and a scalar type while it holds data"
+		inc.Count = len(resp.Q)
+		_ = json.Unmarshal(resp.Q[0]["uid"], &inc.UID)
+		return inc, nil
+	}
+	for _, node := range resp.Q {
+		raw := node[current.Name]
+		values := []json.RawMessage{raw}
+		if current.List {
+			if err := json.Unmarshal(raw, &values); err != nil {
+				return nil, err
+			}
+		}
+		for _, value := range values {
+			err := convertStored(value, fromID, toID)
+			if err == nil {
+				continue
+			}
+			if inc.Count == 0 {
+				inc.Reason = err.Error()
+				_ = json.Unmarshal(node["uid"], &inc.UID)
+				inc.Value = string(value)
+			}
+			inc.Count++
+		}
+	}
+	if inc.Count == 0 {
+		return nil, nil
+	}
+	return inc, nil
+}
+
+// convertStored converts value, a JSON value read from a predicate of type
+// from, to type to the way Dgraph does once the schema changes: from the
+// value's stored binary form.
+func convertStored(value json.RawMessage, from, to types.TypeID) error {
+	text := string(value)
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		text = s
+	}
+	native, err := types.Convert(types.Val{Tid: types.StringID, Value: []byte(text)}, from)
+	if err != nil {
+		return err
+	}
+	stored := types.Val{Tid: types.BinaryID}
+	if err := types.Marshal(native, &stored); err != nil {
+		return err
+	}
+	_, err = types.Convert(types.Val{Tid: from, Value: stored.Value}, to)
+	return err
+}
+
+// schemaTypeName renders a value type as the schema declares it.
+func schemaTypeName(typ string, list bool) string {
+	if list {
+		return "[" + typ + "]"
+	}
+	return typ
+}
@@ -0,0 +1,111 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type CompatScore struct {
+	UID   string   `json:"uid,omitempty"`
+	Label string   `json:"compat_label,omitempty"`
+	Score string   `json:"compat_score,omitempty"`
+	Rank  string   `json:"compat_rank,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+type CompatLabel struct {
+	UID   string   `json:"uid,omitempty"`
+	Name  string   `json:"compat_label_name,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+// CompatScoreV2 is CompatScore after a migration that makes its values
+// numbers and its label an edge.
+type CompatScoreV2 struct {
+	UID   string       `json:"uid,omitempty"`
+	Label *CompatLabel `json:"compat_label,omitempty"`
+	Score int          `json:"compat_score,omitempty"`
+	Rank  int          `json:"compat_rank,omitempty"`
+	Note  string       `json:"compat_note,omitempty"`
+	DType []string     `json:"dgraph.type,omitempty"`
+}
+
+func TestValidateSchemaCompatibility(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "ValidateSchemaCompatibilityWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "ValidateSchemaCompatibilityWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			ctx := context.Background()
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			found, err := client.ValidateSchemaCompatibility(ctx, CompatScoreV2{})
+			require.NoError(t, err)
+			require.Empty(t, found, "An empty database conflicts with nothing")
+
+			scores := []*CompatScore{
+				{Label: "a", Score: "12", Rank: "1"},
+				{Label: "b", Score: "high", Rank: "2"},
+				{Label: "c", Score: "7", Rank: "3"},
+			}
+			require.NoError(t, client.Insert(ctx, scores))
+
+			found, err = client.ValidateSchemaCompatibility(ctx, &CompatScoreV2{})
+			require.NoError(t, err)
+			require.Len(t, found, 2, "Every rank converts to an int, so only the label and score conflict")
+
+			label := found[0]
+			require.Equal(t, "compat_label", label.Predicate)
+			require.Equal(t, "string", label.Current)
+			require.Equal(t, "uid", label.Proposed)
+			require.Equal(t, 3, label.Count)
+
+			score := found[1]
+			require.Equal(t, "compat_score", score.Predicate)
+			require.Equal(t, "string", score.Current)
+			require.Equal(t, "int", score.Proposed)
+			require.Equal(t, 1, score.Count)
+			require.Equal(t, scores[1].UID, score.UID)
+			require.Equal(t, `"high"`, score.Value)
+			require.NotEmpty(t, score.Reason)
+
+			// Nothing was altered
+			in, err := client.Introspect(ctx)
+			require.NoError(t, err)
+			pred, ok := in.Predicate("compat_score")
+			require.True(t, ok)
+			require.Equal(t, "string", pred.Type)
+
+			found, err = client.ValidateSchemaCompatibility(ctx, CompatScore{})
+			require.NoError(t, err)
+			require.Empty(t, found, "The current models match the schema")
+		})
+	}
+}
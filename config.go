@@ -6,33 +6,96 @@
 package modusgraph
 
 import (
+	"context"
+	"os"
+	"path"
+	"strings"
+	"time"
+
 	"github.com/go-logr/logr"
 )
 
 type Config struct {
 	dataDir            string
+	name               string
 	cacheSizeMB        int
 	limitNormalizeNode int
+	gcInterval         time.Duration
+
+	// dirMode is the permission mode of the p, w and t directories
+	dirMode os.FileMode
+
+	// numMemtables and maxOpenFiles tune the posting store; zero keeps
+	// Badger's defaults
+	numMemtables int
+	maxOpenFiles int
+
+	// lockWait is how long NewEngine waits for another process to release
+	// the data directory
+	lockWait time.Duration
+
+	// commitHook is called after each commit of a mutation
+	commitHook func(context.Context, CommitInfo)
+
+	// baseCtx is the parent context of the engine's background work
+	baseCtx context.Context
 
 	// logger is used for structured logging
 	logger logr.Logger
 }
 
+// DefaultLimitNormalizeNode is the number of nodes a query's @normalize
+// directive may produce before Dgraph rejects it, unless the Config sets
+// another limit with WithLimitNormalizeNode.
+const DefaultLimitNormalizeNode = 10000
+
 func NewDefaultConfig(dir string) Config {
 	return Config{
 		dataDir:            dir,
-		limitNormalizeNode: 10000,
+		limitNormalizeNode: DefaultLimitNormalizeNode,
+		baseCtx:            context.Background(),
 		logger:             logr.Discard(),
 		cacheSizeMB:        64, // 64 MB
+		dirMode:            DefaultDirMode,
 	}
 }
 
+// DefaultDirMode is the permission mode of the engine's posting, WAL and temp
+// directories, unless the Config sets another with WithDirMode.
+const DefaultDirMode os.FileMode = 0o700
+
 // WithLimitNormalizeNode sets the limit for the number of nodes to normalize
 func (cc Config) WithLimitNormalizeNode(d int) Config {
 	cc.limitNormalizeNode = d
 	return cc
 }
 
+// LimitNormalizeNode returns the limit for the number of nodes to normalize,
+// which applies to every query that does not override it with
+// ContextWithNormalizeLimit.
+func (cc Config) LimitNormalizeNode() int {
+	return cc.limitNormalizeNode
+}
+
+// normalizeLimitKey is the context key of a per-query @normalize node limit.
+type normalizeLimitKey struct{}
+
+// ContextWithNormalizeLimit returns a copy of ctx under which queries run by
+// an embedded (file://) engine may produce up to n nodes through @normalize,
+// in place of the engine's configured limit, so a single wide query can be
+// let through without raising the limit for every query. n must be positive;
+// a remote Dgraph cluster applies its own limit and ignores this one.
+func ContextWithNormalizeLimit(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, normalizeLimitKey{}, n)
+}
+
+// normalizeLimitFromContext returns the limit ContextWithNormalizeLimit set
+// on ctx, if it set a positive one.
+func normalizeLimitFromContext(ctx context.Context) (int, bool) {
+	n, ok := ctx.Value(normalizeLimitKey{}).(int)
+	return n, ok && n > 0
+}
+
 // WithLogger sets a structured logger for the engine
 func (cc Config) WithLogger(logger logr.Logger) Config {
 	cc.logger = logger
@@ -45,14 +108,147 @@ func (cc Config) WithCacheSizeMB(size int) Config {
 	return cc
 }
 
+// WithGCInterval runs Engine.RunGC in the background every d, keeping disk
+// usage bounded for long-running engines with churny workloads. Zero, the
+// default, disables periodic GC.
+func (cc Config) WithGCInterval(d time.Duration) Config {
+	cc.gcInterval = d
+	return cc
+}
+
+// WithBaseContext sets the parent context of the work the engine does on its
+// own rather than for a caller, such as periodic GC. Canceling ctx stops that
+// work; the engine itself stays open until Close.
+func (cc Config) WithBaseContext(ctx context.Context) Config {
+	cc.baseCtx = ctx
+	return cc
+}
+
+// WithName places the engine's posting, WAL and temp directories under a
+// subdirectory of the data directory with the given name, so several logical
+// databases can share one parent directory.
+func (cc Config) WithName(name string) Config {
+	cc.name = name
+	return cc
+}
+
+// WithDirMode sets the permission mode the engine gives its posting, WAL and
+// temp directories, for example 0o750 to let a group read backups taken from
+// them. The mode must grant the owner read, write and search permission.
+func (cc Config) WithDirMode(mode os.FileMode) Config {
+	cc.dirMode = mode
+	return cc
+}
+
+// WithNumMemtables sets how many memtables the posting store keeps before it
+// stalls writes. Each memtable is backed by an open, memory-mapped file, so
+// fewer memtables mean fewer open files and less memory. Zero keeps Badger's
+// default of 5.
+func (cc Config) WithNumMemtables(n int) Config {
+	cc.numMemtables = n
+	return cc
+}
+
+// WithMaxOpenFiles sets how many files the engine may need to keep open.
+// Badger keeps every table file open and has no limit of its own, so rather
+// than fail partway through opening its stores in a container with a low
+// ulimit, NewEngine raises the process's soft open-file limit to n if it is
+// lower, and fails with ErrOpenFilesLimit if the hard limit is below n. Zero
+// leaves the process limit alone.
+func (cc Config) WithMaxOpenFiles(n int) Config {
+	cc.maxOpenFiles = n
+	return cc
+}
+
+// WithLockWait sets how long NewEngine waits for the data directory to be
+// released when another process holds it open, such as a test binary that
+// has not exited yet. NewEngine checks the directory every 100ms and fails
+// with ErrDataDirLocked if it is still locked once d has passed. Zero, the
+// default, fails at once.
+func (cc Config) WithLockWait(d time.Duration) Config {
+	cc.lockWait = d
+	return cc
+}
+
+// WithCommitHook makes the engine call hook after each commit of a
+// mutation, with the UIDs and predicates it wrote; see CommitInfo. The
+// mutations of a transaction are reported together when it commits, and
+// nothing is reported for one that is discarded. hook runs on the
+// committing goroutine once the engine's locks are released, so it may read
+// the database, but a slow hook delays the caller.
+func (cc Config) WithCommitHook(hook func(context.Context, CommitInfo)) Config {
+	cc.commitHook = hook
+	return cc
+}
+
+// baseDir returns the directory holding the engine's p, w and t directories.
+func (cc Config) baseDir() string {
+	return path.Join(cc.dataDir, cc.name)
+}
+
+func (cc Config) postingDir() string {
+	return path.Join(cc.baseDir(), "p")
+}
+
+func (cc Config) walDir() string {
+	return path.Join(cc.baseDir(), "w")
+}
+
+func (cc Config) tmpDir() string {
+	return path.Join(cc.baseDir(), "t")
+}
+
+// makeDirs creates the p, w and t directories with the configured mode,
+// setting it on any that already exist.
+func (cc Config) makeDirs() error {
+	for _, dir := range []string{cc.postingDir(), cc.walDir(), cc.tmpDir()} {
+		if err := os.MkdirAll(dir, cc.dirMode); err != nil {
+			return err
+		}
+		// MkdirAll's mode is filtered through the umask.
+		if err := os.Chmod(dir, cc.dirMode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (cc Config) validate() error {
 	if cc.dataDir == "" {
 		return ErrEmptyDataDir
 	}
 
+	if cc.name == "." || cc.name == ".." || strings.ContainsAny(cc.name, `/\`) {
+		return ErrInvalidName
+	}
+
 	if cc.cacheSizeMB < 0 {
 		return ErrInvalidCacheSize
 	}
 
+	if cc.gcInterval < 0 {
+		return ErrInvalidGCInterval
+	}
+
+	if cc.dirMode&0o700 != 0o700 || cc.dirMode&^os.ModePerm != 0 {
+		return ErrInvalidDirMode
+	}
+
+	if cc.numMemtables < 0 {
+		return ErrInvalidNumMemtables
+	}
+
+	if cc.maxOpenFiles < 0 {
+		return ErrInvalidMaxOpenFiles
+	}
+
+	if cc.lockWait < 0 {
+		return ErrInvalidLockWait
+	}
+
+	if cc.baseCtx == nil {
+		return ErrNilBaseContext
+	}
+
 	return nil
 }
@@ -0,0 +1,101 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigWithName(t *testing.T) {
+	parent := t.TempDir()
+
+	unnamed := NewDefaultConfig(parent)
+	require.Equal(t, path.Join(parent, "p"), unnamed.postingDir())
+	require.Equal(t, path.Join(parent, "w"), unnamed.walDir())
+	require.Equal(t, path.Join(parent, "t"), unnamed.tmpDir())
+
+	graph1 := NewDefaultConfig(parent).WithName("graph1")
+	graph2 := NewDefaultConfig(parent).WithName("graph2")
+	require.NoError(t, graph1.validate())
+	require.NoError(t, graph2.validate())
+
+	require.Equal(t, path.Join(parent, "graph1", "p"), graph1.postingDir())
+	require.Equal(t, path.Join(parent, "graph1", "w"), graph1.walDir())
+	require.Equal(t, path.Join(parent, "graph1", "t"), graph1.tmpDir())
+	require.NotEqual(t, graph1.postingDir(), graph2.postingDir())
+	require.NotEqual(t, graph1.walDir(), graph2.walDir())
+	require.NotEqual(t, graph1.tmpDir(), graph2.tmpDir())
+
+	for _, name := range []string{"..", ".", "a/b", `a\b`} {
+		require.ErrorIs(t, NewDefaultConfig(parent).WithName(name).validate(), ErrInvalidName, name)
+	}
+}
+
+func TestConfigWithGCInterval(t *testing.T) {
+	require.Zero(t, NewDefaultConfig(t.TempDir()).gcInterval, "periodic GC is off by default")
+	require.NoError(t, NewDefaultConfig(t.TempDir()).WithGCInterval(time.Minute).validate())
+	require.ErrorIs(t, NewDefaultConfig(t.TempDir()).WithGCInterval(-time.Second).validate(), ErrInvalidGCInterval)
+}
+
+func TestConfigWithBaseContext(t *testing.T) {
+	require.Equal(t, context.Background(), NewDefaultConfig(t.TempDir()).baseCtx)
+	require.ErrorIs(t, NewDefaultConfig(t.TempDir()).WithBaseContext(nil).validate(), ErrNilBaseContext)
+}
+
+func TestBaseContextStopsPeriodicGC(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, err := NewClient("file://"+t.TempDir(), WithBaseContext(ctx))
+	require.NoError(t, err)
+	engine := c.(client).engine
+	require.Equal(t, ctx, engine.baseCtx, "the client hands its base context to the engine")
+	c.Close()
+
+	engine, err = NewEngine(NewDefaultConfig(t.TempDir()).
+		WithGCInterval(10 * time.Millisecond).
+		WithBaseContext(ctx))
+	require.NoError(t, err)
+	defer engine.Close()
+
+	select {
+	case <-engine.gcDone:
+		t.Fatal("periodic GC stopped before the base context was canceled")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case <-engine.gcDone:
+	case <-time.After(10 * time.Second):
+		t.Fatal("canceling the base context did not stop the periodic GC")
+	}
+
+	// Only the background work stops; the engine stays usable.
+	ns := engine.GetDefaultNamespace()
+	require.NoError(t, ns.AlterSchema(context.Background(), "label: string ."))
+}
+
+func TestConfigStorageOptions(t *testing.T) {
+	conf := NewDefaultConfig(t.TempDir())
+	require.Equal(t, DefaultDirMode, conf.dirMode)
+	require.Zero(t, conf.numMemtables, "Badger's memtable count is kept by default")
+	require.Zero(t, conf.maxOpenFiles, "the open-file limit is left alone by default")
+
+	require.NoError(t, conf.WithDirMode(0o750).WithNumMemtables(2).WithMaxOpenFiles(256).validate())
+	for _, mode := range []os.FileMode{0o500, 0o077, os.ModeDir | 0o700} {
+		require.ErrorIs(t, conf.WithDirMode(mode).validate(), ErrInvalidDirMode, mode.String())
+	}
+	require.ErrorIs(t, conf.WithNumMemtables(-1).validate(), ErrInvalidNumMemtables)
+	require.ErrorIs(t, conf.WithMaxOpenFiles(-1).validate(), ErrInvalidMaxOpenFiles)
+	require.ErrorIs(t, conf.WithLockWait(-time.Second).validate(), ErrInvalidLockWait)
+}
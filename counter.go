@@ -0,0 +1,122 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dgraph-io/dgo/v250"
+	"github.com/dgraph-io/dgo/v250/protos/api"
+)
+
+// Increment adds delta to the integer predicate of the node uid and returns
+// the new value. A node without a value for predicate counts from zero. The
+// current value is read and the new one written in a single transaction, so
+// concurrent increments of the same counter never lose an update: the
+// embedded engine serializes them, as LoadAndDelete does, and against a
+// Dgraph cluster the loser of a commit conflict aborts and retries against
+// the winner's value.
+func (c client) Increment(ctx context.Context, uid, predicate string, delta int64) (int64, error) {
+	if uid == "" {
+		return 0, fmt.Errorf("Increment: empty UID")
+	}
+	if !isValidPredicateName(predicate) {
+		return 0, fmt.Errorf("Increment: invalid predicate %q", predicate)
+	}
+	predicate = c.names.derive(predicate)
+
+	// In a PendingTxn the read and write commit with the rest of it, so a
+	// conflict is reported by Commit rather than retried here.
+	if pending := pendingTxnFrom(ctx); pending != nil {
+		tx, err := pending.join(ctx, c.pool)
+		if err != nil {
+			return 0, err
+		}
+		return increment(ctx, tx.Txn(), uid, predicate, delta, false)
+	}
+
+	dgClient, err := c.pool.get()
+	if err != nil {
+		c.logger.Error(err, "Failed to get client from pool")
+		return 0, err
+	}
+	defer c.pool.put(dgClient)
+
+	if c.engine != nil && c.consumeMu != nil {
+		c.consumeMu.Lock()
+		defer c.consumeMu.Unlock()
+	}
+
+	// Every concurrent increment of one counter conflicts with the others, so
+	// allow more attempts than LoadAndDelete, whose losers stop at not-found.
+	const maxAttempts = 100
+	for attempt := 0; ; attempt++ {
+		txn := dgClient.NewTxn()
+		next, err := increment(ctx, txn, uid, predicate, delta, true)
+		if err != nil {
+			_ = txn.Discard(ctx)
+			if isAbortedErr(err) && attempt < maxAttempts {
+				continue
+			}
+			return 0, err
+		}
+		return next, nil
+	}
+}
+
+// increment reads the counter predicate of uid within txn and writes it back
+// plus delta, committing the write when commitNow is set.
+func increment(ctx context.Context, txn *dgo.Txn, uid, predicate string, delta int64,
+	commitNow bool) (int64, error) {
+	query := "query q($uid: string) { q(func: uid($uid)) { v: " + predicate + " } }"
+	resp, err := txn.QueryWithVars(ctx, query, map[string]string{"$uid": uid})
+	if err != nil {
+		return 0, err
+	}
+	current, err := counterValue(resp.GetJson())
+	if err != nil {
+		return 0, fmt.Errorf("Increment: reading %s of %s: %w", predicate, uid, err)
+	}
+	next := current + delta
+	_, err = txn.Mutate(ctx, &api.Mutation{
+		Set: []*api.NQuad{{
+			Subject:     uid,
+			Predicate:   predicate,
+			ObjectValue: &api.Value{Val: &api.Value_IntVal{IntVal: next}},
+		}},
+		CommitNow: commitNow,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return next, nil
+}
+
+// counterValue decodes the counter read by Increment's query, which is zero
+// when the node has no value yet.
+func counterValue(resp []byte) (int64, error) {
+	var result struct {
+		Q []struct {
+			V *json.Number `json:"v"`
+		} `json:"q"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(resp))
+	dec.UseNumber()
+	if err := dec.Decode(&result); err != nil {
+		return 0, err
+	}
+	if len(result.Q) == 0 || result.Q[0].V == nil {
+		return 0, nil
+	}
+	n, err := result.Q[0].V.Int64()
+	if err != nil {
+		return 0, fmt.Errorf("value %s is not an integer", *result.Q[0].V)
+	}
+	return n, nil
+}
@@ -0,0 +1,117 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type PageCounter struct {
+	UID   string   `json:"uid,omitempty"`
+	Path  string   `json:"path,omitempty" dgraph:"index=exact"`
+	Views int64    `json:"views,omitempty" dgraph:"index=int"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+func TestClientIncrement(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "IncrementWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "IncrementWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			page := PageCounter{Path: "/home"}
+			require.NoError(t, client.Insert(ctx, &page), "Insert should succeed")
+
+			t.Run("sequential", func(t *testing.T) {
+				n, err := client.Increment(ctx, page.UID, "views", 5)
+				require.NoError(t, err)
+				require.Equal(t, int64(5), n, "a missing value counts from zero")
+
+				n, err = client.Increment(ctx, page.UID, "views", -2)
+				require.NoError(t, err)
+				require.Equal(t, int64(3), n)
+
+				var got PageCounter
+				require.NoError(t, client.Get(ctx, &got, page.UID))
+				require.Equal(t, int64(3), got.Views)
+			})
+
+			t.Run("concurrent", func(t *testing.T) {
+				const workers, perWorker = 10, 10
+				start := int64(3)
+				var (
+					wg      sync.WaitGroup
+					sum     int64
+					results = make(chan int64, workers*perWorker)
+					errs    = make(chan error, workers*perWorker)
+				)
+				for w := range workers {
+					delta := int64(w + 1)
+					sum += delta * perWorker
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						for range perWorker {
+							n, err := client.Increment(ctx, page.UID, "views", delta)
+							if err != nil {
+								errs <- err
+								return
+							}
+							results <- n
+						}
+					}()
+				}
+				wg.Wait()
+				close(results)
+				close(errs)
+				for err := range errs {
+					require.NoError(t, err)
+				}
+				seen := make(map[int64]bool)
+				for n := range results {
+					require.False(t, seen[n], "value %d returned twice: an update was lost", n)
+					seen[n] = true
+				}
+
+				var got PageCounter
+				require.NoError(t, client.Get(ctx, &got, page.UID))
+				require.Equal(t, start+sum, got.Views, "final value should equal the sum of deltas")
+			})
+
+			t.Run("InvalidPredicate", func(t *testing.T) {
+				_, err := client.Increment(ctx, page.UID, "views) { uid", 1)
+				require.Error(t, err)
+			})
+		})
+	}
+}
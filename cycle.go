@@ -0,0 +1,172 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrCyclicGraph is returned when an object passed to a mutation reaches
+// itself through its edges, as when two people list each other as friends.
+// dgman renders every edge target as a nested node, so it would recurse
+// through the cycle without end.
+var ErrCyclicGraph = errors.New("object graph contains a cycle")
+
+// cycleKey identifies a value a cycle can pass through. The type is part of
+// the key because a struct and its first field share an address.
+type cycleKey struct {
+	t    reflect.Type
+	addr uintptr
+}
+
+// cycleWalk tracks the pointers and slices on the path being walked, by how
+// deep in the path they were reached, and those already walked without
+// finding one. path holds the steps taken from the root, rendered only when a
+// cycle is reported.
+type cycleWalk struct {
+	root   string
+	path   []cycleStep
+	onPath map[cycleKey]int
+	done   map[cycleKey]bool
+}
+
+// cycleStep is a step of a cycleWalk's path: into the named struct field, or
+// into element index of a slice or array when field is "".
+type cycleStep struct {
+	field string
+	index int
+}
+
+// checkCycles returns an ErrCyclicGraph naming the first edge found that
+// leads back to a node on its own path. A node reached twice along different
+// paths, as when two people share a friend, is not a cycle.
+func checkCycles(val reflect.Value) error {
+	if !val.IsValid() {
+		return nil
+	}
+	name := "object"
+	if t := derefType(val.Type()); t.Name() != "" {
+		name = t.Name()
+	}
+	w := &cycleWalk{root: name, onPath: make(map[cycleKey]int), done: make(map[cycleKey]bool)}
+	return w.walk(val)
+}
+
+func (w *cycleWalk) walk(val reflect.Value) error {
+	if !mayCycle(val.Type()) {
+		return nil
+	}
+	switch val.Kind() {
+	case reflect.Pointer, reflect.Slice:
+		if val.IsNil() || (val.Kind() == reflect.Slice && val.Len() == 0) {
+			return nil
+		}
+		key := cycleKey{val.Type(), val.Pointer()}
+		if w.done[key] {
+			return nil
+		}
+		if depth, ok := w.onPath[key]; ok {
+			return fmt.Errorf("%w: %s refers back to %s", ErrCyclicGraph, w.render(len(w.path)), w.render(depth))
+		}
+		w.onPath[key] = len(w.path)
+		var err error
+		if val.Kind() == reflect.Pointer {
+			err = w.walk(val.Elem())
+		} else {
+			err = w.walkElems(val)
+		}
+		delete(w.onPath, key)
+		w.done[key] = err == nil
+		return err
+	case reflect.Interface:
+		return w.walk(val.Elem())
+	case reflect.Array:
+		return w.walkElems(val)
+	case reflect.Struct:
+		t := val.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			w.path = append(w.path, cycleStep{field: t.Field(i).Name})
+			err := w.walk(val.Field(i))
+			w.path = w.path[:len(w.path)-1]
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// walkElems walks each element of the slice or array val.
+func (w *cycleWalk) walkElems(val reflect.Value) error {
+	for i := 0; i < val.Len(); i++ {
+		w.path = append(w.path, cycleStep{index: i})
+		err := w.walk(val.Index(i))
+		w.path = w.path[:len(w.path)-1]
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// render returns the first depth steps of the path, such as
+// Person.Friends[0].Friends.
+func (w *cycleWalk) render(depth int) string {
+	var b strings.Builder
+	b.WriteString(w.root)
+	for _, step := range w.path[:depth] {
+		if step.field != "" {
+			b.WriteString("." + step.field)
+		} else {
+			b.WriteString("[" + strconv.Itoa(step.index) + "]")
+		}
+	}
+	return b.String()
+}
+
+// mayCycleTypes caches mayCycle by type.
+var mayCycleTypes sync.Map
+
+// mayCycle reports whether a value of type t can hold a pointer, slice, or
+// interface the walk follows, and so be part of a cycle. Scalars, and slices
+// and arrays of them such as a []byte blob or a []float32 vector, cannot.
+func mayCycle(t reflect.Type) bool {
+	if v, ok := mayCycleTypes.Load(t); ok {
+		return v.(bool)
+	}
+	var may bool
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		may = true
+	case reflect.Slice, reflect.Array:
+		may = mayCycle(t.Elem())
+	case reflect.Struct:
+		// A struct reaching itself through a slice of its values recurses
+		// here; until its fields are read it is taken to be able to cycle.
+		mayCycleTypes.Store(t, true)
+		for i := 0; i < t.NumField() && !may; i++ {
+			may = t.Field(i).IsExported() && mayCycle(t.Field(i).Type)
+		}
+	}
+	mayCycleTypes.Store(t, may)
+	return may
+}
+
+// derefType strips any pointer and slice layers from t.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t
+}
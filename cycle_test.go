@@ -0,0 +1,88 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertCyclicGraph(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "CyclicGraphWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "CyclicGraphWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			ctx := context.Background()
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			require.NoError(t, client.UpdateSchema(ctx, FoafPerson{}))
+			countPeople := func() int {
+				var people []FoafPerson
+				require.NoError(t, client.Query(ctx, FoafPerson{}).Nodes(&people))
+				return len(people)
+			}
+
+			// Alice and Bob list each other as friends
+			alice := &FoafPerson{Name: "Alice"}
+			bob := &FoafPerson{Name: "Bob", Friends: []*FoafPerson{alice}}
+			alice.Friends = []*FoafPerson{bob}
+			err := client.Insert(ctx, alice)
+			require.ErrorIs(t, err, modusgraph.ErrCyclicGraph)
+			require.Contains(t, err.Error(), "FoafPerson.Friends[0].Friends[0] refers back to FoafPerson")
+			require.Zero(t, countPeople(), "Nothing should be written")
+
+			err = client.Insert(ctx, []*FoafPerson{bob, alice})
+			require.ErrorIs(t, err, modusgraph.ErrCyclicGraph)
+			err = client.Upsert(ctx, alice)
+			require.ErrorIs(t, err, modusgraph.ErrCyclicGraph)
+
+			carol := &FoafPerson{Name: "Carol"}
+			carol.Friends = []*FoafPerson{carol}
+			err = client.Insert(ctx, carol)
+			require.ErrorIs(t, err, modusgraph.ErrCyclicGraph, "A node listing itself is a cycle")
+
+			// Nodes that already exist form a cycle in memory just the same
+			alice.Friends, bob.Friends = nil, nil
+			require.NoError(t, client.Insert(ctx, []*FoafPerson{alice, bob}))
+			alice.Friends = []*FoafPerson{bob}
+			bob.Friends = []*FoafPerson{alice}
+			err = client.Update(ctx, alice)
+			require.ErrorIs(t, err, modusgraph.ErrCyclicGraph)
+
+			// A friend shared along two paths is not a cycle
+			dave := &FoafPerson{Name: "Dave"}
+			erin := &FoafPerson{Name: "Erin", Friends: []*FoafPerson{dave}}
+			frank := &FoafPerson{Name: "Frank", Friends: []*FoafPerson{dave, erin}}
+			require.NoError(t, client.Insert(ctx, frank))
+			require.NotEmpty(t, dave.UID)
+			require.Equal(t, 5, countPeople(), "The shared friend should be written once")
+		})
+	}
+}
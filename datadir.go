@@ -0,0 +1,36 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"fmt"
+	"time"
+)
+
+// lockPollInterval is how often waitForDataDir checks a locked directory.
+const lockPollInterval = 100 * time.Millisecond
+
+// waitForDataDir waits up to wait for no other process to hold the lock
+// Badger takes on dir, failing with ErrDataDirLocked if one still does.
+// The lock is free again once the process holding it exits, so a directory
+// left behind by a crash opens as usual.
+func waitForDataDir(dir string, wait time.Duration) error {
+	deadline := time.Now().Add(wait)
+	for {
+		locked, err := dataDirLocked(dir)
+		if err != nil {
+			return fmt.Errorf("error checking the data directory lock: %w", err)
+		}
+		if !locked {
+			return nil
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("%w: %s", ErrDataDirLocked, dir)
+		}
+		time.Sleep(min(lockPollInterval, remaining))
+	}
+}
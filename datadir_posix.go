@@ -0,0 +1,30 @@
+//go:build linux || darwin
+
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// dataDirLocked reports whether another process holds the flock Badger
+// takes on dir while a store is open there.
+func dataDirLocked(dir string) (bool, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return false, err
+	}
+	// Closing f drops the lock if the probe took it.
+	defer f.Close()
+	err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return true, nil
+	}
+	return false, err
+}
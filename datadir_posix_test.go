@@ -0,0 +1,54 @@
+//go:build linux || darwin
+
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// lockDir takes the flock Badger holds on an open store's directory, as a
+// second process with the database open would.
+func lockDir(t *testing.T, dir string) *os.File {
+	t.Helper()
+	f, err := os.Open(dir)
+	require.NoError(t, err)
+	require.NoError(t, syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB))
+	return f
+}
+
+func TestEngineDataDirLocked(t *testing.T) {
+	conf := NewDefaultConfig(t.TempDir())
+	require.NoError(t, conf.makeDirs())
+	// A lock file left behind by a process that has exited does not count
+	require.NoError(t, os.WriteFile(conf.postingDir()+"/LOCK", []byte("999999\n"), 0o600))
+
+	held := lockDir(t, conf.postingDir())
+	start := time.Now()
+	_, err := NewEngine(conf)
+	require.ErrorIs(t, err, ErrDataDirLocked)
+	require.Less(t, time.Since(start), time.Second, "Without a lock wait NewEngine should fail at once")
+
+	_, err = NewEngine(conf.WithLockWait(250 * time.Millisecond))
+	require.ErrorIs(t, err, ErrDataDirLocked, "The lock is still held when the wait runs out")
+
+	// Released partway through the wait, the directory is reacquired
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		held.Close()
+	}()
+	engine, err := NewEngine(conf.WithLockWait(10 * time.Second))
+	require.NoError(t, err)
+	defer engine.Close()
+	require.NoError(t, engine.GetDefaultNamespace().AlterSchema(context.Background(), "name: string ."))
+}
@@ -0,0 +1,57 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// maxPooledDecodeBytes caps the buffer capacity returned to the pool, so one
+// very large node does not pin its memory for every later call.
+const maxPooledDecodeBytes = 1 << 20
+
+// decodeBuffers holds the JSON Get and QueryInterface read before decoding:
+// node is the one node Get reads, nodes the nodes QueryInterface reads.
+// json.RawMessage decodes by appending into its existing capacity, so a
+// reused buffer takes a read without allocating.
+type decodeBuffers struct {
+	node  json.RawMessage
+	nodes []json.RawMessage
+}
+
+var decodePool = sync.Pool{
+	New: func() any { return new(decodeBuffers) },
+}
+
+// decodeBuffers returns buffers to decode through: pooled ones when
+// WithDecodePooling is set, otherwise fresh ones.
+func (c client) decodeBuffers() *decodeBuffers {
+	if !c.options.decodePooling {
+		return new(decodeBuffers)
+	}
+	return decodePool.Get().(*decodeBuffers)
+}
+
+// releaseDecodeBuffers returns bufs to the pool once the values decoded
+// through them no longer need them. Oversized buffers are dropped.
+func (c client) releaseDecodeBuffers(bufs *decodeBuffers) {
+	if !c.options.decodePooling {
+		return
+	}
+	if cap(bufs.node) > maxPooledDecodeBytes {
+		bufs.node = nil
+	}
+	size := 0
+	for _, raw := range bufs.nodes[:cap(bufs.nodes)] {
+		size += cap(raw)
+	}
+	if size > maxPooledDecodeBytes {
+		bufs.nodes = nil
+	}
+	bufs.node, bufs.nodes = bufs.node[:0], bufs.nodes[:0]
+	decodePool.Put(bufs)
+}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/dgraph-io/dgo/v250/protos/api"
+	dg "github.com/dolan-in/dgman/v2"
 	"github.com/stretchr/testify/require"
 )
 
@@ -82,6 +83,184 @@ func TestClientDelete(t *testing.T) {
 	}
 }
 
+func TestClientDeleteAndReturn(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "DeleteAndReturnWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "DeleteAndReturnWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			entity := &TestEntity{
+				Name:        "Doomed Entity",
+				Description: "Removed by DeleteAndReturn",
+				CreatedAt:   time.Now().UTC().Truncate(time.Second),
+			}
+			require.NoError(t, client.Insert(ctx, entity), "Insert should succeed")
+
+			var before TestEntity
+			require.NoError(t, client.Get(ctx, &before, entity.UID), "Get should succeed")
+
+			var deleted TestEntity
+			err := client.DeleteAndReturn(ctx, &deleted, entity.UID)
+			require.NoError(t, err, "DeleteAndReturn should succeed")
+			require.Equal(t, before, deleted, "returned object should match the pre-delete state")
+
+			var gone TestEntity
+			err = client.Get(ctx, &gone, entity.UID)
+			require.ErrorIs(t, err, dg.ErrNodeNotFound, "node should be gone")
+
+			var again TestEntity
+			err = client.DeleteAndReturn(ctx, &again, entity.UID)
+			require.ErrorIs(t, err, dg.ErrNodeNotFound, "a second delete should find nothing")
+			require.Equal(t, TestEntity{}, again)
+		})
+	}
+}
+
+func TestClientDeleteCount(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "DeleteCountWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "DeleteCountWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			entities := make([]*TestEntity, 4)
+			for i := range entities {
+				entities[i] = &TestEntity{Name: fmt.Sprintf("Counted Entity %d", i), CreatedAt: time.Now()}
+			}
+			require.NoError(t, client.Insert(ctx, entities), "Insert should succeed")
+
+			n, err := client.DeleteCount(ctx, []string{entities[0].UID})
+			require.NoError(t, err, "DeleteCount should succeed")
+			require.Equal(t, 1, n, "A single existing node should be counted")
+
+			// The already deleted node and the repeated UID are not counted.
+			n, err = client.DeleteCount(ctx, []string{entities[0].UID, entities[1].UID, entities[2].UID,
+				entities[2].UID})
+			require.NoError(t, err, "Batch DeleteCount should succeed")
+			require.Equal(t, 2, n, "Only the nodes that existed should be counted")
+
+			var remaining []TestEntity
+			require.NoError(t, client.Query(ctx, TestEntity{}).Nodes(&remaining))
+			require.Len(t, remaining, 1)
+			require.Equal(t, entities[3].UID, remaining[0].UID)
+
+			n, err = client.DeleteCount(ctx, nil)
+			require.NoError(t, err)
+			require.Zero(t, n, "No UIDs should delete nothing")
+
+			_, err = client.DeleteCount(ctx, []string{"0x1) OR has(name"})
+			require.Error(t, err, "An invalid UID should be rejected")
+		})
+	}
+}
+
+// ImportedRecord carries a non-unique external key shared by the records
+// of one import batch.
+type ImportedRecord struct {
+	UID     string   `json:"uid,omitempty"`
+	Title   string   `json:"title,omitempty"`
+	BatchID string   `json:"batch_id,omitempty" dgraph:"index=exact"`
+	DType   []string `json:"dgraph.type,omitempty"`
+}
+
+func TestClientDeleteBy(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "DeleteByWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "DeleteByWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			records := []*ImportedRecord{
+				{Title: "a", BatchID: "batch-1"},
+				{Title: "b", BatchID: "batch-1"},
+				{Title: "c", BatchID: "batch-2"},
+				{Title: "d", BatchID: "batch-1"},
+			}
+			require.NoError(t, client.Insert(ctx, records))
+
+			n, err := client.DeleteBy(ctx, ImportedRecord{}, "batch_id", "batch-1")
+			require.NoError(t, err)
+			require.Equal(t, 3, n, "Every node with the value should be deleted")
+
+			var remaining []ImportedRecord
+			require.NoError(t, client.Query(ctx, ImportedRecord{}).Nodes(&remaining))
+			require.Len(t, remaining, 1)
+			require.Equal(t, "c", remaining[0].Title)
+
+			n, err = client.DeleteBy(ctx, ImportedRecord{}, "batch_id", "batch-1")
+			require.NoError(t, err)
+			require.Zero(t, n, "Nothing should be left to delete")
+
+			_, err = client.DeleteBy(ctx, ImportedRecord{}, "batch_id) OR has(title", "x")
+			require.Error(t, err, "An invalid predicate should be rejected")
+		})
+	}
+}
+
 func TestDeletePredicate(t *testing.T) {
 	testCases := []struct {
 		name string
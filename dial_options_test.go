@@ -6,7 +6,12 @@
 package modusgraph
 
 import (
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"google.golang.org/grpc"
 )
@@ -51,3 +56,48 @@ func TestKeyIgnoresGRPCDialOptionsForEmbedded(t *testing.T) {
 		t.Fatal("dial options must not affect the cache key for embedded (file://) clients")
 	}
 }
+
+func TestWithDialerOpensRemoteConnections(t *testing.T) {
+	// The listener stands in for a proxy: it accepts connections and closes
+	// them without speaking gRPC, so the query fails once the dialer has run.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer lis.Close()
+	var accepted atomic.Int32
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			accepted.Add(1)
+			conn.Close()
+		}
+	}()
+
+	// Nothing listens on port 1; only the dialer can reach the listener.
+	var dialed atomic.Value
+	dialer := func(ctx context.Context, addr string) (net.Conn, error) {
+		dialed.Store(addr)
+		return (&net.Dialer{}).DialContext(ctx, "tcp", lis.Addr().String())
+	}
+	c, err := NewClient("dgraph://localhost:1", WithDialer(dialer))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, _ = c.QueryRaw(ctx, `{ q(func: uid(0x1)) { uid } }`, nil)
+
+	addr, _ := dialed.Load().(string)
+	if !strings.HasSuffix(addr, ":1") {
+		t.Fatalf("dialer got address %q, want the endpoint's resolved address", addr)
+	}
+	if accepted.Load() == 0 {
+		t.Fatal("the connection opened by the dialer never reached the listener")
+	}
+}
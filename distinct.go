@@ -0,0 +1,46 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// CountDistinct returns the number of distinct values field holds across the
+// nodes of model's type, by grouping them with @groupby. field may be a
+// scalar predicate or a uid edge, whose distinct values are the nodes it
+// points at. Nodes without a value for field are not counted.
+func (c client) CountDistinct(ctx context.Context, model any, field string) (int, error) {
+	model = UnwrapSchema(model)
+	if !isValidPredicateName(field) {
+		return 0, fmt.Errorf("CountDistinct: invalid predicate %q", field)
+	}
+	nodeType := getNodeType(model)
+	if !isValidPredicateName(nodeType) {
+		return 0, fmt.Errorf("CountDistinct: invalid type name %q", nodeType)
+	}
+	query := "{ q(func: type(" + nodeType + ")) @groupby(" + field + ") { count(uid) } }"
+
+	data, err := c.queryRaw(ctx, query, nil)
+	if err != nil {
+		return 0, err
+	}
+	var resp struct {
+		Q []struct {
+			Groups []json.RawMessage `json:"@groupby"`
+		} `json:"q"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return 0, fmt.Errorf("CountDistinct: decoding: %w", err)
+	}
+	n := 0
+	for _, block := range resp.Q {
+		n += len(block.Groups)
+	}
+	return n, nil
+}
@@ -0,0 +1,92 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type DistinctWorkspace struct {
+	UID   string   `json:"uid,omitempty"`
+	Name  string   `json:"workspace_name,omitempty" dgraph:"index=exact"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+type DistinctThread struct {
+	UID       string             `json:"uid,omitempty"`
+	Title     string             `json:"thread_title,omitempty"`
+	Slug      string             `json:"thread_workspace_slug,omitempty"`
+	Workspace *DistinctWorkspace `json:"thread_workspace,omitempty"`
+	DType     []string           `json:"dgraph.type,omitempty"`
+}
+
+func TestCountDistinct(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "CountDistinctWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "CountDistinctWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			ctx := context.Background()
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			workspaces := []*DistinctWorkspace{{Name: "eng"}, {Name: "sales"}, {Name: "ops"}}
+			require.NoError(t, client.Insert(ctx, workspaces))
+			// Five threads across the three workspaces, and one in none
+			threads := []*DistinctThread{
+				{Title: "a", Slug: "eng", Workspace: workspaces[0]},
+				{Title: "b", Slug: "eng", Workspace: workspaces[0]},
+				{Title: "c", Slug: "sales", Workspace: workspaces[1]},
+				{Title: "d", Slug: "ops", Workspace: workspaces[2]},
+				{Title: "e", Slug: "ops", Workspace: workspaces[2]},
+				{Title: "f"},
+			}
+			require.NoError(t, client.Insert(ctx, threads))
+
+			n, err := client.CountDistinct(ctx, DistinctThread{}, "thread_workspace")
+			require.NoError(t, err)
+			require.Equal(t, 3, n, "Threads should span three workspaces")
+
+			n, err = client.CountDistinct(ctx, &DistinctThread{}, "thread_workspace_slug")
+			require.NoError(t, err)
+			require.Equal(t, 3, n, "Scalar values should group the same way")
+
+			n, err = client.CountDistinct(ctx, DistinctThread{}, "thread_title")
+			require.NoError(t, err)
+			require.Equal(t, 6, n)
+
+			// Only nodes of the model's type are grouped
+			n, err = client.CountDistinct(ctx, DistinctWorkspace{}, "thread_workspace")
+			require.NoError(t, err)
+			require.Zero(t, n)
+
+			_, err = client.CountDistinct(ctx, DistinctThread{}, "thread_workspace) { uid }")
+			require.Error(t, err, "The field must be a predicate name")
+		})
+	}
+}
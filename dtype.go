@@ -0,0 +1,143 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// dtypeOverride is a node whose DType the caller preset to types other than
+// the one dgman derives for its Go type. dgman's mutation walk overwrites
+// DType unconditionally, so the preset types are captured beforehand and
+// written back once the node has a UID.
+type dtypeOverride struct {
+	node    reflect.Value // the addressable struct
+	derived string        // the type dgman writes
+	types   []string      // the caller's types
+}
+
+// presetDTypes returns the overrides among the top-level nodes of obj, a
+// struct pointer or a (pointer to a) slice of struct pointers. Nodes reached
+// through edges keep the type dgman derives.
+func presetDTypes(obj any) []dtypeOverride {
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Slice {
+		v = v.Elem()
+	}
+	var nodes []reflect.Value
+	if v.Kind() == reflect.Slice {
+		for i := 0; i < v.Len(); i++ {
+			nodes = append(nodes, v.Index(i))
+		}
+	} else {
+		nodes = append(nodes, v)
+	}
+
+	var overrides []dtypeOverride
+	for _, n := range nodes {
+		if n.Kind() != reflect.Pointer || n.IsNil() || n.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		n = n.Elem()
+		dt := n.FieldByName("DType")
+		if !dt.IsValid() || dt.Kind() != reflect.Slice || dt.Type().Elem().Kind() != reflect.String || dt.Len() == 0 {
+			continue
+		}
+		derived := derivedNodeType(n.Type())
+		types := make([]string, 0, dt.Len())
+		for i := 0; i < dt.Len(); i++ {
+			if t := dt.Index(i).String(); t != "" {
+				types = append(types, t)
+			}
+		}
+		if len(types) == 0 || (len(types) == 1 && types[0] == derived) {
+			continue
+		}
+		overrides = append(overrides, dtypeOverride{node: n, derived: derived, types: types})
+	}
+	return overrides
+}
+
+// derivedNodeType is the dgraph.type dgman writes for structs of type t: the
+// dgraph tag on the dgraph.type field, falling back to the struct name.
+func derivedNodeType(t reflect.Type) string {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name == "dgraph.type" {
+			if tag := field.Tag.Get("dgraph"); tag != "" {
+				return tag
+			}
+			break
+		}
+	}
+	return t.Name()
+}
+
+// declareDTypes defines each overriding type name in the schema with the
+// predicates of the Go type it stands in for, so queries that expand(_all_)
+// on it, such as QueryInterface, return its fields.
+func (c client) declareDTypes(ctx context.Context, overrides []dtypeOverride) error {
+	types := make(dg.TypeMap)
+	for _, o := range overrides {
+		ts := dg.NewTypeSchema()
+		ts.Marshal("", o.node.Addr().Interface())
+		fields := ts.Types[o.derived]
+		for _, pred := range computedPredicates(o.node.Addr().Interface()) {
+			delete(fields, pred)
+		}
+		for _, t := range o.types {
+			if t != o.derived && isValidPredicateName(t) {
+				types[t] = fields
+			}
+		}
+	}
+	if len(types) == 0 {
+		return nil
+	}
+	return c.AlterSchema(ctx, typeMapString(types, c.options.sortedSchema))
+}
+
+// applyDTypeOverrides replaces the dgraph.type dgman wrote for each override
+// with the caller's types, in the mutation's transaction, and restores the
+// DType fields dgman overwrote.
+func applyDTypeOverrides(ctx context.Context, tx *dg.TxnContext, overrides []dtypeOverride) error {
+	var setNquads, delNquads []*api.NQuad
+	for _, o := range overrides {
+		uid := uidOf(o.node.Addr().Interface())
+		if uid == "" {
+			continue
+		}
+		dt := o.node.FieldByName("DType")
+		for i := 0; i < dt.Len(); i++ {
+			if t := dt.Index(i).String(); !slices.Contains(o.types, t) {
+				delNquads = append(delNquads, dtypeNquad(uid, t))
+			}
+		}
+		for _, t := range o.types {
+			setNquads = append(setNquads, dtypeNquad(uid, t))
+		}
+		dt.Set(reflect.ValueOf(slices.Clone(o.types)))
+	}
+	if len(setNquads) == 0 {
+		return nil
+	}
+	_, err := tx.Txn().Mutate(ctx, &api.Mutation{Set: setNquads, Del: delNquads})
+	return err
+}
+
+func dtypeNquad(uid, nodeType string) *api.NQuad {
+	return &api.NQuad{
+		Subject:     uid,
+		Predicate:   "dgraph.type",
+		ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: nodeType}},
+	}
+}
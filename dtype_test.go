@@ -0,0 +1,86 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertPresetDType(t *testing.T) {
+
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "PresetDTypeWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "PresetDTypeWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			legacy := TestEntity{
+				Name:        "Legacy",
+				Description: "stored under an existing graph's type",
+				DType:       []string{"LegacyEntity"},
+			}
+			require.NoError(t, client.Insert(ctx, &legacy))
+			require.NotEmpty(t, legacy.UID)
+			require.Equal(t, []string{"LegacyEntity"}, legacy.DType, "preset DType should be kept")
+
+			plain := TestEntity{Name: "Plain"}
+			require.NoError(t, client.Insert(ctx, &plain))
+			require.Equal(t, []string{"TestEntity"}, plain.DType, "an empty DType is still populated")
+
+			resp, err := client.QueryRaw(ctx, `{ q(func: type(LegacyEntity)) { uid name dgraph.type } }`, nil)
+			require.NoError(t, err)
+			var result struct {
+				Q []struct {
+					UID   string   `json:"uid"`
+					Name  string   `json:"name"`
+					DType []string `json:"dgraph.type"`
+				} `json:"q"`
+			}
+			require.NoError(t, json.Unmarshal(resp, &result))
+			require.Len(t, result.Q, 1)
+			require.Equal(t, legacy.UID, result.Q[0].UID)
+			require.Equal(t, "Legacy", result.Q[0].Name)
+			require.Equal(t, []string{"LegacyEntity"}, result.Q[0].DType)
+
+			// AutoSchema declares the type, so its predicates expand.
+			nodes, err := client.QueryInterface(ctx, "LegacyEntity", func() any { return &TestEntity{} })
+			require.NoError(t, err)
+			require.Len(t, nodes, 1)
+			require.Equal(t, "stored under an existing graph's type", nodes[0].(*TestEntity).Description)
+
+			var entities []TestEntity
+			require.NoError(t, client.Query(ctx, TestEntity{}).Nodes(&entities))
+			require.Len(t, entities, 1, "the Go type's query should not see the preset-type node")
+			require.Equal(t, plain.UID, entities[0].UID)
+		})
+	}
+}
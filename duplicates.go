@@ -0,0 +1,158 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// DuplicatePolicy decides what Insert does with the elements of a slice that
+// repeat an earlier element's value for a predicate tagged dgraph:"unique".
+type DuplicatePolicy int
+
+const (
+	// DuplicateError fails the whole batch with a UniqueError. It is the
+	// default.
+	DuplicateError DuplicatePolicy = iota
+	// DuplicateSkip inserts the first element carrying a unique value and
+	// drops the later ones.
+	DuplicateSkip
+	// DuplicateMerge inserts the first element carrying a unique value, after
+	// filling each of its zero fields from the later duplicates in order, and
+	// drops the later ones.
+	DuplicateMerge
+)
+
+// String returns the policy's name.
+func (p DuplicatePolicy) String() string {
+	switch p {
+	case DuplicateError:
+		return "Error"
+	case DuplicateSkip:
+		return "Skip"
+	case DuplicateMerge:
+		return "Merge"
+	}
+	return fmt.Sprintf("DuplicatePolicy(%d)", int(p))
+}
+
+// applyDuplicatePolicy returns the batch Insert should write in place of obj
+// under the client's DuplicatePolicy: obj itself, or a copy of the slice
+// without the elements that duplicate an earlier one. The returned finish,
+// called once the insert succeeds, gives each dropped element the UID of the
+// element it duplicated, so every element the caller passed ends up
+// identifying its node.
+func (c client) applyDuplicatePolicy(obj any) (batch any, finish func()) {
+	finish = func() {}
+	if c.options.duplicatePolicy == DuplicateError {
+		return obj, finish
+	}
+	nodes := sliceNodes(obj)
+	if len(nodes) < 2 {
+		return obj, finish
+	}
+
+	seen := make(map[string]int) // predicate and value -> index of the kept element
+	dupOf := make(map[int]int)   // index of a dropped element -> index of the kept one
+	for i, n := range nodes {
+		keys := uniqueKeys(n)
+		kept, dup := -1, false
+		for _, key := range keys {
+			if kept, dup = seen[key]; dup {
+				break
+			}
+		}
+		if dup {
+			dupOf[i] = kept
+			if c.options.duplicatePolicy == DuplicateMerge {
+				mergeZeroFields(nodes[kept], n)
+			}
+			continue
+		}
+		for _, key := range keys {
+			seen[key] = i
+		}
+	}
+	if len(dupOf) == 0 {
+		return obj, finish
+	}
+
+	v := reflect.ValueOf(obj)
+	isPtr := v.Kind() == reflect.Pointer
+	if isPtr {
+		v = v.Elem()
+	}
+	kept := reflect.MakeSlice(v.Type(), 0, v.Len()-len(dupOf))
+	var keptIdx []int // index in obj of each element of kept
+	for i := 0; i < v.Len(); i++ {
+		if _, dropped := dupOf[i]; !dropped {
+			kept = reflect.Append(kept, v.Index(i))
+			keptIdx = append(keptIdx, i)
+		}
+	}
+	batch = kept.Interface()
+	if isPtr {
+		p := reflect.New(v.Type())
+		p.Elem().Set(kept)
+		batch = p.Interface()
+	}
+	c.logger.V(1).Info("Dropped duplicate unique values from batch",
+		"policy", c.options.duplicatePolicy.String(), "dropped", len(dupOf))
+	return batch, func() {
+		// Struct values were copied into kept, so the UIDs the insert wrote
+		// there are copied back to the caller's elements.
+		if v.Type().Elem().Kind() == reflect.Struct {
+			for j, i := range keptIdx {
+				setUIDValue(nodes[i], getUIDValue(kept.Index(j).Addr().Interface()))
+			}
+		}
+		for i, k := range dupOf {
+			setUIDValue(nodes[i], getUIDValue(nodes[k]))
+		}
+	}
+}
+
+// uniqueKeys returns a key for each non-zero unique predicate value of node,
+// in predicate order.
+func uniqueKeys(node any) []string {
+	preds := getUniquePredicates(node)
+	names := make([]string, 0, len(preds))
+	for name := range preds {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		val := preds[name]
+		if val == nil || reflect.ValueOf(val).IsZero() {
+			continue
+		}
+		keys = append(keys, name+"\x00"+fmt.Sprint(val))
+	}
+	return keys
+}
+
+// mergeZeroFields copies each field of src, a struct pointer of the same type
+// as dst, into dst where dst's field is zero. The UID and dgraph.type fields
+// are left alone.
+func mergeZeroFields(dst, src any) {
+	dv := reflect.ValueOf(dst).Elem()
+	sv := reflect.ValueOf(src).Elem()
+	if dv.Type() != sv.Type() {
+		return
+	}
+	for i := 0; i < dv.NumField(); i++ {
+		field := dv.Type().Field(i)
+		if !field.IsExported() || field.Name == "UID" || field.Name == "DType" {
+			continue
+		}
+		if df := dv.Field(i); df.IsZero() && !sv.Field(i).IsZero() && df.CanSet() {
+			df.Set(sv.Field(i))
+		}
+	}
+}
@@ -0,0 +1,173 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	"github.com/dgraph-io/dgraph/v25/protos/pb"
+	"github.com/dgraph-io/dgraph/v25/schema"
+	"github.com/dgraph-io/dgraph/v25/types/facets"
+	"github.com/dgraph-io/dgraph/v25/x"
+)
+
+// facetNameRegex matches the facet names WithEdgeTimestamps accepts, which
+// the engine interpolates into the queries that read existing stamps.
+var facetNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// edgeTimestampKey carries, in a mutation's context, the name of the facet
+// the engine stamps on every edge the mutation creates.
+type edgeTimestampKey struct{}
+
+// contextWithEdgeTimestamps returns a context under which the engine stamps
+// the edges a mutation creates with their creation time in facet.
+func contextWithEdgeTimestamps(ctx context.Context, facet string) context.Context {
+	return context.WithValue(ctx, edgeTimestampKey{}, facet)
+}
+
+// stampEdgeTimes adds the edge timestamp facet named in ctx, if any, to every
+// UID edge that edges set. An edge that is new gets the time of this
+// mutation; an edge that already exists keeps the time it was stamped with,
+// since setting an edge replaces its facets. An edge whose facets already
+// carry the key keeps the caller's value.
+func (engine *Engine) stampEdgeTimes(
+	ctx context.Context,
+	ns *Namespace,
+	edges []*pb.DirectedEdge,
+	newUids map[string]uint64,
+	readTs uint64,
+) error {
+	facet, _ := ctx.Value(edgeTimestampKey{}).(string)
+	if facet == "" {
+		return nil
+	}
+
+	created := make(map[uint64]bool, len(newUids))
+	for _, uid := range newUids {
+		created[uid] = true
+	}
+
+	// Edges from nodes that existed before this mutation may already be
+	// stored; look up the stamps they carry
+	var stamped []*pb.DirectedEdge
+	var lookups []*pb.DirectedEdge
+	for _, edge := range edges {
+		if edge.Op != pb.DirectedEdge_SET || edge.ValueType != pb.Posting_UID {
+			continue
+		}
+		if slices.ContainsFunc(edge.Facets, func(f *api.Facet) bool { return f.Key == facet }) {
+			continue
+		}
+		stamped = append(stamped, edge)
+		if created[edge.Entity] {
+			continue
+		}
+		if _, ok := schema.State().Get(ctx, x.NamespaceAttr(ns.ID(), attrName(edge.Attr))); ok {
+			lookups = append(lookups, edge)
+		}
+	}
+	if len(stamped) == 0 {
+		return nil
+	}
+	existing, err := engine.edgeStamps(ctx, ns, facet, lookups, readTs)
+	if err != nil {
+		return fmt.Errorf("error reading edge timestamps: %w", err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	for _, edge := range stamped {
+		stamp, ok := existing[edge]
+		if !ok {
+			stamp = now
+		} else if stamp == "" {
+			// The edge predates the option; its creation time is unknown
+			continue
+		}
+		f, err := facets.FacetFor(facet, stamp)
+		if err != nil {
+			return fmt.Errorf("error stamping edge %s: %w", attrName(edge.Attr), err)
+		}
+		fs := append(slices.Clone(edge.Facets), f)
+		slices.SortFunc(fs, func(a, b *api.Facet) int { return strings.Compare(a.Key, b.Key) })
+		edge.Facets = fs
+	}
+	return nil
+}
+
+// edgeStamps looks up, in a single query, which of edges are already stored,
+// and returns the facet value each stored one carries ("" when it has none).
+func (engine *Engine) edgeStamps(
+	ctx context.Context,
+	ns *Namespace,
+	facet string,
+	edges []*pb.DirectedEdge,
+	readTs uint64,
+) (map[*pb.DirectedEdge]string, error) {
+	if len(edges) == 0 {
+		return nil, nil
+	}
+	var q strings.Builder
+	q.WriteString("{\n")
+	for i, edge := range edges {
+		fmt.Fprintf(&q, "\te%d(func: uid(0x%x)) { %s @filter(uid(0x%x)) @facets(%s) { uid } }\n",
+			i, edge.Entity, attrName(edge.Attr), edge.ValueId, facet)
+	}
+	q.WriteString("}")
+
+	resp, err := engine.queryWithLock(ctx, ns, q.String(), nil, readTs)
+	if err != nil {
+		return nil, err
+	}
+	var blocks map[string][]map[string]json.RawMessage
+	if err := json.Unmarshal(resp.Json, &blocks); err != nil {
+		return nil, err
+	}
+
+	stamps := make(map[*pb.DirectedEdge]string)
+	for i, edge := range edges {
+		pred := attrName(edge.Attr)
+		for _, node := range blocks[fmt.Sprintf("e%d", i)] {
+			raw, ok := node[pred]
+			if !ok {
+				continue
+			}
+			// A [uid] predicate reads back as a list, a uid predicate as one node
+			var targets []map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &targets); err != nil {
+				var target map[string]json.RawMessage
+				if err := json.Unmarshal(raw, &target); err != nil {
+					return nil, err
+				}
+				targets = append(targets, target)
+			}
+			for _, target := range targets {
+				var stamp string
+				if v, ok := target[pred+"|"+facet]; ok {
+					if err := json.Unmarshal(v, &stamp); err != nil {
+						return nil, err
+					}
+				}
+				stamps[edge] = stamp
+			}
+		}
+	}
+	return stamps, nil
+}
+
+// attrName returns the predicate name of attr without its namespace prefix.
+func attrName(attr string) string {
+	if strings.Contains(attr, x.NsSeparator) {
+		return x.ParseAttr(attr)
+	}
+	return attr
+}
@@ -0,0 +1,101 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+type StampedPerson struct {
+	UID     string           `json:"uid,omitempty"`
+	Name    string           `json:"name,omitempty" dgraph:"index=exact"`
+	Friends []*StampedPerson `json:"stamped_friends,omitempty"`
+	Mentor  *StampedPerson   `json:"stamped_mentor,omitempty"`
+	DType   []string         `json:"dgraph.type,omitempty"`
+}
+
+// edgeCreated reads the created facet of every edge of predicate from node
+// uid, by the name of the node the edge points at.
+func edgeCreated(t *testing.T, client modusgraph.Client, uid, predicate string) map[string]time.Time {
+	t.Helper()
+	q := fmt.Sprintf(`{ q(func: uid(%s)) { %s @facets(created) { name } } }`, uid, predicate)
+	data, err := client.QueryRaw(context.Background(), q, nil)
+	require.NoError(t, err)
+
+	var resp struct {
+		Q []map[string]json.RawMessage `json:"q"`
+	}
+	require.NoError(t, json.Unmarshal(data, &resp))
+	require.Len(t, resp.Q, 1)
+	var targets []map[string]any
+	if err := json.Unmarshal(resp.Q[0][predicate], &targets); err != nil {
+		var target map[string]any
+		require.NoError(t, json.Unmarshal(resp.Q[0][predicate], &target))
+		targets = append(targets, target)
+	}
+
+	created := make(map[string]time.Time)
+	for _, target := range targets {
+		stamp, ok := target[predicate+"|created"].(string)
+		require.True(t, ok, "Edge to %v should carry a created facet", target["name"])
+		at, err := time.Parse(time.RFC3339Nano, stamp)
+		require.NoError(t, err)
+		created[target["name"].(string)] = at
+	}
+	return created
+}
+
+func TestClientWithEdgeTimestamps(t *testing.T) {
+	ctx := context.Background()
+	client, err := modusgraph.NewClient("file://"+GetTempDir(t), modusgraph.WithAutoSchema(true),
+		modusgraph.WithEdgeTimestamps("created"))
+	require.NoError(t, err)
+	defer func() {
+		client.Close()
+		modusgraph.Shutdown()
+	}()
+
+	before := time.Now()
+	alice := &StampedPerson{
+		Name:    "Alice",
+		Friends: []*StampedPerson{{Name: "Bob"}},
+		Mentor:  &StampedPerson{Name: "Carol"},
+	}
+	require.NoError(t, client.Insert(ctx, alice))
+	after := time.Now()
+
+	friends := edgeCreated(t, client, alice.UID, "stamped_friends")
+	require.Len(t, friends, 1)
+	require.False(t, friends["Bob"].Before(before.Truncate(time.Microsecond)), "Stamp should not predate the insert")
+	require.False(t, friends["Bob"].After(after), "Stamp should not postdate the insert")
+	mentor := edgeCreated(t, client, alice.UID, "stamped_mentor")
+	require.Contains(t, mentor, "Carol", "A single uid edge should be stamped too")
+
+	// Writing the node again keeps the existing edge's stamp and stamps the new one
+	time.Sleep(10 * time.Millisecond)
+	alice.Friends = append(alice.Friends, &StampedPerson{Name: "Dan"})
+	require.NoError(t, client.Update(ctx, alice))
+
+	again := edgeCreated(t, client, alice.UID, "stamped_friends")
+	require.Len(t, again, 2)
+	require.True(t, again["Bob"].Equal(friends["Bob"]), "An existing edge should keep its creation time")
+	require.True(t, again["Dan"].After(friends["Bob"]), "A new edge should be stamped when it is added")
+}
+
+func TestClientWithEdgeTimestampsRejected(t *testing.T) {
+	_, err := modusgraph.NewClient("dgraph://localhost:9080", modusgraph.WithEdgeTimestamps("created"))
+	require.Error(t, err, "Remote clients cannot stamp edges")
+
+	_, err = modusgraph.NewClient("file://"+GetTempDir(t), modusgraph.WithEdgeTimestamps("created at"))
+	require.Error(t, err, "Facet names must be identifiers")
+}
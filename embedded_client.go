@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"runtime/debug"
 	"strings"
 
 	"github.com/dgraph-io/dgo/v250/protos/api"
@@ -22,13 +23,42 @@ import (
 type embeddedDgraphClient struct {
 	engine *Engine
 	ns     *Namespace
+
+	// recoverPanics turns panics raised by the engine into errors; see WithRecover.
+	recoverPanics bool
+
+	// skipUniqueCheck applies mutations without the engine's @unique check;
+	// see WithUniquenessCheck.
+	skipUniqueCheck bool
+
+	// edgeTimestampFacet names the facet the engine stamps on the edges a
+	// mutation creates, or is empty; see WithEdgeTimestamps.
+	edgeTimestampFacet string
 }
 
 // newEmbeddedDgraphClient creates a new embedded client for the given namespace.
-func newEmbeddedDgraphClient(engine *Engine, ns *Namespace) *embeddedDgraphClient {
+func newEmbeddedDgraphClient(engine *Engine, ns *Namespace, recoverPanics,
+	skipUniqueCheck bool, edgeTimestampFacet string) *embeddedDgraphClient {
 	return &embeddedDgraphClient{
-		engine: engine,
-		ns:     ns,
+		engine:             engine,
+		ns:                 ns,
+		recoverPanics:      recoverPanics,
+		skipUniqueCheck:    skipUniqueCheck,
+		edgeTimestampFacet: edgeTimestampFacet,
+	}
+}
+
+// recoverPanic, deferred by each method that calls into the engine, replaces
+// the method's error with one wrapping ErrEnginePanic when the engine panicked
+// and panic recovery is enabled. Otherwise the panic propagates.
+func (c *embeddedDgraphClient) recoverPanic(op string, err *error) {
+	if !c.recoverPanics {
+		return
+	}
+	if r := recover(); r != nil {
+		c.engine.logger.Error(nil, "Recovered from engine panic", "op", op, "panic", r,
+			"stack", string(debug.Stack()))
+		*err = fmt.Errorf("%w during %s: %v", ErrEnginePanic, op, r)
 	}
 }
 
@@ -45,18 +75,35 @@ func (c *embeddedDgraphClient) Query(
 	ctx context.Context,
 	in *api.Request,
 	opts ...grpc.CallOption,
-) (*api.Response, error) {
+) (resp *api.Response, err error) {
+	defer c.recoverPanic("query", &err)
+
 	// Attach namespace context
 	ctx = x.AttachNamespace(ctx, c.ns.ID())
+	if c.skipUniqueCheck {
+		ctx = contextWithoutUniqueCheck(ctx)
+	}
+	if c.edgeTimestampFacet != "" {
+		ctx = contextWithEdgeTimestamps(ctx, c.edgeTimestampFacet)
+	}
+
+	// A request in a PendingTxn (DeferCommit) reads and writes at its
+	// timestamp; read-only requests always read the latest commit.
+	var pendingTs uint64
+	if !in.ReadOnly {
+		if pendingTs, err = c.pendingTs(ctx); err != nil {
+			return nil, err
+		}
+	}
 
 	// For requests with both query and mutations (upsert case)
 	if len(in.Mutations) > 0 && in.Query != "" {
-		return c.handleUpsert(ctx, in)
+		return c.handleUpsert(ctx, in, pendingTs)
 	}
 
 	// Simple mutation (no query)
 	if len(in.Mutations) > 0 {
-		uids, err := c.ns.Mutate(ctx, in.Mutations)
+		uids, err := c.engine.mutateAt(ctx, c.ns, in.Mutations, pendingTs)
 		if err != nil {
 			return nil, err
 		}
@@ -77,20 +124,31 @@ func (c *embeddedDgraphClient) Query(
 	}
 
 	// Query only
-	return c.engine.query(ctx, c.ns, in.Query, in.Vars)
+	return c.engine.queryAt(ctx, c.ns, in.Query, in.Vars, pendingTs)
+}
+
+// pendingTs returns the engine timestamp of the PendingTxn ctx carries,
+// starting its engine transaction on first use, or 0 when ctx carries none.
+func (c *embeddedDgraphClient) pendingTs(ctx context.Context) (uint64, error) {
+	p := pendingTxnFrom(ctx)
+	if p == nil {
+		return 0, nil
+	}
+	return p.engineTs(c.engine)
 }
 
 // handleUpsert handles upsert requests (query + mutations) for embedded mode.
 // It executes the query first to resolve variable UIDs, then substitutes
 // uid(var) references in mutations before applying them.
-func (c *embeddedDgraphClient) handleUpsert(ctx context.Context, in *api.Request) (*api.Response, error) {
+func (c *embeddedDgraphClient) handleUpsert(ctx context.Context, in *api.Request,
+	pendingTs uint64) (*api.Response, error) {
 	// Step 1: Transform the upsert query to remove variable definitions
 	// dgman sends queries like: q_1_0(...) { u_1_0 as uid }
 	// We need to convert to: q_1_0(...) { uid } and map results back
 	transformedQuery, varMappings := transformUpsertQuery(in.Query)
 
 	// Step 2: Execute the transformed query
-	queryResp, err := c.engine.query(ctx, c.ns, transformedQuery, in.Vars)
+	queryResp, err := c.engine.queryAt(ctx, c.ns, transformedQuery, in.Vars, pendingTs)
 	if err != nil {
 		return nil, fmt.Errorf("upsert query failed: %w", err)
 	}
@@ -107,7 +165,7 @@ func (c *embeddedDgraphClient) handleUpsert(ctx context.Context, in *api.Request
 	}
 
 	// Step 5: Apply mutations using embedded path
-	uids, err := c.ns.Mutate(ctx, in.Mutations)
+	uids, err := c.engine.mutateAt(ctx, c.ns, in.Mutations, pendingTs)
 	if err != nil {
 		return nil, err
 	}
@@ -133,7 +191,9 @@ func (c *embeddedDgraphClient) Alter(
 	ctx context.Context,
 	in *api.Operation,
 	opts ...grpc.CallOption,
-) (*api.Payload, error) {
+) (payload *api.Payload, err error) {
+	defer c.recoverPanic("alter", &err)
+
 	if in.DropAll {
 		if err := c.engine.DropAll(ctx); err != nil {
 			return nil, err
@@ -164,7 +224,14 @@ func (c *embeddedDgraphClient) CommitOrAbort(
 	ctx context.Context,
 	in *api.TxnContext,
 	opts ...grpc.CallOption,
-) (*api.TxnContext, error) {
+) (txn *api.TxnContext, err error) {
+	defer c.recoverPanic("commit", &err)
+
+	if p := pendingTxnFrom(ctx); p != nil {
+		if ts := p.startedTs(); ts != 0 {
+			return in, c.engine.finishPending(ctx, ts, !in.Aborted)
+		}
+	}
 	return c.engine.commitOrAbort(ctx, c.ns, in)
 }
 
@@ -180,7 +247,9 @@ func (c *embeddedDgraphClient) RunDQL(
 	ctx context.Context,
 	in *api.RunDQLRequest,
 	opts ...grpc.CallOption,
-) (*api.Response, error) {
+) (resp *api.Response, err error) {
+	defer c.recoverPanic("DQL request", &err)
+
 	return c.engine.query(ctx, c.ns, in.DqlQuery, in.Vars)
 }
 
@@ -214,7 +283,9 @@ func (c *embeddedDgraphClient) CreateNamespace(
 	ctx context.Context,
 	in *api.CreateNamespaceRequest,
 	opts ...grpc.CallOption,
-) (*api.CreateNamespaceResponse, error) {
+) (resp *api.CreateNamespaceResponse, err error) {
+	defer c.recoverPanic("namespace creation", &err)
+
 	ns, err := c.engine.CreateNamespace()
 	if err != nil {
 		return nil, err
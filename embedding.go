@@ -10,8 +10,10 @@ import (
 	"context"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"reflect"
 	"strconv"
@@ -459,6 +461,8 @@ func injectShadowVectors(ctx context.Context,
 //	dgoClient, cleanup, _ := client.DgraphClient(); defer cleanup()
 //	tx := dg.NewReadOnlyTxn(dgoClient)
 //	err := SimilarTo(tx, &result, "description", vec, 5).Scan()
+//
+// Use SimilarToScored to also read how close each neighbour is.
 func SimilarTo(tx *dg.TxnContext, model any, field string, vec []float32, k int) *dg.QueryBlock {
 	vecStr := vectorToQueryString(vec)
 	rootFunc := fmt.Sprintf("similar_to(%s, %d, $vec)", vecShadowPredicate(field), k)
@@ -466,6 +470,84 @@ func SimilarTo(tx *dg.TxnContext, model any, field string, vec []float32, k int)
 	return tx.Query(q).Vars("similar_to($vec: string)", map[string]string{"$vec": vecStr})
 }
 
+// SimilarToScored is SimilarTo that also decodes each neighbour's score into
+// the field of the model whose json name is alias, and returns the neighbours
+// closest first. Declare that field with dgraph:"alias=computed" so it is not
+// stored as a predicate:
+//
+//	Score float64 `json:"score,omitempty" dgraph:"alias=computed"`
+//
+// The score follows the metric of the field's embedding tag: the cosine
+// similarity for cosine (the default) and the dot product for dotproduct,
+// both highest first, and the Euclidean distance for euclidean, lowest first.
+//
+// It returns an error for an alias that cannot name a DQL field, or for a
+// zero vector scored by cosine.
+func SimilarToScored(tx *dg.TxnContext, model any, field string, vec []float32, k int, alias string) (*dg.QueryBlock, error) {
+	if !isValidAlias(alias) {
+		return nil, fmt.Errorf("SimilarToScored: invalid score alias %q", alias)
+	}
+	metric := "cosine"
+	for _, info := range collectSimFieldInfoFromType(reflect.TypeOf(model)) {
+		if info.jsonPredicate == field {
+			metric = info.metric
+		}
+	}
+
+	var score string
+	descending := true
+	switch metric {
+	case "euclidean":
+		score = "sqrt((mgSimVec - $vec) dot (mgSimVec - $vec))"
+		descending = false
+	case "dotproduct":
+		score = "mgSimVec dot $vec"
+	default:
+		// Dgraph's math cannot take the dot product of $vec with itself, so
+		// the query vector's norm is computed here.
+		var sum float64
+		for _, v := range vec {
+			sum += float64(v) * float64(v)
+		}
+		if sum == 0 {
+			return nil, errors.New("SimilarToScored: cosine score of a zero vector")
+		}
+		score = fmt.Sprintf("(mgSimVec dot $vec) / (sqrt(mgSimVec dot mgSimVec) * %v)", math.Sqrt(sum))
+	}
+
+	search := dg.NewQuery().Var().
+		RootFunc(fmt.Sprintf("similar_to(%s, %d, $vec)", vecShadowPredicate(field), k)).
+		Query("{\n\t\tmgSimVec as " + vecShadowPredicate(field) + "\n\t\tmgSimScore as math(" + score + ")\n\t}")
+	data := dg.NewQuery().Model(model).RootFunc("uid(mgSimScore)").
+		Query("{\n\t\tuid\n\t\tdgraph.type\n\t\texpand(_all_)\n\t\t" + alias + " : val(mgSimScore)\n\t}")
+	if descending {
+		data.OrderDesc("val(mgSimScore)")
+	} else {
+		data.OrderAsc("val(mgSimScore)")
+	}
+	// The score's math needs $vec as a vector rather than a string.
+	return tx.Query(search, data).
+		Vars("similar_to($vec: float32vector)", map[string]string{"$vec": vectorToQueryString(vec)}), nil
+}
+
+// isValidAlias reports whether alias can name a field of a DQL selection.
+func isValidAlias(alias string) bool {
+	if alias == "" {
+		return false
+	}
+	for _, r := range alias {
+		switch {
+		case r >= 'a' && r <= 'z',
+			r >= 'A' && r <= 'Z',
+			r >= '0' && r <= '9',
+			r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // SimilarToText embeds text on-the-fly using the client's configured EmbeddingProvider,
 // executes a similar_to(<field>__vec, k, $vec) query, and scans the nearest-neighbour
 // results into model. The connection lifecycle is managed internally.
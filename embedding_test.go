@@ -317,6 +317,74 @@ func TestSimilarToQuery(t *testing.T) {
 		"Expected a Group1 result but got: %s", result.Name)
 }
 
+// scoredProduct reads the similarity score of a SimilarToScored query.
+type scoredProduct struct {
+	Name        string       `json:"name,omitempty" dgraph:"index=term"`
+	Description mg.SimString `json:"description,omitempty" dgraph:"embedding"`
+	Score       float64      `json:"score,omitempty" dgraph:"alias=computed"`
+
+	UID   string   `json:"uid,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+func TestSimilarToScored(t *testing.T) {
+	const dims = 3
+	provider := newMockProvider(dims)
+	vectors := map[string][]float32{
+		"near":    {0.8, 0.6, 0.0},
+		"middle":  {1.0, 0.0, 0.0},
+		"far":     {0.0, 0.6, 0.8},
+		"opposed": {0.0, 0.0, 1.0},
+	}
+	var products []*scoredProduct
+	for name, v := range vectors {
+		provider.register(name, v)
+		products = append(products, &scoredProduct{Name: name, Description: mg.SimString(name)})
+	}
+
+	client, cleanup := createEmbeddingClient(t, provider)
+	defer cleanup()
+
+	ctx := context.Background()
+	require.NoError(t, client.Insert(ctx, products))
+
+	dgoClient, cleanupDgo, err := client.DgraphClient()
+	require.NoError(t, err)
+	defer cleanupDgo()
+
+	queryVec := []float32{0.8, 0.6, 0.0}
+	var result []scoredProduct
+	tx := dg.NewReadOnlyTxn(dgoClient)
+	q, err := mg.SimilarToScored(tx, &result, "description", queryVec, 3, "score")
+	require.NoError(t, err)
+	require.NoError(t, q.Scan())
+
+	require.Len(t, result, 3, "k should bound the neighbours")
+	names := make([]string, len(result))
+	for i, r := range result {
+		names[i] = r.Name
+	}
+	require.Equal(t, []string{"near", "middle", "far"}, names, "neighbours should come closest first")
+	require.InDelta(t, 1.0, result[0].Score, 1e-6)
+	require.InDelta(t, 0.8, result[1].Score, 1e-6)
+	require.InDelta(t, 0.36, result[2].Score, 1e-6)
+	for i := 1; i < len(result); i++ {
+		require.Greater(t, result[i-1].Score, result[i].Score, "scores should descend")
+	}
+
+	var top scoredProduct
+	q, err = mg.SimilarToScored(dg.NewReadOnlyTxn(dgoClient), &top, "description", queryVec, 2, "score")
+	require.NoError(t, err)
+	require.NoError(t, q.Scan())
+	require.Equal(t, "near", top.Name, "a struct model should get the closest neighbour")
+
+	_, err = mg.SimilarToScored(dg.NewReadOnlyTxn(dgoClient), &top, "description", queryVec, 2, "score }")
+	require.Error(t, err, "an invalid alias should be rejected")
+
+	_, err = mg.SimilarToScored(dg.NewReadOnlyTxn(dgoClient), &top, "description", []float32{0, 0, 0}, 2, "score")
+	require.Error(t, err, "a zero vector has no cosine score")
+}
+
 func TestSimilarToTextQuery(t *testing.T) {
 	const dims = 5
 	provider := newMockProvider(dims)
@@ -10,8 +10,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"path"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -30,7 +30,9 @@ import (
 	"github.com/dgraph-io/dgraph/v25/worker"
 	"github.com/dgraph-io/dgraph/v25/x"
 	"github.com/dgraph-io/ristretto/v2/z"
+	dg "github.com/dolan-in/dgman/v2"
 	"github.com/go-logr/logr"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
@@ -39,11 +41,21 @@ var (
 	// activeEngine tracks the current Engine instance for global access
 	activeEngine *Engine
 
-	ErrSingletonOnly    = errors.New("only one instance of modusGraph can exist in a process")
-	ErrEmptyDataDir     = errors.New("data directory is required")
-	ErrClosedEngine     = errors.New("modusGraph engine is closed")
-	ErrNonExistentDB    = errors.New("namespace does not exist")
-	ErrInvalidCacheSize = errors.New("cache size must be zero or positive")
+	ErrSingletonOnly       = errors.New("only one instance of modusGraph can exist in a process")
+	ErrEmptyDataDir        = errors.New("data directory is required")
+	ErrClosedEngine        = errors.New("modusGraph engine is closed")
+	ErrNonExistentDB       = errors.New("namespace does not exist")
+	ErrInvalidCacheSize    = errors.New("cache size must be zero or positive")
+	ErrInvalidName         = errors.New("name must be a single directory name")
+	ErrInvalidGCInterval   = errors.New("GC interval must be zero or positive")
+	ErrNilBaseContext      = errors.New("base context must not be nil")
+	ErrInvalidDirMode      = errors.New("directory mode must be permission bits granting the owner rwx")
+	ErrInvalidNumMemtables = errors.New("number of memtables must be zero or positive")
+	ErrInvalidMaxOpenFiles = errors.New("max open files must be zero or positive")
+	ErrOpenFilesLimit      = errors.New("process open-file limit is below the configured max open files")
+	ErrInvalidLockWait     = errors.New("lock wait must be zero or positive")
+	ErrDataDirLocked       = errors.New("data directory is locked by another process")
+	ErrEnginePanic         = errors.New("embedded engine panicked")
 )
 
 // Engine is an instance of modusGraph.
@@ -58,6 +70,27 @@ type Engine struct {
 	db0 *Namespace
 
 	logger logr.Logger
+
+	// snapshotMu is held shared by Snapshot and exclusively by the operations
+	// that discard stored versions a snapshot may still be reading.
+	snapshotMu sync.RWMutex
+
+	// baseCtx is the parent context of background work; see WithBaseContext.
+	baseCtx context.Context
+
+	// limitNormalizeNode is the configured @normalize node limit, restored
+	// after a query that overrides it; see ContextWithNormalizeLimit.
+	limitNormalizeNode int
+
+	// gcStop and gcDone coordinate the periodic GC goroutine, when enabled.
+	gcStop chan struct{}
+	gcDone chan struct{}
+
+	// commitHook is called after each commit; see WithCommitHook. Guarded
+	// by mutex, pendingChanges holds the writes of each pending transaction
+	// with a hook to report them to, by start timestamp.
+	commitHook     func(context.Context, CommitInfo)
+	pendingChanges map[uint64]*commitChanges
 }
 
 // NewEngine returns a new modusGraph instance.
@@ -68,21 +101,47 @@ func NewEngine(conf Config) (*Engine, error) {
 		return nil, ErrSingletonOnly
 	}
 
-	conf.logger.V(1).Info("Creating new modusGraph engine", "dataDir", conf.dataDir)
+	conf.logger.V(1).Info("Creating new modusGraph engine", "dataDir", conf.dataDir, "name", conf.name)
 
 	if err := conf.validate(); err != nil {
 		conf.logger.Error(err, "Invalid configuration")
+		singleton.Store(false)
+		return nil, err
+	}
+
+	if conf.maxOpenFiles > 0 {
+		if err := ensureOpenFilesLimit(uint64(conf.maxOpenFiles)); err != nil {
+			conf.logger.Error(err, "Failed to raise the open-file limit", "maxOpenFiles", conf.maxOpenFiles)
+			singleton.Store(false)
+			return nil, err
+		}
+	}
+
+	if err := conf.makeDirs(); err != nil {
+		conf.logger.Error(err, "Failed to create data directories")
+		singleton.Store(false)
+		return nil, fmt.Errorf("error creating data directories: %w", err)
+	}
+
+	// Badger exits the process when its directory lock is taken, so find
+	// out first
+	if err := waitForDataDir(conf.postingDir(), conf.lockWait); err != nil {
+		conf.logger.Error(err, "Failed to lock the data directory", "dir", conf.postingDir())
+		singleton.Store(false)
 		return nil, err
 	}
 
 	// setup data directories
-	worker.Config.PostingDir = path.Join(conf.dataDir, "p")
-	worker.Config.WALDir = path.Join(conf.dataDir, "w")
+	worker.Config.PostingDir = conf.postingDir()
+	worker.Config.WALDir = conf.walDir()
 	worker.Config.TypeFilterUidLimit = 100000
-	x.WorkerConfig.TmpDir = path.Join(conf.dataDir, "t")
+	x.WorkerConfig.TmpDir = conf.tmpDir()
 
 	// TODO: optimize these and more options
 	x.WorkerConfig.Badger = badger.DefaultOptions("").FromSuperFlag(worker.BadgerDefaults)
+	if conf.numMemtables > 0 {
+		x.WorkerConfig.Badger = x.WorkerConfig.Badger.WithNumMemtables(conf.numMemtables)
+	}
 	x.Config.MaxRetries = 10
 	x.Config.Limit = z.NewSuperFlag("max-pending-queries=100000")
 	x.Config.LimitNormalizeNode = conf.limitNormalizeNode
@@ -98,7 +157,11 @@ func NewEngine(conf Config) (*Engine, error) {
 	posting.Init(worker.State.Pstore, int64(cacheSizeBytes), false)
 
 	engine := &Engine{
-		logger: conf.logger,
+		logger:             conf.logger,
+		baseCtx:            conf.baseCtx,
+		limitNormalizeNode: conf.limitNormalizeNode,
+		commitHook:         conf.commitHook,
+		pendingChanges:     make(map[uint64]*commitChanges),
 	}
 	engine.isOpen.Store(true)
 	engine.logger.V(1).Info("Initializing engine state")
@@ -142,6 +205,7 @@ func NewEngine(conf Config) (*Engine, error) {
 					Txns: []*pb.TxnStatus{{StartTs: tc.StartTs, CommitTs: commitTs}},
 				}
 				posting.Oracle().ProcessDelta(delta)
+				engine.z.markCommitted(commitTs)
 				return &api.TxnContext{StartTs: tc.StartTs, CommitTs: commitTs}, nil
 			},
 			ApplyMutationsFn: func(ctx context.Context, m *pb.Mutations) (*api.TxnContext, error) {
@@ -159,6 +223,12 @@ func NewEngine(conf Config) (*Engine, error) {
 
 	engine.db0 = &Namespace{id: 0, engine: engine}
 
+	if conf.gcInterval > 0 {
+		engine.gcStop = make(chan struct{})
+		engine.gcDone = make(chan struct{})
+		go engine.runPeriodicGC(conf.gcInterval, engine.gcStop, engine.gcDone)
+	}
+
 	return engine, nil
 }
 
@@ -180,6 +250,47 @@ func (engine *Engine) CreateNamespace() (*Namespace, error) {
 		return nil, ErrClosedEngine
 	}
 
+	return engine.createNamespaceWithLock()
+}
+
+// CreateNamespaceWithSchema creates a namespace, as CreateNamespace does, and
+// applies the schema generated from models to it before returning, so a new
+// tenant starts with the predicates and types its application expects. The
+// engine is locked throughout, so no query or mutation runs against the
+// namespace before its schema is in place, and the schema is parsed first, so
+// models whose schema is invalid create no namespace.
+func (engine *Engine) CreateNamespaceWithSchema(ctx context.Context, models ...any) (*Namespace, error) {
+	sch := modelSchema(models...)
+	if _, err := schema.Parse(sch); err != nil {
+		return nil, fmt.Errorf("error parsing schema: %w", err)
+	}
+
+	engine.snapshotMu.Lock()
+	defer engine.snapshotMu.Unlock()
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	if !engine.isOpen.Load() {
+		return nil, ErrClosedEngine
+	}
+
+	ns, err := engine.createNamespaceWithLock()
+	if err != nil {
+		return nil, err
+	}
+	sc, err := schema.ParseWithNamespace(sch, ns.ID())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing schema: %w", err)
+	}
+	if err := engine.alterSchemaWithParsed(ctx, sc); err != nil {
+		return nil, fmt.Errorf("error applying schema: %w", err)
+	}
+	return ns, nil
+}
+
+// createNamespaceWithLock allocates a namespace and applies the initial
+// schema to it. The caller holds engine.mutex.
+func (engine *Engine) createNamespaceWithLock() (*Namespace, error) {
 	startTs, err := engine.z.nextTs()
 	if err != nil {
 		return nil, err
@@ -192,6 +303,7 @@ func (engine *Engine) CreateNamespace() (*Namespace, error) {
 	if err := worker.ApplyInitialSchema(nsID, startTs); err != nil {
 		return nil, fmt.Errorf("error applying initial schema: %w", err)
 	}
+	engine.z.markCommitted(startTs)
 	for _, pred := range schema.State().Predicates() {
 		worker.InitTablet(pred)
 	}
@@ -199,6 +311,27 @@ func (engine *Engine) CreateNamespace() (*Namespace, error) {
 	return &Namespace{id: nsID, engine: engine}, nil
 }
 
+// modelSchema renders the schema UpdateSchema applies for models: dgman's
+// predicates and types, and the shadow vector predicates of their SimString
+// fields.
+func modelSchema(models ...any) string {
+	models = slices.Clone(models)
+	for i := range models {
+		models[i] = UnwrapSchema(models[i])
+	}
+	ts := dg.NewTypeSchema()
+	ts.Marshal("", models...)
+	var b strings.Builder
+	b.WriteString(ts.String())
+	for _, model := range models {
+		for _, info := range collectSimFields(model) {
+			b.WriteString("\n")
+			b.WriteString(buildVecSchemaStatement(info))
+		}
+	}
+	return b.String()
+}
+
 func (engine *Engine) GetNamespace(nsID uint64) (*Namespace, error) {
 	engine.mutex.RLock()
 	defer engine.mutex.RUnlock()
@@ -226,6 +359,8 @@ func (engine *Engine) GetDefaultNamespace() *Namespace {
 
 // DropAll drops all the data and schema in the modusDB instance.
 func (engine *Engine) DropAll(ctx context.Context) error {
+	engine.snapshotMu.Lock()
+	defer engine.snapshotMu.Unlock()
 	engine.mutex.Lock()
 	defer engine.mutex.Unlock()
 
@@ -249,6 +384,8 @@ func (engine *Engine) DropAll(ctx context.Context) error {
 }
 
 func (engine *Engine) dropData(ctx context.Context, ns *Namespace) error {
+	engine.snapshotMu.Lock()
+	defer engine.snapshotMu.Unlock()
 	engine.mutex.Lock()
 	defer engine.mutex.Unlock()
 
@@ -271,9 +408,50 @@ func (engine *Engine) dropData(ctx context.Context, ns *Namespace) error {
 	return nil
 }
 
+// dropSchema deletes every user-defined predicate and type of the namespace,
+// leaving reserved dgraph.* definitions and other namespaces untouched. Dgraph
+// keeps no data for a predicate without a definition, so the predicates' data
+// goes with them.
+func (engine *Engine) dropSchema(ctx context.Context, ns *Namespace) error {
+	engine.snapshotMu.Lock()
+	defer engine.snapshotMu.Unlock()
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	if !engine.isOpen.Load() {
+		return ErrClosedEngine
+	}
+
+	startTs, err := engine.z.nextTs()
+	if err != nil {
+		return err
+	}
+
+	for _, pred := range schema.State().Predicates() {
+		if x.ParseNamespace(pred) != ns.ID() || x.IsReservedPredicate(pred) {
+			continue
+		}
+		if err := posting.DeletePredicate(ctx, pred, startTs); err != nil {
+			return fmt.Errorf("error dropping predicate %s: %w", x.ParseAttr(pred), err)
+		}
+	}
+	for _, typ := range schema.State().Types() {
+		if x.ParseNamespace(typ) != ns.ID() || x.IsReservedType(typ) {
+			continue
+		}
+		if err := schema.State().DeleteType(typ, startTs); err != nil {
+			return fmt.Errorf("error dropping type %s: %w", x.ParseAttr(typ), err)
+		}
+	}
+	engine.z.markCommitted(startTs)
+	return nil
+}
+
 // dropPredicate deletes a single predicate (and its data) from the embedded
 // engine — the in-process equivalent of a gRPC Alter with DropAttr set.
 func (engine *Engine) dropPredicate(ctx context.Context, ns *Namespace, pred string) error {
+	engine.snapshotMu.Lock()
+	defer engine.snapshotMu.Unlock()
 	engine.mutex.Lock()
 	defer engine.mutex.Unlock()
 
@@ -281,16 +459,27 @@ func (engine *Engine) dropPredicate(ctx context.Context, ns *Namespace, pred str
 		return ErrClosedEngine
 	}
 
+	nsAttr := x.NamespaceAttr(ns.ID(), pred)
+	// Pre-defined predicates cannot be dropped, as on a Dgraph cluster.
+	if x.IsPreDefinedPredicate(nsAttr) {
+		return fmt.Errorf("predicate %s is pre-defined and is not allowed to be dropped", pred)
+	}
+
 	startTs, err := engine.z.nextTs()
 	if err != nil {
 		return err
 	}
 
-	nsAttr := x.NamespaceAttr(ns.ID(), pred)
-	return posting.DeletePredicate(ctx, nsAttr, startTs)
+	if err := posting.DeletePredicate(ctx, nsAttr, startTs); err != nil {
+		return err
+	}
+	engine.z.markCommitted(startTs)
+	return nil
 }
 
 func (engine *Engine) alterSchema(ctx context.Context, ns *Namespace, sch string) error {
+	engine.snapshotMu.Lock()
+	defer engine.snapshotMu.Unlock()
 	engine.mutex.Lock()
 	defer engine.mutex.Unlock()
 
@@ -324,6 +513,8 @@ func (engine *Engine) alterSchemaWithParsed(ctx context.Context, sc *schema.Pars
 	if err := worker.ApplyMutations(ctx, p); err != nil {
 		return fmt.Errorf("error applying mutation: %w", err)
 	}
+	// Index rebuilds write at startTs; reads must not start before it.
+	engine.z.markCommitted(startTs)
 	return nil
 }
 
@@ -331,54 +522,106 @@ func (engine *Engine) query(ctx context.Context,
 	ns *Namespace,
 	q string,
 	vars map[string]string) (*api.Response, error) {
+	return engine.queryAt(ctx, ns, q, vars, 0)
+}
+
+// queryAt runs q at readTs, or at the latest commit when readTs is 0. A
+// query at the start timestamp of a pending transaction (see startPending)
+// also sees that transaction's uncommitted writes.
+func (engine *Engine) queryAt(ctx context.Context,
+	ns *Namespace,
+	q string,
+	vars map[string]string,
+	readTs uint64) (*api.Response, error) {
+	if limit, ok := normalizeLimitFromContext(ctx); ok && limit != engine.limitNormalizeNode {
+		return engine.queryWithNormalizeLimit(ctx, ns, q, vars, limit, readTs)
+	}
 	engine.mutex.RLock()
 	defer engine.mutex.RUnlock()
 
-	return engine.queryWithLock(ctx, ns, q, vars)
+	return engine.queryWithLock(ctx, ns, q, vars, readTs)
+}
+
+// queryWithNormalizeLimit runs a query under a different @normalize node
+// limit. Dgraph reads the limit from a process-wide setting, so the query
+// holds the engine exclusively while the setting is swapped, keeping every
+// other query on the configured limit.
+func (engine *Engine) queryWithNormalizeLimit(ctx context.Context,
+	ns *Namespace,
+	q string,
+	vars map[string]string,
+	limit int,
+	readTs uint64) (*api.Response, error) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	x.Config.LimitNormalizeNode = limit
+	defer func() { x.Config.LimitNormalizeNode = engine.limitNormalizeNode }()
+	return engine.queryWithLock(ctx, ns, q, vars, readTs)
 }
 
 func (engine *Engine) queryWithLock(ctx context.Context,
 	ns *Namespace,
 	q string,
-	vars map[string]string) (*api.Response, error) {
+	vars map[string]string,
+	readTs uint64) (*api.Response, error) {
 	if !engine.isOpen.Load() {
 		return nil, ErrClosedEngine
 	}
+	if readTs == 0 {
+		readTs = engine.z.readTs()
+	}
 
 	engine.logger.V(2).Info("Querying namespace", "namespaceID", ns.ID(), "query", q)
 	ctx = x.AttachNamespace(ctx, ns.ID())
 	return (&edgraph.Server{}).QueryNoAuth(ctx, &api.Request{
 		ReadOnly: true,
 		Query:    q,
-		StartTs:  engine.z.readTs(),
+		StartTs:  readTs,
 		Vars:     vars,
 	})
 }
 
 func (engine *Engine) mutate(ctx context.Context, ns *Namespace, ms []*api.Mutation) (map[string]uint64, error) {
+	return engine.mutateAt(ctx, ns, ms, 0)
+}
+
+// mutateAt applies ms and commits them, or, when pendingTs is the start
+// timestamp of a pending transaction (see startPending), adds them to that
+// transaction uncommitted.
+func (engine *Engine) mutateAt(ctx context.Context, ns *Namespace, ms []*api.Mutation,
+	pendingTs uint64) (map[string]uint64, error) {
 	if len(ms) == 0 {
 		return nil, nil
 	}
+	newUids, committed, err := engine.mutateWithLock(ctx, ns, ms, pendingTs)
+	engine.notifyCommit(ctx, committed)
+	return newUids, err
+}
 
+// mutateWithLock is mutateAt under the engine's lock. It returns what a
+// commit wrote, when the engine has a commit hook to report it to.
+func (engine *Engine) mutateWithLock(ctx context.Context, ns *Namespace, ms []*api.Mutation,
+	pendingTs uint64) (map[string]uint64, *CommitInfo, error) {
 	engine.mutex.Lock()
 	defer engine.mutex.Unlock()
 	dms := make([]*dql.Mutation, 0, len(ms))
 	for _, mu := range ms {
 		dm, err := edgraph.ParseMutationObject(ctx, mu)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing mutation: %w", err)
+			return nil, nil, fmt.Errorf("error parsing mutation: %w", err)
 		}
 		dms = append(dms, dm)
 	}
 	newUids, err := query.ExtractBlankUIDs(ctx, dms)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if len(newUids) > 0 {
 		num := &pb.Num{Val: uint64(len(newUids)), Type: pb.Num_UID}
 		res, err := engine.z.nextUIDs(num)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		curId := res.StartId
@@ -389,33 +632,34 @@ func (engine *Engine) mutate(ctx context.Context, ns *Namespace, ms []*api.Mutat
 		}
 	}
 
-	return engine.mutateWithDqlMutation(ctx, ns, dms, newUids)
+	return engine.mutateWithDqlMutation(ctx, ns, dms, newUids, pendingTs)
 }
 
 func (engine *Engine) mutateWithDqlMutation(ctx context.Context, ns *Namespace, dms []*dql.Mutation,
-	newUids map[string]uint64) (map[string]uint64, error) {
+	newUids map[string]uint64, pendingTs uint64) (map[string]uint64, *CommitInfo, error) {
 	edges, err := query.ToDirectedEdges(dms, newUids)
 	if err != nil {
-		return nil, fmt.Errorf("error converting to directed edges: %w", err)
+		return nil, nil, fmt.Errorf("error converting to directed edges: %w", err)
 	}
 	ctx = x.AttachNamespace(ctx, ns.ID())
 
 	if !engine.isOpen.Load() {
-		return nil, ErrClosedEngine
+		return nil, nil, ErrClosedEngine
 	}
 
 	// Check unique constraints before applying mutations
-	if err := engine.verifyUniqueConstraints(ctx, ns, edges, newUids); err != nil {
-		return nil, err
+	if err := engine.verifyUniqueConstraints(ctx, ns, edges, newUids, pendingTs); err != nil {
+		return nil, nil, err
 	}
-
-	startTs, err := engine.z.nextTs()
-	if err != nil {
-		return nil, err
+	if err := engine.stampEdgeTimes(ctx, ns, edges, newUids, pendingTs); err != nil {
+		return nil, nil, err
 	}
-	commitTs, err := engine.z.nextTs()
-	if err != nil {
-		return nil, err
+
+	startTs := pendingTs
+	if startTs == 0 {
+		if startTs, err = engine.z.nextTs(); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	m := &pb.Mutations{
@@ -426,7 +670,7 @@ func (engine *Engine) mutateWithDqlMutation(ctx context.Context, ns *Namespace,
 
 	m.Edges, err = query.ExpandEdges(ctx, m)
 	if err != nil {
-		return nil, fmt.Errorf("error expanding edges: %w", err)
+		return nil, nil, fmt.Errorf("error expanding edges: %w", err)
 	}
 
 	for _, edge := range m.Edges {
@@ -435,26 +679,166 @@ func (engine *Engine) mutateWithDqlMutation(ctx context.Context, ns *Namespace,
 
 	p := &pb.Proposal{Mutations: m, StartTs: startTs}
 	if err := worker.ApplyMutations(ctx, p); err != nil {
-		return nil, err
+		// Edges applied before the failing one would otherwise stay pending
+		// and block later schema changes. A pending transaction is left for
+		// its owner to discard.
+		if pendingTs == 0 {
+			_ = engine.abortAt(ctx, startTs)
+		}
+		return nil, nil, mutationError(ctx, m.Edges, err)
+	}
+	var changes *commitChanges
+	if engine.commitHook != nil {
+		if changes = engine.pendingChanges[startTs]; changes == nil {
+			changes = newCommitChanges(ns.ID())
+		}
+		changes.add(m.Edges)
+	}
+	if pendingTs != 0 {
+		if changes != nil {
+			engine.pendingChanges[startTs] = changes
+		}
+		return newUids, nil, nil
+	}
+	commitTs, err := engine.commitAt(ctx, startTs)
+	if err != nil || changes == nil {
+		return newUids, nil, err
+	}
+	return newUids, changes.info(commitTs), nil
+}
+
+// mutationError wraps err, the failure to apply edges, in a MutationError
+// naming the first edge its predicate's schema rejects: a value Dgraph fails
+// to convert reports only the conversion, such as a strconv error. err is
+// returned as is when no edge is at fault.
+func mutationError(ctx context.Context, edges []*pb.DirectedEdge, err error) error {
+	ctx = schema.GetWriteContext(ctx)
+	for _, edge := range edges {
+		su, ok := schema.State().Get(ctx, edge.Attr)
+		if !ok {
+			continue
+		}
+		// ValidateAndConvert converts the edge's value in place, and an
+		// applied value edge carries its value's fingerprint as ValueId,
+		// which would read as a UID.
+		check := proto.Clone(edge).(*pb.DirectedEdge)
+		if len(check.Value) > 0 {
+			check.ValueId = 0
+		}
+		if worker.ValidateAndConvert(check, &su) == nil {
+			continue
+		}
+		value := string(edge.Value)
+		if len(edge.Value) == 0 {
+			value = fmt.Sprintf("%#x", edge.ValueId)
+		}
+		return &MutationError{
+			Predicate: x.ParseAttr(edge.Attr),
+			UID:       fmt.Sprintf("%#x", edge.Entity),
+			Value:     value,
+			Err:       err,
+		}
 	}
+	return err
+}
 
-	return newUids, worker.ApplyCommited(ctx, &pb.OracleDelta{
+// commitAt commits the mutations applied at startTs at a new commit
+// timestamp, making them visible to later reads.
+func (engine *Engine) commitAt(ctx context.Context, startTs uint64) (uint64, error) {
+	commitTs, err := engine.z.nextTs()
+	if err != nil {
+		return 0, err
+	}
+	if err := worker.ApplyCommited(ctx, &pb.OracleDelta{
 		Txns: []*pb.TxnStatus{{StartTs: startTs, CommitTs: commitTs}},
+	}); err != nil {
+		return 0, err
+	}
+	engine.z.markCommitted(commitTs)
+	return commitTs, nil
+}
+
+// startPending starts a transaction whose mutations, applied through
+// mutateAt with the returned start timestamp, stay invisible to other reads
+// until finishPending commits them. Reads through queryAt at that timestamp
+// see the latest commit as of the start plus the transaction's own writes.
+// There is no conflict detection: a commit applies its writes over whatever
+// was committed since the start.
+func (engine *Engine) startPending() (uint64, error) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	if !engine.isOpen.Load() {
+		return 0, ErrClosedEngine
+	}
+	return engine.z.nextTs()
+}
+
+// finishPending commits, or when commit is false aborts, the pending
+// transaction started at startTs.
+func (engine *Engine) finishPending(ctx context.Context, startTs uint64, commit bool) error {
+	committed, err := engine.finishPendingWithLock(ctx, startTs, commit)
+	engine.notifyCommit(ctx, committed)
+	return err
+}
+
+// finishPendingWithLock is finishPending under the engine's lock. It returns
+// what a commit wrote, when the engine has a commit hook to report it to.
+func (engine *Engine) finishPendingWithLock(ctx context.Context, startTs uint64,
+	commit bool) (*CommitInfo, error) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	changes := engine.pendingChanges[startTs]
+	delete(engine.pendingChanges, startTs)
+	if !engine.isOpen.Load() {
+		return nil, ErrClosedEngine
+	}
+	if !commit {
+		return nil, engine.abortAt(ctx, startTs)
+	}
+	commitTs, err := engine.commitAt(ctx, startTs)
+	if err != nil || changes == nil {
+		return nil, err
+	}
+	return changes.info(commitTs), nil
+}
+
+// abortAt discards the mutations applied at startTs.
+func (engine *Engine) abortAt(ctx context.Context, startTs uint64) error {
+	return worker.ApplyCommited(ctx, &pb.OracleDelta{
+		Txns: []*pb.TxnStatus{{StartTs: startTs}},
 	})
 }
 
-// verifyUniqueConstraints checks that mutations don't violate @unique constraints
+// skipUniqueCheckKey is the context key marking mutations to apply without
+// verifyUniqueConstraints.
+type skipUniqueCheckKey struct{}
+
+// contextWithoutUniqueCheck returns a copy of ctx under which mutations skip
+// the @unique check; see WithUniquenessCheck.
+func contextWithoutUniqueCheck(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipUniqueCheckKey{}, true)
+}
+
+// verifyUniqueConstraints checks that mutations don't violate @unique constraints,
+// unless ctx comes from contextWithoutUniqueCheck. Values repeated within the
+// batch are caught first; the rest are checked against the database in one
+// query, with a block per value, rather than a query each.
 func (engine *Engine) verifyUniqueConstraints(
 	ctx context.Context,
 	ns *Namespace,
 	edges []*pb.DirectedEdge,
 	newUids map[string]uint64,
+	readTs uint64,
 ) error {
+	if skip, _ := ctx.Value(skipUniqueCheckKey{}).(bool); skip {
+		return nil
+	}
 	namespace := ns.ID()
 
 	// Track values seen within this mutation batch for in-batch duplicate detection
 	// Key: "predName:value", Value: subject UID
 	seenValues := make(map[string]uint64)
+	var checks []uniqueCheck
 
 	for _, edge := range edges {
 		// Skip delete operations
@@ -493,33 +877,42 @@ func (engine *Engine) verifyUniqueConstraints(
 					UID:   fmt.Sprintf("0x%x", existingUID),
 				}
 			}
+			continue
 		}
 		seenValues[key] = subjectUID
+		checks = append(checks, uniqueCheck{predicate: predName, value: valStr, subject: subjectUID})
+	}
+	if len(checks) == 0 {
+		return nil
+	}
 
-		// Build query to check for existing value in database
-		queryStr := fmt.Sprintf(`{
-			check(func: eq(%s, %q)) {
-				uid
-			}
-		}`, predName, valStr)
-
-		resp, err := engine.queryWithLock(ctx, ns, queryStr, nil)
-		if err != nil {
-			return fmt.Errorf("error checking unique constraint for %s: %w", predName, err)
-		}
+	// Check every value against the database in a single query
+	var q strings.Builder
+	q.WriteString("{\n")
+	for i, c := range checks {
+		fmt.Fprintf(&q, "\tc%d(func: eq(%s, %q)) { uid }\n", i, c.predicate, c.value)
+	}
+	q.WriteString("}")
 
-		// Parse response to check if any existing UIDs found
-		existingUID, found := parseUniqueCheckResponse(resp.Json)
-		if !found {
-			continue
-		}
+	resp, err := engine.queryWithLock(ctx, ns, q.String(), nil, readTs)
+	if err != nil {
+		return fmt.Errorf("error checking unique constraints: %w", err)
+	}
+	existing, err := parseUniqueCheckResponse(resp.Json)
+	if err != nil {
+		return fmt.Errorf("error checking unique constraints: %w", err)
+	}
 
-		// If found, check if it's the same UID (update case is allowed)
-		if existingUID != subjectUID {
-			return &UniqueError{
-				Field: predName,
-				Value: valStr,
-				UID:   fmt.Sprintf("0x%x", existingUID),
+	// Report the first value, in mutation order, held by another node; a node
+	// keeping its own value is an update and allowed
+	for i, c := range checks {
+		for _, uid := range existing[fmt.Sprintf("c%d", i)] {
+			if uid != c.subject {
+				return &UniqueError{
+					Field: c.predicate,
+					Value: c.value,
+					UID:   fmt.Sprintf("0x%x", uid),
+				}
 			}
 		}
 	}
@@ -527,41 +920,40 @@ func (engine *Engine) verifyUniqueConstraints(
 	return nil
 }
 
-// parseUniqueCheckResponse parses the query response to find existing UID
-func parseUniqueCheckResponse(jsonData []byte) (uint64, bool) {
+// uniqueCheck is a value written to a @unique predicate, to be checked
+// against the nodes already holding it.
+type uniqueCheck struct {
+	predicate string
+	value     string
+	subject   uint64
+}
+
+// parseUniqueCheckResponse parses the response of the unique check query into
+// the UIDs each of its blocks found, by block name
+func parseUniqueCheckResponse(jsonData []byte) (map[string][]uint64, error) {
 	if len(jsonData) == 0 {
-		return 0, false
+		return nil, nil
 	}
 
-	var result struct {
-		Check []struct {
-			UID string `json:"uid"`
-		} `json:"check"`
+	var result map[string][]struct {
+		UID string `json:"uid"`
 	}
-
 	if err := json.Unmarshal(jsonData, &result); err != nil {
-		return 0, false
-	}
-
-	if len(result.Check) == 0 {
-		return 0, false
-	}
-
-	uidStr := result.Check[0].UID
-	if uidStr == "" {
-		return 0, false
+		return nil, err
 	}
 
-	// Parse UID (format: "0x123")
-	if len(uidStr) > 2 && uidStr[:2] == "0x" {
-		uid, err := strconv.ParseUint(uidStr[2:], 16, 64)
-		if err != nil {
-			return 0, false
+	uids := make(map[string][]uint64, len(result))
+	for block, nodes := range result {
+		for _, n := range nodes {
+			// Parse UID (format: "0x123")
+			uid, err := strconv.ParseUint(strings.TrimPrefix(n.UID, "0x"), 16, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parsing uid %q: %w", n.UID, err)
+			}
+			uids[block] = append(uids[block], uid)
 		}
-		return uid, true
 	}
-
-	return 0, false
+	return uids, nil
 }
 
 func (engine *Engine) commitOrAbort(ctx context.Context, ns *Namespace, tc *api.TxnContext) (*api.TxnContext, error) {
@@ -595,6 +987,15 @@ func (engine *Engine) LoadData(inCtx context.Context, dataDir string) error {
 
 // Close closes the modusGraph instance.
 func (engine *Engine) Close() {
+	// Stop periodic GC before taking the lock RunGC needs.
+	if engine.gcStop != nil && engine.isOpen.Load() {
+		close(engine.gcStop)
+		<-engine.gcDone
+		engine.gcStop = nil
+	}
+
+	engine.snapshotMu.Lock()
+	defer engine.snapshotMu.Unlock()
 	engine.mutex.Lock()
 	defer engine.mutex.Unlock()
 
@@ -629,6 +1030,11 @@ func (ns *Engine) reset() error {
 			return fmt.Errorf("error applying initial schema: %w", err)
 		}
 	}
+	if ns.z != nil {
+		if err := z.continueFrom(ns.z); err != nil {
+			return fmt.Errorf("error carrying leases over the reset: %w", err)
+		}
+	}
 
 	if err := schema.LoadFromDb(context.Background()); err != nil {
 		return fmt.Errorf("error loading schema: %w", err)
@@ -9,7 +9,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/dgraph-io/dgo/v250/protos/api"
@@ -60,6 +62,54 @@ func TestRestart(t *testing.T) {
 	require.NoError(t, engine.DropAll(context.Background()))
 }
 
+func TestNamedEngines(t *testing.T) {
+	parent := t.TempDir()
+	ctx := context.Background()
+	query := `{
+			me(func: has(name)) {
+				name
+			}
+		}`
+
+	engine, err := modusgraph.NewEngine(modusgraph.NewDefaultConfig(parent).WithName("graph1"))
+	require.NoError(t, err)
+	require.NoError(t, engine.GetDefaultNamespace().AlterSchema(ctx, "name: string @index(term) ."))
+	_, err = engine.GetDefaultNamespace().Mutate(ctx, []*api.Mutation{
+		{
+			Set: []*api.NQuad{
+				{
+					Subject:     "_:aman",
+					Predicate:   "name",
+					ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: "A"}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	engine.Close()
+
+	engine, err = modusgraph.NewEngine(modusgraph.NewDefaultConfig(parent).WithName("graph2"))
+	require.NoError(t, err)
+	qresp, err := engine.GetDefaultNamespace().Query(ctx, query)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"me":[]}`, string(qresp.GetJson()))
+	engine.Close()
+
+	for _, name := range []string{"graph1", "graph2"} {
+		for _, dir := range []string{"p", "w"} {
+			require.DirExists(t, filepath.Join(parent, name, dir))
+		}
+	}
+	require.NoDirExists(t, filepath.Join(parent, "p"))
+
+	engine, err = modusgraph.NewEngine(modusgraph.NewDefaultConfig(parent).WithName("graph1"))
+	require.NoError(t, err)
+	defer engine.Close()
+	qresp, err = engine.GetDefaultNamespace().Query(ctx, query)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"me":[{"name":"A"}]}`, string(qresp.GetJson()))
+}
+
 func TestSchemaQuery(t *testing.T) {
 	engine, err := modusgraph.NewEngine(modusgraph.NewDefaultConfig(t.TempDir()))
 	require.NoError(t, err)
@@ -124,3 +174,60 @@ func TestBasicVector(t *testing.T) {
 		`{"q":[{"project_description_v":[5.1E+00,5.1E+00,1.1E+00]}]}`,
 		string(resp.GetJson()))
 }
+
+func TestNormalizeLimitOverride(t *testing.T) {
+	engine, err := modusgraph.NewEngine(modusgraph.NewDefaultConfig(t.TempDir()).WithLimitNormalizeNode(10))
+	require.NoError(t, err)
+	defer engine.Close()
+
+	ctx := context.Background()
+	ns := engine.GetDefaultNamespace()
+	require.NoError(t, ns.AlterSchema(ctx, `
+		name: string @index(exact) .
+		member: [uid] .
+	`))
+
+	// One group with 20 members normalizes to 20 rows, each merging the
+	// group's alias with a member's, well past a limit of 10 nodes.
+	set := []*api.NQuad{{
+		Subject:     "_:group",
+		Predicate:   "name",
+		ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: "group"}},
+	}}
+	for i := range 20 {
+		member := fmt.Sprintf("_:m%d", i)
+		set = append(set,
+			&api.NQuad{Subject: "_:group", Predicate: "member", ObjectId: member},
+			&api.NQuad{
+				Subject:     member,
+				Predicate:   "name",
+				ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: fmt.Sprintf("member %d", i)}},
+			})
+	}
+	_, err = ns.Mutate(ctx, []*api.Mutation{{Set: set}})
+	require.NoError(t, err)
+
+	const query = `{
+		q(func: eq(name, "group")) @normalize {
+			group: name
+			member { who: name }
+		}
+	}`
+	_, err = ns.Query(ctx, query)
+	require.ErrorContains(t, err, "too many results", "the configured limit should reject the query")
+
+	resp, err := ns.Query(modusgraph.ContextWithNormalizeLimit(ctx, 1000), query)
+	require.NoError(t, err, "a raised limit should let the query through")
+	var result struct {
+		Q []struct {
+			Group string `json:"group"`
+			Who   string `json:"who"`
+		} `json:"q"`
+	}
+	require.NoError(t, json.Unmarshal(resp.GetJson(), &result))
+	require.Len(t, result.Q, 20)
+
+	// The override does not outlive its query.
+	_, err = ns.Query(ctx, query)
+	require.ErrorContains(t, err, "too many results")
+}
@@ -6,6 +6,7 @@
 package modusgraph
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -16,6 +17,26 @@ import (
 // a node that would violate a unique constraint.
 type UniqueError = dg.UniqueError
 
+// MutationError is returned by a mutation through an embedded client that
+// the engine rejects while applying it, such as a value that does not convert
+// to its predicate's schema type. It names the predicate, node, and value that
+// failed, which Dgraph's own error (Err) may not.
+type MutationError struct {
+	Predicate string // the predicate the rejected value was written to
+	UID       string // the node it was written on, as 0x-prefixed hex
+	Value     string // the rejected value, or the UID of an edge's target
+	Err       error
+}
+
+func (e *MutationError) Error() string {
+	return fmt.Sprintf("mutation rejected for predicate %s on node %s with value %q: %v",
+		e.Predicate, e.UID, e.Value, e.Err)
+}
+
+func (e *MutationError) Unwrap() error {
+	return e.Err
+}
+
 // parseUniqueError attempts to parse a Dgraph unique constraint violation error
 // and convert it to a UniqueError. Returns nil if the error is not a unique constraint violation.
 func parseUniqueError(err error) *UniqueError {
@@ -0,0 +1,47 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// ErrMultipleNodes is returned by ExactlyOne when more than one node matches
+// a query expected to match a single node.
+var ErrMultipleNodes = errors.New("query matched more than one node")
+
+// ExactlyOne runs q, such as one built with Client.Query, and decodes its
+// only match into obj, a pointer to the query's model type. Where dgman's
+// Node returns the first match, ExactlyOne fails with dg.ErrNodeNotFound when
+// nothing matches and with ErrMultipleNodes when more than one node does, so
+// a lookup by a field meant to be unique catches duplicates instead of
+// silently picking one. It replaces any limit set on q.
+func ExactlyOne(q *dg.Query, obj any) error {
+	if q == nil {
+		return errors.New("ExactlyOne: query must not be nil")
+	}
+	if err := checkPointer(obj); err != nil {
+		return err
+	}
+	// Two rows are enough to tell one match from several.
+	rows := reflect.New(reflect.SliceOf(reflect.TypeOf(obj).Elem()))
+	if err := q.First(2).Nodes(rows.Interface()); err != nil {
+		return err
+	}
+	switch rows.Elem().Len() {
+	case 0:
+		return dg.ErrNodeNotFound
+	case 1:
+		reflect.ValueOf(obj).Elem().Set(rows.Elem().Index(0))
+		return nil
+	default:
+		return fmt.Errorf("ExactlyOne: %w", ErrMultipleNodes)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	dg "github.com/dolan-in/dgman/v2"
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExactlyOne(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "ExactlyOneWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "ExactlyOneWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			ctx := context.Background()
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			people := []*FoafPerson{{Name: "Alice"}, {Name: "Bob"}, {Name: "Bob"}}
+			require.NoError(t, client.Insert(ctx, people))
+
+			var alice FoafPerson
+			err := modusgraph.ExactlyOne(client.Query(ctx, FoafPerson{}).Filter(`eq(person_name, "Alice")`), &alice)
+			require.NoError(t, err, "A single match should decode")
+			require.Equal(t, people[0].UID, alice.UID)
+			require.Equal(t, "Alice", alice.Name)
+
+			var carol FoafPerson
+			err = modusgraph.ExactlyOne(client.Query(ctx, FoafPerson{}).Filter(`eq(person_name, "Carol")`), &carol)
+			require.ErrorIs(t, err, dg.ErrNodeNotFound, "No match should be an error")
+
+			var bob FoafPerson
+			err = modusgraph.ExactlyOne(client.Query(ctx, FoafPerson{}).Filter(`eq(person_name, "Bob")`), &bob)
+			require.ErrorIs(t, err, modusgraph.ErrMultipleNodes, "Duplicate matches should be an error")
+			require.Empty(t, bob.UID, "Nothing should be decoded on duplicates")
+
+			err = modusgraph.ExactlyOne(client.Query(ctx, FoafPerson{}), alice)
+			require.Error(t, err, "The destination must be a pointer")
+		})
+	}
+}
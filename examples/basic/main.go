@@ -160,28 +160,22 @@ func createThread(client mg.Client, logger logr.Logger, name, workspaceID, creat
 
 // updateThread updates an existing Thread in the database
 func updateThread(client mg.Client, logger logr.Logger, uid, name, workspaceID, createdBy string) error {
-	// First get the existing Thread
+	// Read, change, and save the Thread in one transaction
 	ctx := context.Background()
 	var thread Thread
-	err := client.Get(ctx, &thread, uid)
-	if err != nil {
-		logger.Error(err, "Failed to get Thread for update", "UID", uid)
-		return err
-	}
-
-	// Update fields if provided
-	if name != "" {
-		thread.Name = name
-	}
-	if workspaceID != "" {
-		thread.WorkspaceID = workspaceID
-	}
-	if createdBy != "" {
-		thread.CreatedBy = createdBy
-	}
-
-	// Save the updated Thread
-	err = client.Update(ctx, &thread)
+	err := client.Modify(ctx, &thread, uid, func() error {
+		// Update fields if provided
+		if name != "" {
+			thread.Name = name
+		}
+		if workspaceID != "" {
+			thread.WorkspaceID = workspaceID
+		}
+		if createdBy != "" {
+			thread.CreatedBy = createdBy
+		}
+		return nil
+	})
 	if err != nil {
 		logger.Error(err, "Failed to update Thread", "UID", uid)
 		return err
@@ -207,15 +201,8 @@ func truncateString(s string, maxLen int) string {
 func deleteThread(client mg.Client, logger logr.Logger, uid string) error {
 	ctx := context.Background()
 	var thread Thread
-	// First get the Thread to confirm it exists and to show what's being deleted
-	err := client.Get(ctx, &thread, uid)
-	if err != nil {
-		logger.Error(err, "Failed to get Thread for deletion", "UID", uid)
-		return err
-	}
-
-	// Now delete it
-	err = client.Delete(ctx, []string{uid})
+	// Read and delete in one transaction, keeping what was deleted to show it
+	err := client.DeleteAndReturn(ctx, &thread, uid)
 	if err != nil {
 		logger.Error(err, "Failed to delete Thread", "UID", uid)
 		return err
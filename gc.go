@@ -0,0 +1,167 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	bpb "github.com/dgraph-io/badger/v4/pb"
+	"github.com/dgraph-io/dgraph/v25/posting"
+	"github.com/dgraph-io/dgraph/v25/worker"
+	"github.com/dgraph-io/dgraph/v25/x"
+)
+
+// gcFlushPrefix is the prefix of the marker key RunGC writes, and drops, to
+// make Badger flush its memtables. Dgraph's keys start with a byte below '!'.
+var gcFlushPrefix = []byte("!modusgraph!gc")
+
+// gcDiscardRatio is the fraction of a value-log file that must be garbage
+// before Badger rewrites it.
+const gcDiscardRatio = 0.5
+
+// RunGC reclaims the disk space held by deleted and overwritten data.
+//
+// The embedded engine writes every mutation as a delta on top of the posting
+// list it changes and keeps all versions, so deletes alone never shrink the
+// store. RunGC rolls every list with pending deltas up into a single complete
+// version, flushes the memtables holding the new versions, allows Badger to
+// discard the versions they supersede, compacts the LSM tree and then runs
+// value-log GC until no file is worth rewriting.
+//
+// Mutations and queries are blocked while it runs. Transactions that started
+// before RunGC may no longer read the versions it discarded.
+func (engine *Engine) RunGC(ctx context.Context) error {
+	engine.snapshotMu.Lock()
+	defer engine.snapshotMu.Unlock()
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	if !engine.isOpen.Load() {
+		return ErrClosedEngine
+	}
+
+	readTs, err := engine.z.nextTs()
+	if err != nil {
+		return err
+	}
+	pstore := worker.State.Pstore
+	if err := rollupDeltas(ctx, pstore, readTs); err != nil {
+		return fmt.Errorf("error rolling up posting lists: %w", err)
+	}
+
+	discardTs := readTs
+	if pending := posting.Oracle().MinPendingStartTs(); pending <= discardTs {
+		discardTs = pending - 1
+	}
+	pstore.SetDiscardTs(discardTs)
+	// Compaction only reaches tables on disk, and the rolled-up lists are
+	// still in memory. The flush compacts level 0, and so discards already.
+	if err := flushMemtables(pstore, readTs); err != nil {
+		return fmt.Errorf("error flushing posting store: %w", err)
+	}
+	if err := pstore.Flatten(1); err != nil {
+		return fmt.Errorf("error compacting posting store: %w", err)
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := pstore.RunValueLogGC(gcDiscardRatio)
+		if errors.Is(err, badger.ErrNoRewrite) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error running value log GC: %w", err)
+		}
+	}
+}
+
+// rollupDeltas rewrites every data, index, reverse and count key whose latest
+// version is a delta as a complete posting list read at readTs. Complete lists
+// are written with Badger's discard bit, which lets compaction drop the
+// versions underneath them.
+func rollupDeltas(ctx context.Context, pstore *badger.DB, readTs uint64) error {
+	txn := pstore.NewTransactionAt(readTs, false)
+	defer txn.Discard()
+
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	writer := posting.NewTxnWriter(pstore)
+	for it.Rewind(); it.Valid(); it.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		item := it.Item()
+		if item.UserMeta()&posting.BitDeltaPosting == 0 {
+			continue
+		}
+		pk, err := x.Parse(item.Key())
+		if err != nil || pk.HasStartUid || !(pk.IsData() || pk.IsIndex() || pk.IsReverse() || pk.IsCount()) {
+			continue
+		}
+
+		key := item.KeyCopy(nil)
+		l, err := posting.GetNoStore(key, readTs)
+		if err != nil {
+			return err
+		}
+		kvs, err := l.Rollup(nil, readTs)
+		if err != nil {
+			return err
+		}
+		posting.RemoveCacheFor(key)
+		if err := writer.Write(&bpb.KVList{Kv: kvs}); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// flushMemtables writes every memtable of pstore to disk. Badger offers no
+// call for that alone, but DropPrefix flushes the memtables before dropping a
+// prefix that holds data, so a marker key is written under gcFlushPrefix and
+// that prefix dropped.
+func flushMemtables(pstore *badger.DB, ts uint64) error {
+	writer := posting.NewTxnWriter(pstore)
+	marker := &bpb.KV{Key: gcFlushPrefix, Value: []byte{0}, Version: ts}
+	if err := writer.Write(&bpb.KVList{Kv: []*bpb.KV{marker}}); err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	return pstore.DropPrefix(gcFlushPrefix)
+}
+
+// runPeriodicGC calls RunGC every interval until stop is closed or the
+// engine's base context is done.
+func (engine *Engine) runPeriodicGC(interval time.Duration, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-engine.baseCtx.Done():
+			return
+		case <-ticker.C:
+			err := engine.RunGC(engine.baseCtx)
+			if err != nil && !errors.Is(err, ErrClosedEngine) && engine.baseCtx.Err() == nil {
+				engine.logger.Error(err, "Periodic GC failed")
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	"github.com/dgraph-io/dgraph/v25/worker"
+	"github.com/stretchr/testify/require"
+)
+
+// storedVersions counts the versions of every key the posting store holds.
+func storedVersions(t *testing.T) int {
+	t.Helper()
+	txn := worker.State.Pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	opts := badger.DefaultIteratorOptions
+	opts.AllVersions = true
+	opts.PrefetchValues = false
+	it := txn.NewIterator(opts)
+	defer it.Close()
+	n := 0
+	for it.Rewind(); it.Valid(); it.Next() {
+		n++
+	}
+	return n
+}
+
+func TestRunGCDiscardsSupersededVersions(t *testing.T) {
+	dataDir := t.TempDir()
+	ctx := context.Background()
+
+	engine, err := NewEngine(NewDefaultConfig(dataDir))
+	require.NoError(t, err)
+	ns := engine.GetDefaultNamespace()
+	require.NoError(t, ns.AlterSchema(ctx, "label: string @index(exact) ."))
+
+	// Write every label three times, then delete half of them
+	const n = 100
+	var nquads strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&nquads, "_:n%d <label> \"v0-%d\" .\n", i, i)
+	}
+	uidMap, err := ns.Mutate(ctx, []*api.Mutation{{SetNquads: []byte(nquads.String())}})
+	require.NoError(t, err)
+	for round := 1; round <= 2; round++ {
+		for i := 0; i < n; i++ {
+			uid := fmt.Sprintf("%#x", uidMap[fmt.Sprintf("_:n%d", i)])
+			mu := &api.Mutation{SetNquads: []byte(fmt.Sprintf("<%s> <label> \"v%d-%d\" .", uid, round, i))}
+			_, err := ns.Mutate(ctx, []*api.Mutation{mu})
+			require.NoError(t, err)
+		}
+	}
+	for i := 1; i < n; i += 2 {
+		uid := fmt.Sprintf("%#x", uidMap[fmt.Sprintf("_:n%d", i)])
+		_, err := ns.Mutate(ctx, []*api.Mutation{{DelNquads: []byte("<" + uid + "> <label> * .")}})
+		require.NoError(t, err)
+	}
+
+	defer engine.Close()
+
+	before := storedVersions(t)
+	require.NoError(t, engine.RunGC(ctx))
+	after := storedVersions(t)
+	require.Less(t, after, before, "GC should discard the versions the rollup superseded")
+
+	resp, err := engine.GetDefaultNamespace().Query(ctx, `{ q(func: has(label)) { count(uid) } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, fmt.Sprintf(`{"q":[{"count":%d}]}`, n/2), string(resp.GetJson()))
+}
@@ -0,0 +1,142 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+// sstSize returns the bytes held by the LSM tables under dir. Value-log and
+// memtable files are preallocated, so their sizes say nothing about usage.
+func sstSize(t *testing.T, dir string) int64 {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(dir, "*.sst"))
+	require.NoError(t, err)
+	var size int64
+	for _, m := range matches {
+		info, err := os.Stat(m)
+		require.NoError(t, err)
+		size += info.Size()
+	}
+	return size
+}
+
+// churn inserts n nodes, then overwrites the label of every even node and
+// deletes it from every odd one.
+func churn(t *testing.T, ctx context.Context, ns *modusgraph.Namespace, n int) {
+	t.Helper()
+	var nquads strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&nquads, "_:n%d <label> \"v1-%d\" .\n", i, i)
+	}
+	uidMap, err := ns.Mutate(ctx, []*api.Mutation{{SetNquads: []byte(nquads.String())}})
+	require.NoError(t, err)
+
+	for i := 0; i < n; i++ {
+		uid := fmt.Sprintf("%#x", uidMap[fmt.Sprintf("_:n%d", i)])
+		mu := &api.Mutation{SetNquads: []byte(fmt.Sprintf("<%s> <label> \"v2-%d\" .", uid, i))}
+		if i%2 == 1 {
+			mu = &api.Mutation{DelNquads: []byte("<" + uid + "> <label> * .")}
+		}
+		_, err := ns.Mutate(ctx, []*api.Mutation{mu})
+		require.NoError(t, err)
+	}
+}
+
+func requireLabels(t *testing.T, ctx context.Context, ns *modusgraph.Namespace, want int) {
+	t.Helper()
+	resp, err := ns.Query(ctx, `{ q(func: has(label)) { count(uid) } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, fmt.Sprintf(`{"q":[{"count":%d}]}`, want), string(resp.GetJson()))
+
+	resp, err = ns.Query(ctx, `{ q(func: eq(label, "v1-0")) { uid } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"q":[]}`, string(resp.GetJson()), "overwritten values must stay gone")
+
+	resp, err = ns.Query(ctx, `{ q(func: eq(label, "v2-0")) { label } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"q":[{"label":"v2-0"}]}`, string(resp.GetJson()))
+}
+
+func TestRunGC(t *testing.T) {
+	dataDir := t.TempDir()
+	ctx := context.Background()
+
+	engine, err := modusgraph.NewEngine(modusgraph.NewDefaultConfig(dataDir))
+	require.NoError(t, err)
+	ns := engine.GetDefaultNamespace()
+	require.NoError(t, ns.AlterSchema(ctx, "label: string @index(exact) ."))
+
+	const n = 200
+	churn(t, ctx, ns, n)
+	requireLabels(t, ctx, ns, n/2)
+
+	// Closing flushes the memtable, putting every version the churn wrote
+	// on disk.
+	engine.Close()
+	postingDir := filepath.Join(dataDir, "p")
+	before := sstSize(t, postingDir)
+	require.Positive(t, before)
+
+	engine, err = modusgraph.NewEngine(modusgraph.NewDefaultConfig(dataDir))
+	require.NoError(t, err)
+	defer engine.Close()
+	ns = engine.GetDefaultNamespace()
+	requireLabels(t, ctx, ns, n/2)
+
+	require.NoError(t, engine.RunGC(ctx))
+	require.Less(t, sstSize(t, postingDir), before, "GC should drop the superseded versions from the LSM tree")
+	requireLabels(t, ctx, ns, n/2)
+
+	// With nothing left to discard, GC changes nothing visible
+	require.NoError(t, engine.RunGC(ctx))
+	requireLabels(t, ctx, ns, n/2)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, engine.RunGC(cancelled), context.Canceled)
+}
+
+func TestGCInterval(t *testing.T) {
+	ctx := context.Background()
+
+	engine, err := modusgraph.NewEngine(modusgraph.NewDefaultConfig(t.TempDir()).WithGCInterval(20 * time.Millisecond))
+	require.NoError(t, err)
+	ns := engine.GetDefaultNamespace()
+	require.NoError(t, ns.AlterSchema(ctx, "label: string @index(exact) ."))
+
+	// Keep writing while the background GC runs underneath.
+	deadline := time.Now().Add(200 * time.Millisecond)
+	total := 0
+	for time.Now().Before(deadline) {
+		churn(t, ctx, ns, 20)
+		total += 10
+	}
+	resp, err := ns.Query(ctx, `{ q(func: has(label)) { count(uid) } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, fmt.Sprintf(`{"q":[{"count":%d}]}`, total), string(resp.GetJson()))
+
+	closed := make(chan struct{})
+	go func() {
+		engine.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Close did not stop the periodic GC")
+	}
+}
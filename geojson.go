@@ -0,0 +1,258 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgo/v250/protos/api"
+)
+
+const (
+	// GeoJSONGeometryPredicate is the geo predicate ImportGeoJSON stores each
+	// feature's geometry in.
+	GeoJSONGeometryPredicate = "geometry"
+	// GeoJSONIDPredicate is the string predicate ImportGeoJSON stores a
+	// feature's "id" member in, when the feature has one.
+	GeoJSONIDPredicate = "feature_id"
+)
+
+// GeoJSON is a geometry stored in a geo predicate, held as its GeoJSON
+// (RFC 7946) text: a Point, LineString, Polygon, MultiPolygon, or other
+// geometry object. It writes the geometry to Dgraph as-is and reads back the
+// GeoJSON Dgraph returns, in compact form, so a geometry round-trips with its
+// coordinates unchanged without a hand-written GeoJSON struct:
+//
+//	type Park struct {
+//	    UID      string   `json:"uid,omitempty"`
+//	    Name     string   `json:"name,omitempty" dgraph:"index=exact"`
+//	    Boundary GeoJSON  `json:"boundary,omitempty" dgraph:"index=geo"`
+//	    DType    []string `json:"dgraph.type,omitempty"`
+//	}
+//
+// GeoPoint and GeoPolygon build the common geometries. GeoJSON is a string so
+// dgman passes it to the encoder whole; the zero value is no geometry.
+type GeoJSON string
+
+// GeoPoint returns the GeoJSON Point at lon, lat.
+func GeoPoint(lon, lat float64) GeoJSON {
+	return mustGeoJSON("Point", [2]float64{lon, lat})
+}
+
+// GeoPolygon returns the GeoJSON Polygon with the given linear rings, the
+// exterior ring first and any holes after it. Each ring is a list of lon, lat
+// positions whose last position repeats its first.
+func GeoPolygon(rings ...[][2]float64) GeoJSON {
+	return mustGeoJSON("Polygon", rings)
+}
+
+func mustGeoJSON(typ string, coordinates any) GeoJSON {
+	// Marshaling a string and float arrays cannot fail.
+	data, _ := json.Marshal(struct {
+		Type        string `json:"type"`
+		Coordinates any    `json:"coordinates"`
+	}{typ, coordinates})
+	return GeoJSON(data)
+}
+
+// MarshalJSON implements json.Marshaler, writing the geometry as a JSON
+// object, or null for the zero value.
+func (g GeoJSON) MarshalJSON() ([]byte, error) {
+	if g == "" {
+		return []byte("null"), nil
+	}
+	if !json.Valid([]byte(g)) {
+		return nil, fmt.Errorf("invalid GeoJSON %q", string(g))
+	}
+	return []byte(g), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping the geometry's GeoJSON
+// in compact form.
+func (g *GeoJSON) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*g = ""
+		return nil
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		return err
+	}
+	*g = GeoJSON(compact.String())
+	return nil
+}
+
+// SchemaType implements the dgman SchemaType interface so that dgman emits
+// "geo" as the Dgraph predicate type for GeoJSON fields.
+func (g GeoJSON) SchemaType() string {
+	return "geo"
+}
+
+// geoJSONFeatureCollection is the subset of a GeoJSON (RFC 7946)
+// FeatureCollection that ImportGeoJSON reads.
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string                     `json:"type"`
+	ID         json.RawMessage            `json:"id"`
+	Geometry   json.RawMessage            `json:"geometry"`
+	Properties map[string]json.RawMessage `json:"properties"`
+}
+
+// ImportGeoJSON parses a GeoJSON FeatureCollection and inserts each feature as
+// a node of Dgraph type typeName, returning the number of nodes created. A
+// feature's geometry is stored in the geo-indexed GeoJSONGeometryPredicate,
+// its id (if any) as a string in GeoJSONIDPredicate, and each of its
+// properties in a predicate of the same name. Scalar properties keep their
+// JSON type; object and array properties are stored as their JSON text, and
+// null properties are skipped. Property names must be plain predicate
+// identifiers.
+//
+// All features are inserted in one mutation, so the import either succeeds
+// whole or not at all. The geometry predicate is declared before the insert
+// and typeName's declaration is extended with the imported predicates after
+// it, so the nodes can be read back with expand(typeName) or a struct whose
+// geometry field is tagged dgraph:"type=geo index=geo".
+func (c client) ImportGeoJSON(ctx context.Context, featureCollection []byte, typeName string) (int, error) {
+	if !isValidBlockName(typeName) {
+		return 0, fmt.Errorf("ImportGeoJSON: invalid type name %q", typeName)
+	}
+	var fc geoJSONFeatureCollection
+	if err := json.Unmarshal(featureCollection, &fc); err != nil {
+		return 0, fmt.Errorf("ImportGeoJSON: parsing FeatureCollection: %w", err)
+	}
+	if fc.Type != "FeatureCollection" {
+		return 0, fmt.Errorf("ImportGeoJSON: expected a FeatureCollection, got type %q", fc.Type)
+	}
+	if len(fc.Features) == 0 {
+		return 0, nil
+	}
+
+	predicates := map[string]bool{GeoJSONGeometryPredicate: true}
+	nodes := make([]map[string]any, 0, len(fc.Features))
+	for i, f := range fc.Features {
+		node, err := geoJSONNode(f, typeName, "_:feature"+strconv.Itoa(i))
+		if err != nil {
+			return 0, fmt.Errorf("ImportGeoJSON: feature %d: %w", i, err)
+		}
+		for pred := range node {
+			if pred != "uid" && pred != "dgraph.type" {
+				predicates[pred] = true
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	setJSON, err := json.Marshal(nodes)
+	if err != nil {
+		return 0, fmt.Errorf("ImportGeoJSON: encoding nodes: %w", err)
+	}
+
+	dgClient, err := c.pool.get()
+	if err != nil {
+		c.logger.Error(err, "Failed to get client from pool")
+		return 0, err
+	}
+	defer c.pool.put(dgClient)
+
+	// Declaring the predicate as geo up front makes Dgraph store each
+	// geometry object as a geo value rather than as a nested node.
+	schema := GeoJSONGeometryPredicate + ": geo @index(geo) ."
+	if err := dgClient.Alter(ctx, &api.Operation{Schema: schema}); err != nil {
+		return 0, fmt.Errorf("ImportGeoJSON: declaring %s: %w", GeoJSONGeometryPredicate, err)
+	}
+
+	txn := dgClient.NewTxn()
+	resp, err := txn.Mutate(ctx, &api.Mutation{SetJson: setJSON, CommitNow: true})
+	if err != nil {
+		_ = txn.Discard(ctx)
+		return 0, fmt.Errorf("ImportGeoJSON: inserting features: %w", err)
+	}
+
+	// Extend rather than replace an existing declaration of typeName.
+	existing, err := dgClient.NewReadOnlyTxn().Query(ctx, "schema(type: "+typeName+") {}")
+	if err != nil {
+		return len(resp.Uids), fmt.Errorf("ImportGeoJSON: reading type %s: %w", typeName, err)
+	}
+	var declared struct {
+		Types []struct {
+			Fields []struct {
+				Name string `json:"name"`
+			} `json:"fields"`
+		} `json:"types"`
+	}
+	if err := json.Unmarshal(existing.Json, &declared); err != nil {
+		return len(resp.Uids), fmt.Errorf("ImportGeoJSON: reading type %s: %w", typeName, err)
+	}
+	for _, t := range declared.Types {
+		for _, f := range t.Fields {
+			predicates[f.Name] = true
+		}
+	}
+	fields := make([]string, 0, len(predicates))
+	for pred := range predicates {
+		fields = append(fields, pred)
+	}
+	slices.Sort(fields)
+	typeDef := "type " + typeName + " {\n\t" + strings.Join(fields, "\n\t") + "\n}"
+	if err := dgClient.Alter(ctx, &api.Operation{Schema: typeDef}); err != nil {
+		return len(resp.Uids), fmt.Errorf("ImportGeoJSON: declaring type %s: %w", typeName, err)
+	}
+	return len(resp.Uids), nil
+}
+
+// geoJSONNode converts one GeoJSON Feature into the JSON mutation node for it,
+// identified by the blank node uid.
+func geoJSONNode(f geoJSONFeature, typeName, uid string) (map[string]any, error) {
+	if f.Type != "Feature" {
+		return nil, fmt.Errorf("expected a Feature, got type %q", f.Type)
+	}
+	if len(f.Geometry) == 0 || bytes.Equal(f.Geometry, []byte("null")) {
+		return nil, fmt.Errorf("missing geometry")
+	}
+	node := map[string]any{
+		"uid":                    uid,
+		"dgraph.type":            typeName,
+		GeoJSONGeometryPredicate: f.Geometry,
+	}
+	if len(f.ID) > 0 && !bytes.Equal(f.ID, []byte("null")) {
+		// Decode a numeric id as json.Number so it keeps every digit.
+		var id any
+		dec := json.NewDecoder(bytes.NewReader(f.ID))
+		dec.UseNumber()
+		if err := dec.Decode(&id); err != nil {
+			return nil, fmt.Errorf("id: %w", err)
+		}
+		node[GeoJSONIDPredicate] = fmt.Sprint(id)
+	}
+	for name, raw := range f.Properties {
+		if !isValidPredicateName(name) || name == "uid" || strings.HasPrefix(name, "dgraph.") ||
+			name == GeoJSONGeometryPredicate || name == GeoJSONIDPredicate {
+			return nil, fmt.Errorf("property %q cannot be stored as a predicate", name)
+		}
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("property %q: %w", name, err)
+		}
+		switch value.(type) {
+		case nil:
+			continue
+		case map[string]any, []any:
+			node[name] = string(raw)
+		default:
+			node[name] = raw
+		}
+	}
+	return node, nil
+}
@@ -0,0 +1,205 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+const parksFeatureCollection = `{
+	"type": "FeatureCollection",
+	"features": [
+		{
+			"type": "Feature",
+			"id": "eiffel",
+			"geometry": {"type": "Point", "coordinates": [2.2945, 48.8584]},
+			"properties": {"name": "Eiffel Tower", "height": 330, "tags": ["landmark", "tower"]}
+		},
+		{
+			"type": "Feature",
+			"id": 2,
+			"geometry": {"type": "Point", "coordinates": [-0.1276, 51.5072]},
+			"properties": {"name": "Trafalgar Square", "height": null}
+		},
+		{
+			"type": "Feature",
+			"geometry": {
+				"type": "Polygon",
+				"coordinates": [[[2.2500, 48.8150], [2.4200, 48.8150], [2.4200, 48.9050], [2.2500, 48.9050], [2.2500, 48.8150]]]
+			},
+			"properties": {"name": "Central Paris"}
+		}
+	]
+}`
+
+// ParkFeature reads back the nodes ImportGeoJSON creates for
+// parksFeatureCollection.
+type ParkFeature struct {
+	UID       string          `json:"uid,omitempty"`
+	FeatureID string          `json:"feature_id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Height    int             `json:"height,omitempty"`
+	Tags      string          `json:"tags,omitempty"`
+	Geometry  json.RawMessage `json:"geometry,omitempty"`
+}
+
+func TestClientImportGeoJSON(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "ImportGeoJSONWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "ImportGeoJSONWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			n, err := client.ImportGeoJSON(ctx, []byte(parksFeatureCollection), "Park")
+			require.NoError(t, err, "ImportGeoJSON should succeed")
+			require.Equal(t, 3, n, "one node per feature")
+
+			resp, err := client.QueryRaw(ctx, `{
+				q(func: type(Park), orderasc: name) { uid expand(Park) }
+				total(func: type(Park)) { count(uid) }
+			}`, nil)
+			require.NoError(t, err)
+			var result struct {
+				Q     []ParkFeature `json:"q"`
+				Total []struct {
+					Count int `json:"count"`
+				} `json:"total"`
+			}
+			require.NoError(t, json.Unmarshal(resp, &result))
+			require.Equal(t, 3, result.Total[0].Count)
+			require.Len(t, result.Q, 3)
+
+			central, eiffel, trafalgar := result.Q[0], result.Q[1], result.Q[2]
+			require.Equal(t, "Central Paris", central.Name)
+			require.Empty(t, central.FeatureID)
+			require.JSONEq(t,
+				`{"type":"Polygon","coordinates":[[[2.25,48.815],[2.42,48.815],[2.42,48.905],[2.25,48.905],[2.25,48.815]]]}`,
+				string(central.Geometry))
+
+			require.Equal(t, "Eiffel Tower", eiffel.Name)
+			require.Equal(t, "eiffel", eiffel.FeatureID)
+			require.Equal(t, 330, eiffel.Height)
+			require.JSONEq(t, `["landmark","tower"]`, eiffel.Tags)
+			require.JSONEq(t, `{"type":"Point","coordinates":[2.2945,48.8584]}`, string(eiffel.Geometry))
+
+			require.Equal(t, "Trafalgar Square", trafalgar.Name)
+			require.Equal(t, "2", trafalgar.FeatureID)
+			require.Zero(t, trafalgar.Height, "null properties are skipped")
+
+			// The geometry is indexed: the Eiffel Tower lies within Central Paris.
+			resp, err = client.QueryRaw(ctx, `{
+				q(func: within(geometry, [[[2.25, 48.815], [2.42, 48.815], [2.42, 48.905], [2.25, 48.905], [2.25, 48.815]]])) { name }
+			}`, nil)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"q":[{"name":"Eiffel Tower"}]}`, string(resp))
+
+			t.Run("RejectsNonFeatureCollection", func(t *testing.T) {
+				_, err := client.ImportGeoJSON(ctx, []byte(`{"type":"Feature"}`), "Park")
+				require.Error(t, err)
+			})
+		})
+	}
+}
+
+type Park struct {
+	UID      string             `json:"uid,omitempty"`
+	Name     string             `json:"park_name,omitempty" dgraph:"index=exact"`
+	Boundary modusgraph.GeoJSON `json:"boundary,omitempty" dgraph:"index=geo"`
+	Entrance modusgraph.GeoJSON `json:"entrance,omitempty"`
+	DType    []string           `json:"dgraph.type,omitempty"`
+}
+
+func TestClientGeoJSONRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "GeoJSONRoundTripWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "GeoJSONRoundTripWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			exterior := [][2]float64{
+				{-122.5108, 37.7694}, {-122.4534, 37.7694}, {-122.4534, 37.7741},
+				{-122.5108, 37.7741}, {-122.5108, 37.7694},
+			}
+			hole := [][2]float64{
+				{-122.49, 37.770}, {-122.48, 37.770}, {-122.48, 37.772}, {-122.49, 37.770},
+			}
+			park := Park{
+				Name:     "Golden Gate Park",
+				Boundary: modusgraph.GeoPolygon(exterior, hole),
+				Entrance: modusgraph.GeoPoint(-122.4534, 37.7712),
+			}
+			require.NoError(t, client.Insert(ctx, &park))
+
+			var got Park
+			require.NoError(t, client.Get(ctx, &got, park.UID))
+			var boundary struct {
+				Type        string         `json:"type"`
+				Coordinates [][][2]float64 `json:"coordinates"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(got.Boundary), &boundary))
+			require.Equal(t, "Polygon", boundary.Type)
+			require.Equal(t, [][][2]float64{exterior, hole}, boundary.Coordinates,
+				"The polygon's coordinates should round-trip exactly")
+			require.JSONEq(t, string(park.Entrance), string(got.Entrance))
+
+			// The decoded geometry can be written back unchanged and queried.
+			require.NoError(t, client.Update(ctx, &got))
+			var near []Park
+			require.NoError(t, client.Query(ctx, Park{}).
+				Filter(`contains(boundary, [-122.50, 37.772])`).Nodes(&near))
+			require.Len(t, near, 1)
+			require.Equal(t, got.Boundary, near[0].Boundary)
+		})
+	}
+}
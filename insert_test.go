@@ -8,8 +8,10 @@ package modusgraph_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -158,6 +160,51 @@ func TestEmbeddedInsert(t *testing.T) {
 	}
 }
 
+// Meter stores Reading as a string, but the schema declares reading an int,
+// so a non-numeric reading fails when the engine converts it.
+type Meter struct {
+	Name    string   `json:"name,omitempty"`
+	Reading string   `json:"reading,omitempty"`
+	UID     string   `json:"uid,omitempty"`
+	DType   []string `json:"dgraph.type,omitempty"`
+}
+
+func TestEmbeddedInsertNamesRejectedField(t *testing.T) {
+	// No autoschema: it would redeclare reading as the string Meter holds.
+	client, err := modusgraph.NewClient("file://" + GetTempDir(t))
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := context.Background()
+	require.NoError(t, client.AlterSchema(ctx, `
+		name: string .
+		reading: int .
+		type Meter {
+			name
+			reading
+		}`))
+
+	require.NoError(t, client.Insert(ctx, &Meter{Name: "ok", Reading: "42"}), "a numeric reading converts")
+
+	err = client.Insert(ctx, &Meter{Name: "bad", Reading: "forty-two"})
+	require.Error(t, err, "a non-numeric reading should be rejected")
+
+	var mutErr *modusgraph.MutationError
+	require.True(t, errors.As(err, &mutErr), "error should be a MutationError, got %T: %v", err, err)
+	require.Equal(t, "reading", mutErr.Predicate)
+	require.Equal(t, "forty-two", mutErr.Value)
+	require.True(t, strings.HasPrefix(mutErr.UID, "0x"), "UID should be hex, got %q", mutErr.UID)
+	require.Contains(t, err.Error(), "reading")
+	require.Contains(t, mutErr.Err.Error(), "strconv.ParseInt", "Dgraph's own error should be kept")
+
+	// The rejected insert is discarded whole and leaves no pending
+	// transaction to block a schema change.
+	require.NoError(t, client.AlterSchema(ctx, "label: string ."))
+	resp, err := client.QueryRaw(ctx, `{ q(func: type(Meter)) { name } }`, nil)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"q":[{"name":"ok"}]}`, string(resp))
+}
+
 func TestEmbeddedUniqueConstraintViolation(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -235,6 +282,221 @@ func TestEmbeddedUniqueConstraintViolation(t *testing.T) {
 	}
 }
 
+func TestClientInsertDuplicatePolicy(t *testing.T) {
+	ctx := context.Background()
+
+	// newBatch returns a batch whose third entity repeats the first's unique
+	// name; the first leaves Description empty for a merge to fill.
+	newBatch := func() []*TestEntity {
+		return []*TestEntity{
+			{Name: "Alpha"},
+			{Name: "Beta", Description: "second"},
+			{Name: "Alpha", Description: "third"},
+		}
+	}
+	insertBatch := func(t *testing.T, policy modusgraph.DuplicatePolicy, batch any) ([]TestEntity, error) {
+		client, err := modusgraph.NewClient("file://"+GetTempDir(t), modusgraph.WithAutoSchema(true),
+			modusgraph.WithDuplicatePolicy(policy))
+		require.NoError(t, err)
+		defer func() {
+			client.Close()
+			modusgraph.Shutdown()
+		}()
+
+		insertErr := client.Insert(ctx, batch)
+		var stored []TestEntity
+		require.NoError(t, client.Query(ctx, TestEntity{}).OrderAsc("name").Nodes(&stored))
+		return stored, insertErr
+	}
+	insert := func(t *testing.T, policy modusgraph.DuplicatePolicy) ([]*TestEntity, []TestEntity, error) {
+		batch := newBatch()
+		stored, err := insertBatch(t, policy, batch)
+		return batch, stored, err
+	}
+	// insertValues inserts the batch as a slice of struct values.
+	insertValues := func(t *testing.T, policy modusgraph.DuplicatePolicy) ([]TestEntity, []TestEntity, error) {
+		var batch []TestEntity
+		for _, e := range newBatch() {
+			batch = append(batch, *e)
+		}
+		stored, err := insertBatch(t, policy, batch)
+		return batch, stored, err
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		_, stored, err := insert(t, modusgraph.DuplicateError)
+		var uniqueErr *modusgraph.UniqueError
+		require.True(t, errors.As(err, &uniqueErr), "Error should be a UniqueError, got %v", err)
+		require.Equal(t, "Alpha", uniqueErr.Value)
+		require.Empty(t, stored, "The batch should not be inserted")
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		batch, stored, err := insert(t, modusgraph.DuplicateSkip)
+		require.NoError(t, err)
+		require.Len(t, stored, 2)
+		require.Equal(t, "Alpha", stored[0].Name)
+		require.Empty(t, stored[0].Description, "The skipped duplicate should not be written")
+		require.Equal(t, "Beta", stored[1].Name)
+		require.Equal(t, stored[0].UID, batch[0].UID)
+		require.Equal(t, batch[0].UID, batch[2].UID, "The skipped duplicate should get the kept node's UID")
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		batch, stored, err := insert(t, modusgraph.DuplicateMerge)
+		require.NoError(t, err)
+		require.Len(t, stored, 2)
+		require.Equal(t, "Alpha", stored[0].Name)
+		require.Equal(t, "third", stored[0].Description, "The duplicate should fill the empty field")
+		require.Equal(t, "Beta", stored[1].Name)
+		require.Equal(t, "third", batch[0].Description)
+		require.Equal(t, batch[0].UID, batch[2].UID, "The merged duplicate should get the kept node's UID")
+	})
+
+	t.Run("SkipValues", func(t *testing.T) {
+		batch, stored, err := insertValues(t, modusgraph.DuplicateSkip)
+		require.NoError(t, err)
+		require.Len(t, stored, 2)
+		require.Equal(t, stored[0].UID, batch[0].UID, "The kept element should get its node's UID")
+		require.Equal(t, stored[1].UID, batch[1].UID)
+		require.Equal(t, batch[0].UID, batch[2].UID, "The skipped duplicate should get the kept node's UID")
+	})
+
+	t.Run("MergeValues", func(t *testing.T) {
+		batch, stored, err := insertValues(t, modusgraph.DuplicateMerge)
+		require.NoError(t, err)
+		require.Len(t, stored, 2)
+		require.Equal(t, "third", stored[0].Description, "The duplicate should fill the empty field")
+		require.Equal(t, "third", batch[0].Description)
+		require.Equal(t, stored[0].UID, batch[0].UID)
+		require.Equal(t, batch[0].UID, batch[2].UID, "The merged duplicate should get the kept node's UID")
+	})
+}
+
+func TestClientInsertIfAbsent(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "InsertIfAbsentWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "InsertIfAbsentWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+
+			first := TestEntity{Name: "Idempotent", Description: "first"}
+			inserted, err := client.InsertIfAbsent(ctx, &first)
+			require.NoError(t, err, "First InsertIfAbsent should succeed")
+			require.True(t, inserted, "First InsertIfAbsent should insert")
+			require.NotEmpty(t, first.UID)
+
+			second := TestEntity{Name: "Idempotent", Description: "second"}
+			inserted, err = client.InsertIfAbsent(ctx, &second)
+			require.NoError(t, err, "Second InsertIfAbsent should not fail")
+			require.False(t, inserted, "Second InsertIfAbsent should find the existing node")
+			if strings.HasPrefix(tc.uri, "file://") {
+				require.Equal(t, first.UID, second.UID, "UID should be set to the existing node")
+			}
+
+			var entities []TestEntity
+			require.NoError(t, client.Query(ctx, TestEntity{}).Nodes(&entities))
+			require.Len(t, entities, 1, "Only one entity should exist")
+			require.Equal(t, "first", entities[0].Description, "The existing node should be unchanged")
+
+			_, err = client.InsertIfAbsent(ctx, &TestEntity{Description: "no unique value"})
+			require.Error(t, err, "An object without a unique value should be rejected")
+		})
+	}
+}
+
+type Project struct {
+	Name        string `json:"name,omitempty" dgraph:"index=exact unique"`
+	Description string `json:"description,omitempty"`
+
+	UID   string   `json:"uid,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+type Branch struct {
+	Name    string   `json:"name,omitempty" dgraph:"index=exact"`
+	Project *Project `json:"project,omitempty"`
+
+	UID   string   `json:"uid,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+func TestClientInsertLinksByUniqueField(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "LinkByUniqueFieldWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "LinkByUniqueFieldWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			project := Project{Name: "modusgraph", Description: "graph database"}
+			require.NoError(t, client.Insert(ctx, &project), "Project insert should succeed")
+
+			branch := Branch{Name: "main", Project: &Project{Name: "modusgraph"}}
+			require.NoError(t, client.Insert(ctx, &branch), "Branch insert should link to the existing project")
+			require.Equal(t, project.UID, branch.Project.UID, "The reference should take the existing project's UID")
+
+			var projects []Project
+			require.NoError(t, client.Query(ctx, Project{}).Nodes(&projects))
+			require.Len(t, projects, 1, "No duplicate project should be created")
+			require.Equal(t, "graph database", projects[0].Description, "The existing project should be unchanged")
+
+			var got Branch
+			require.NoError(t, client.Get(ctx, &got, branch.UID))
+			require.NotNil(t, got.Project)
+			require.Equal(t, project.UID, got.Project.UID, "The branch should point at the existing project")
+
+			// A reference that matches nothing is inserted as a new node.
+			other := Branch{Name: "dev", Project: &Project{Name: "other"}}
+			require.NoError(t, client.Insert(ctx, &other))
+			require.NotEmpty(t, other.Project.UID)
+			require.NotEqual(t, project.UID, other.Project.UID)
+		})
+	}
+}
+
 func TestClientInsertMultipleEntities(t *testing.T) {
 
 	testCases := []struct {
@@ -263,7 +525,7 @@ func TestClientInsertMultipleEntities(t *testing.T) {
 			client, cleanup := CreateTestClient(t, tc.uri)
 			defer cleanup()
 
-			// Note the `*TestEntity`, the elements in the slice must be pointers
+			// Note the `*TestEntity`; a slice of struct values works as well
 			entities := []*TestEntity{
 				{
 					Name:        "Entity 1",
@@ -540,6 +802,54 @@ func TestDepthQueryInsertRaw(t *testing.T) {
 	}
 }
 
+func TestClientInsertRawBlankNodePrefix(t *testing.T) {
+	uri := "file://" + GetTempDir(t)
+	ctx := context.Background()
+
+	// Each batch reuses the same logical blank node names, and its first
+	// person befriends its second by blank node reference.
+	insertBatch := func(prefix string) []*Person {
+		client, err := modusgraph.NewClient(uri, modusgraph.WithAutoSchema(true),
+			modusgraph.WithBlankNodePrefix(prefix))
+		require.NoError(t, err)
+		defer func() {
+			client.Close()
+			modusgraph.Shutdown()
+		}()
+
+		people := []*Person{
+			{UID: "_:entity-0", Name: prefix + "0", Friends: []*Person{{UID: "_:entity-1"}}},
+			{UID: "_:entity-1", Name: prefix + "1"},
+		}
+		require.NoError(t, client.InsertRaw(ctx, people), "InsertRaw should succeed")
+		for _, p := range people {
+			require.True(t, strings.HasPrefix(p.UID, "0x"), "UID should be assigned, got %q", p.UID)
+		}
+		return people
+	}
+	first := insertBatch("batchA-")
+	second := insertBatch("batchB-")
+	// The names already begin with this prefix, and are still prefixed
+	third := insertBatch("entity-")
+
+	client, cleanup := CreateTestClient(t, uri)
+	defer cleanup()
+
+	var all []Person
+	require.NoError(t, client.Query(ctx, Person{}).Nodes(&all))
+	assert.Len(t, all, 6, "each batch should create its own nodes")
+
+	for _, batch := range [][]*Person{first, second, third} {
+		var got Person
+		require.NoError(t, client.Get(ctx, &got, batch[0].UID))
+		require.Len(t, got.Friends, 1)
+		assert.Equal(t, batch[1].UID, got.Friends[0].UID, "friend should resolve within its own batch")
+		assert.Equal(t, batch[1].Name, got.Friends[0].Name)
+	}
+	assert.NotEqual(t, first[0].UID, second[0].UID)
+	assert.NotEqual(t, first[1].UID, second[1].UID)
+}
+
 func TestClientInsertRawMultipleEntities(t *testing.T) {
 
 	testCases := []struct {
@@ -568,7 +878,7 @@ func TestClientInsertRawMultipleEntities(t *testing.T) {
 			client, cleanup := CreateTestClient(t, tc.uri)
 			defer cleanup()
 
-			// Note the `*TestEntity`, the elements in the slice must be pointers
+			// Note the `*TestEntity`; a slice of struct values works as well
 			entities := []*TestEntity{
 				{
 					Name:        "Entity 1",
@@ -626,7 +936,7 @@ func TestClientInsertMultipleEntitiesWithEmbeddedStructs(t *testing.T) {
 
 			timestamp := time.Now().UTC().Truncate(time.Second)
 
-			// Note: elements in the slice must be pointers
+			// Note: a slice of struct values works as well as this slice of pointers
 			entities := []*OuterTestEntity{
 				{
 					Name: "Outer Entity 1",
@@ -684,3 +994,127 @@ func TestClientInsertMultipleEntitiesWithEmbeddedStructs(t *testing.T) {
 		})
 	}
 }
+
+// TestInsertReadYourWrites checks that a read issued right after an Insert
+// returns always observes it, including while other writers are committing.
+func TestInsertReadYourWrites(t *testing.T) {
+	client, cleanup := CreateTestClient(t, "file://"+GetTempDir(t))
+	defer cleanup()
+
+	ctx := context.Background()
+	require.NoError(t, client.UpdateSchema(ctx, TestEntity{}))
+
+	const workers = 4
+	const iterations = 50
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				entity := TestEntity{Name: fmt.Sprintf("ryw-%d-%d", w, i)}
+				if err := client.Insert(ctx, &entity); err != nil {
+					errs <- err
+					return
+				}
+
+				var got TestEntity
+				if err := client.Get(ctx, &got, entity.UID); err != nil {
+					errs <- fmt.Errorf("get %s after insert: %w", entity.Name, err)
+					return
+				}
+
+				var results []TestEntity
+				err := client.Query(ctx, TestEntity{}).
+					Filter(fmt.Sprintf(`eq(name, %q)`, entity.Name)).
+					Nodes(&results)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if len(results) != 1 {
+					errs <- fmt.Errorf("query for %s after insert found %d nodes", entity.Name, len(results))
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+func TestClientInsertWithUniquenessCheckDisabled(t *testing.T) {
+	uri := "file://" + GetTempDir(t)
+	ctx := context.Background()
+
+	client, err := modusgraph.NewClient(uri, modusgraph.WithAutoSchema(true),
+		modusgraph.WithUniquenessCheck(false))
+	require.NoError(t, err)
+
+	entities := make([]*TestEntity, 50)
+	for i := range entities {
+		entities[i] = &TestEntity{Name: fmt.Sprintf("Bulk %d", i)}
+	}
+	require.NoError(t, client.Insert(ctx, entities), "bulk insert should succeed")
+	for i, e := range entities {
+		require.NotEmpty(t, e.UID, "entity %d should get a UID", i)
+	}
+
+	var all []TestEntity
+	require.NoError(t, client.Query(ctx, TestEntity{}).Nodes(&all))
+	assert.Len(t, all, len(entities))
+
+	// The caller vouches for uniqueness: a duplicate is stored as it is.
+	require.NoError(t, client.Insert(ctx, &TestEntity{Name: "Bulk 0"}),
+		"a duplicate should not be checked")
+	client.Close()
+	modusgraph.Shutdown()
+
+	// With the check on, the default, duplicates are still rejected.
+	checked, cleanup := CreateTestClient(t, uri)
+	defer cleanup()
+	err = checked.Insert(ctx, &TestEntity{Name: "Bulk 1"})
+	var uniqueErr *modusgraph.UniqueError
+	require.True(t, errors.As(err, &uniqueErr), "want a UniqueError, got %v", err)
+}
+
+func TestClientInsertBatchUniqueCollision(t *testing.T) {
+	client, cleanup := CreateTestClient(t, "file://"+GetTempDir(t))
+	defer cleanup()
+	ctx := context.Background()
+
+	existing := &TestEntity{Name: "Taken"}
+	require.NoError(t, client.Insert(ctx, existing))
+
+	// One element in the middle of the batch collides with the stored node.
+	batch := make([]*TestEntity, 20)
+	for i := range batch {
+		batch[i] = &TestEntity{Name: fmt.Sprintf("Free %d", i)}
+	}
+	batch[13].Name = "Taken"
+
+	err := client.Insert(ctx, batch)
+	var uniqueErr *modusgraph.UniqueError
+	require.True(t, errors.As(err, &uniqueErr), "want a UniqueError, got %v", err)
+	assert.Equal(t, "name", uniqueErr.Field)
+	assert.Equal(t, "Taken", uniqueErr.Value, "the colliding element should be flagged")
+	assert.Equal(t, existing.UID, uniqueErr.UID, "the error should name the node holding the value")
+
+	var all []TestEntity
+	require.NoError(t, client.Query(ctx, TestEntity{}).Nodes(&all))
+	assert.Len(t, all, 1, "no element of the failed batch should be stored")
+
+	// Without the collision the batch goes through, checked in one query.
+	batch[13].Name = "Free 13"
+	for _, e := range batch {
+		e.UID = ""
+	}
+	require.NoError(t, client.Insert(ctx, batch))
+	require.NoError(t, client.Query(ctx, TestEntity{}).Nodes(&all))
+	assert.Len(t, all, len(batch)+1)
+}
@@ -6,10 +6,16 @@
 package modusgraph
 
 import (
+	"context"
+	"encoding/json"
+	"reflect"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	dg "github.com/dolan-in/dgman/v2"
 	"github.com/stretchr/testify/require"
 )
 
@@ -210,3 +216,206 @@ func TestGenerateFilterQuery(t *testing.T) {
 
 	//fmt.Println(query)
 }
+
+type AltTagged struct {
+	UID   string   `json:"uid,omitempty"`
+	Email string   `json:"email,omitempty" db:"constraint=unique" dgraph:"index=exact"`
+	Code  string   `json:"code,omitempty" db:"index=hash,upsert"`
+	Plain string   `json:"plain,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+func TestAltTagDirectives(t *testing.T) {
+	require.Equal(t, "unique", translateAltTag("constraint=unique"))
+	require.Equal(t, "index=hash upsert", translateAltTag("index=hash,upsert"))
+	require.Equal(t, "", translateAltTag(""))
+
+	field, _ := reflect.TypeOf(AltTagged{}).FieldByName("Email")
+	require.Equal(t, "index=exact", fieldDirectives(field, ""))
+	require.Equal(t, "index=exact unique", fieldDirectives(field, "db"))
+
+	require.Equal(t, "", firstUpsertPredicate(&AltTagged{}, ""))
+	require.Equal(t, "code", firstUpsertPredicate(&AltTagged{}, "db"))
+
+	ts := dg.NewTypeSchema()
+	ts.Marshal("", &AltTagged{})
+	overlayAltTag(ts.Schema, "db", &AltTagged{})
+	require.Equal(t, "email: string @index(exact) @upsert @unique .", ts.Schema["email"].String())
+	require.Equal(t, "code: string @index(hash) @upsert @unique .", ts.Schema["code"].String())
+	require.Equal(t, "plain: string .", ts.Schema["plain"].String())
+}
+
+type orderedPerson struct {
+	UID   string   `json:"uid,omitempty"`
+	Zone  string   `json:"zone,omitempty" dgraph:"index=exact"`
+	Alias string   `json:"alias,omitempty" dgraph:"index=hash unique"`
+	Age   int      `json:"age,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+func TestSortedSchemaIsStable(t *testing.T) {
+	render := func() string {
+		ts := dg.NewTypeSchema()
+		ts.Marshal("", &AllTags{}, &orderedPerson{})
+		return typeSchemaString(ts, true)
+	}
+	want := render()
+	for range 20 {
+		require.Equal(t, want, render())
+	}
+
+	preds, types, _ := strings.Cut(want, "\n\n")
+	lines := strings.Split(strings.TrimSpace(preds), "\n")
+	require.True(t, sort.StringsAreSorted(lines), "predicates out of order:\n%s", preds)
+	require.Less(t, strings.Index(types, "type AllTags {"), strings.Index(types, "type orderedPerson {"))
+	require.Contains(t, types, "\temail\n\temployee_id\n")
+
+	query, _ := generateUniquePredicateQuery(getUniquePredicates(&AllTags{}), "User")
+	for range 20 {
+		again, _ := generateUniquePredicateQuery(getUniquePredicates(&AllTags{}), "User")
+		require.Equal(t, query, again)
+	}
+}
+
+func TestSortedSchemaAppliesSchema(t *testing.T) {
+	c, err := NewClient("file://"+t.TempDir(), WithAutoSchema(true), WithSortedSchema(true))
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+
+	require.NoError(t, c.Insert(context.Background(), &orderedPerson{Zone: "eu", Alias: "a", Age: 3}))
+	schema, err := c.GetSchema(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, schema, "alias: string @index(hash) @upsert @unique .")
+	require.Contains(t, schema, "zone: string @index(exact) .")
+	require.Contains(t, schema, "type orderedPerson {")
+}
+
+func TestPrefixBlankNodes(t *testing.T) {
+	type node struct {
+		UID   string  `json:"uid,omitempty"`
+		Name  string  `json:"name,omitempty"`
+		Edges []*node `json:"edges,omitempty"`
+	}
+	a := &node{UID: "_:a", Name: "_:not-a-uid"}
+	b := &node{UID: "_:b", Edges: []*node{a, {UID: "0x1"}}}
+	a.Edges = []*node{b} // cycle
+	nodes := []*node{a, b}
+
+	restore := prefixBlankNodes(&nodes, "p-")
+	require.Equal(t, "_:p-a", a.UID)
+	require.Equal(t, "_:p-b", b.UID)
+	require.Equal(t, "0x1", b.Edges[1].UID)
+	require.Equal(t, "_:not-a-uid", a.Name)
+
+	restore()
+	require.Equal(t, "_:a", a.UID, "restore puts back the caller's names")
+	require.Equal(t, "_:b", b.UID)
+
+	// Names that already begin with the prefix are prefixed all the same
+	c := &node{UID: "_:entity-1"}
+	restore = prefixBlankNodes(c, "entity-")
+	require.Equal(t, "_:entity-entity-1", c.UID)
+	restore()
+	require.Equal(t, "_:entity-1", c.UID)
+	prefixBlankNodes(c, "entity-")
+	require.Equal(t, "_:entity-entity-1", c.UID, "a retry prefixes once")
+}
+
+func TestGeoJSONNodeLargeNumericID(t *testing.T) {
+	node, err := geoJSONNode(geoJSONFeature{
+		Type:     "Feature",
+		ID:       json.RawMessage("9007199254740993"),
+		Geometry: json.RawMessage(`{"type":"Point","coordinates":[0,0]}`),
+	}, "Place", "_:f0")
+	require.NoError(t, err)
+	require.Equal(t, "9007199254740993", node[GeoJSONIDPredicate], "a numeric id should keep every digit")
+}
+
+func TestQueryCacheEvictsAndExpires(t *testing.T) {
+	now := time.Unix(0, 0)
+	qc := newQueryCache(2, time.Minute)
+	qc.now = func() time.Time { return now }
+
+	qc.put("a", []byte("A"))
+	qc.put("b", []byte("B"))
+	_, ok := qc.get("a") // a is now the most recently used
+	require.True(t, ok)
+	qc.put("c", []byte("C"))
+	_, ok = qc.get("b")
+	require.False(t, ok, "The least recently used entry should be evicted")
+
+	data, ok := qc.get("a")
+	require.True(t, ok)
+	data[0] = 'X'
+	data, _ = qc.get("a")
+	require.Equal(t, "A", string(data), "Callers should not be able to change a cached result")
+
+	now = now.Add(time.Minute)
+	_, ok = qc.get("a")
+	require.False(t, ok, "An entry should expire after the TTL")
+
+	require.Equal(t, queryCacheKey("0", "q", map[string]string{"$a": "1", "$b": "2"}),
+		queryCacheKey("0", "q", map[string]string{"$b": "2", "$a": "1"}))
+	require.NotEqual(t, queryCacheKey("0", "q", map[string]string{"$a": "1"}),
+		queryCacheKey("1", "q", map[string]string{"$a": "1"}))
+}
+
+func TestCheckCyclesSkipsScalarSlices(t *testing.T) {
+	type node struct {
+		Blob     []byte
+		Vector   []float32
+		Children []node
+		Next     *node
+	}
+	n := &node{Blob: make([]byte, 1<<20), Vector: make([]float32, 1536)}
+	n.Children = []node{{Blob: make([]byte, 1024)}}
+	require.NoError(t, checkCycles(reflect.ValueOf(n)))
+	allocs := testing.AllocsPerRun(10, func() {
+		_ = checkCycles(reflect.ValueOf(n))
+	})
+	require.Less(t, allocs, 20.0, "walking a blob or vector should not allocate per element")
+
+	n.Children[0].Next = n
+	err := checkCycles(reflect.ValueOf(n))
+	require.ErrorIs(t, err, ErrCyclicGraph)
+	require.Contains(t, err.Error(), "node.Children[0].Next refers back to node")
+}
+
+func TestPredicateNamesRewrite(t *testing.T) {
+	type clerk struct {
+		UID     string   `json:"uid,omitempty"`
+		ClerkID string   `json:"clerkID,omitempty"`
+		Score   float64  `json:"score,omitempty" dgraph:"alias=computed"`
+		DType   []string `json:"dgraph.type,omitempty"`
+	}
+	names := newPredicateNames(NamingSnakeCase, "")
+	names.learn(clerk{})
+
+	q := `query q($clerkID: string) { q(func: type(clerkID)) @filter(eq(clerkID, "clerkID")) {
+		clerkID: clerkID
+		~clerkID
+		clerkID@en
+		clerkID__vec
+		score : val(s)
+	} }`
+	want := `query q($clerkID: string) { q(func: type(clerkID)) @filter(eq(clerk_id, "clerkID")) {
+		clerkID: clerk_id
+		~clerk_id
+		clerk_id@en
+		clerk_id__vec
+		score : val(s)
+	} }`
+	require.Equal(t, want, names.query(q))
+
+	set, err := names.mutationJSON([]byte(`{"uid":"_:a","clerkID":"c1","dgraph.type":"Clerk","reportsTo|since":"x","workspaceName":{"deskID":1}}`))
+	require.NoError(t, err)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(set, &got))
+	require.Equal(t, map[string]any{"uid": "_:a", "clerk_id": "c1", "dgraph.type": "Clerk",
+		"reports_to|since": "x", "workspace_name": map[string]any{"desk_id": float64(1)}}, got)
+
+	resp := &api.Response{Json: []byte(`{"q":[{"clerk_id":"c1","~clerk_id":[{"uid":"0x1"}],"desk_id":2}]}`)}
+	require.NoError(t, names.response(resp))
+	require.JSONEq(t, `{"q":[{"clerk_id":"c1","clerkID":"c1","~clerk_id":[{"uid":"0x1"}],"~clerkID":[{"uid":"0x1"}],
+		"desk_id":2,"deskID":2}]}`, string(resp.Json))
+}
@@ -0,0 +1,248 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/v25/protos/pb"
+	"github.com/dgraph-io/dgraph/v25/schema"
+	"github.com/dgraph-io/dgraph/v25/types"
+	"github.com/dgraph-io/dgraph/v25/x"
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// Introspection describes a database's schema in structured form: every type
+// with the predicates it declares, and every predicate with its value type
+// and directives. Dgraph's own dgraph.* types and predicates are left out.
+// Both lists are sorted by name.
+type Introspection struct {
+	Types      []TypeInfo
+	Predicates []PredicateInfo
+}
+
+// TypeInfo describes one Dgraph type.
+type TypeInfo struct {
+	Name   string
+	Fields []string // the type's predicates, sorted
+}
+
+// PredicateInfo describes one predicate.
+type PredicateInfo struct {
+	Name       string
+	Type       string   // the scalar value type, such as "string", "int", or "uid"
+	List       bool     // declared as a list, such as [uid]
+	Tokenizers []string // index tokenizers; empty when the predicate is not indexed
+	Reverse    bool
+	Count      bool
+	Upsert     bool
+	Lang       bool
+	Unique     bool
+}
+
+// Type returns the type named name, if the schema declares it.
+func (in Introspection) Type(name string) (TypeInfo, bool) {
+	i := slices.IndexFunc(in.Types, func(t TypeInfo) bool { return t.Name == name })
+	if i < 0 {
+		return TypeInfo{}, false
+	}
+	return in.Types[i], true
+}
+
+// Predicate returns the predicate named name, if the schema declares it.
+func (in Introspection) Predicate(name string) (PredicateInfo, bool) {
+	i := slices.IndexFunc(in.Predicates, func(p PredicateInfo) bool { return p.Name == name })
+	if i < 0 {
+		return PredicateInfo{}, false
+	}
+	return in.Predicates[i], true
+}
+
+// schema returns the predicate's definition in the form dgman renders.
+func (p PredicateInfo) schema() dg.Schema {
+	return dg.Schema{
+		Predicate: p.Name,
+		Type:      p.Type,
+		Index:     len(p.Tokenizers) > 0,
+		Tokenizer: p.Tokenizers,
+		Reverse:   p.Reverse,
+		Count:     p.Count,
+		List:      p.List,
+		Upsert:    p.Upsert,
+		Lang:      p.Lang,
+		Unique:    p.Unique,
+	}
+}
+
+// Introspect implements describing the schema. Embedded clients read the
+// engine's schema state directly, since a schema {} query there does not
+// list predicates; remote clients run that query.
+func (c client) Introspect(ctx context.Context) (Introspection, error) {
+	if c.engine != nil {
+		ns, err := c.engineNamespace()
+		if err != nil {
+			return Introspection{}, err
+		}
+		return ns.Introspect(ctx)
+	}
+
+	dgClient, err := c.pool.get()
+	if err != nil {
+		c.logger.Error(err, "Failed to get client from pool")
+		return Introspection{}, err
+	}
+	defer c.pool.put(dgClient)
+
+	resp, err := dgClient.NewReadOnlyTxn().Query(ctx, "schema {}")
+	if err != nil {
+		return Introspection{}, err
+	}
+	var raw struct {
+		Schema []struct {
+			Predicate string   `json:"predicate"`
+			Type      string   `json:"type"`
+			List      bool     `json:"list"`
+			Tokenizer []string `json:"tokenizer"`
+			Reverse   bool     `json:"reverse"`
+			Count     bool     `json:"count"`
+			Upsert    bool     `json:"upsert"`
+			Lang      bool     `json:"lang"`
+			Unique    bool     `json:"unique"`
+		} `json:"schema"`
+		Types []struct {
+			Name   string `json:"name"`
+			Fields []struct {
+				Name string `json:"name"`
+			} `json:"fields"`
+		} `json:"types"`
+	}
+	if err := json.Unmarshal(resp.GetJson(), &raw); err != nil {
+		return Introspection{}, err
+	}
+
+	var in Introspection
+	for _, p := range raw.Schema {
+		if strings.HasPrefix(p.Predicate, "dgraph.") {
+			continue
+		}
+		in.Predicates = append(in.Predicates, PredicateInfo{
+			Name:       p.Predicate,
+			Type:       p.Type,
+			List:       p.List,
+			Tokenizers: p.Tokenizer,
+			Reverse:    p.Reverse,
+			Count:      p.Count,
+			Upsert:     p.Upsert,
+			Lang:       p.Lang,
+			Unique:     p.Unique,
+		})
+	}
+	for _, t := range raw.Types {
+		if strings.HasPrefix(t.Name, "dgraph.") {
+			continue
+		}
+		info := TypeInfo{Name: t.Name}
+		for _, f := range t.Fields {
+			info.Fields = append(info.Fields, f.Name)
+		}
+		in.Types = append(in.Types, info)
+	}
+	in.sort()
+	return in, nil
+}
+
+// ListPredicates implements listing the schema's predicates, independent of
+// any Go type. It reads the same schema as Introspect.
+func (c client) ListPredicates(ctx context.Context) ([]string, error) {
+	in, err := c.Introspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(in.Predicates))
+	for i, p := range in.Predicates {
+		names[i] = p.Name
+	}
+	return names, nil
+}
+
+// HasPredicate implements checking the schema for a predicate, such as a
+// migration deciding whether one still needs creating.
+func (c client) HasPredicate(ctx context.Context, predicate string) (bool, error) {
+	in, err := c.Introspect(ctx)
+	if err != nil {
+		return false, err
+	}
+	_, ok := in.Predicate(predicate)
+	return ok, nil
+}
+
+// Introspect describes the namespace's schema from the engine's schema state.
+func (ns *Namespace) Introspect(ctx context.Context) (Introspection, error) {
+	return ns.engine.introspect(ctx, ns)
+}
+
+func (engine *Engine) introspect(ctx context.Context, ns *Namespace) (Introspection, error) {
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+
+	if !engine.isOpen.Load() {
+		return Introspection{}, ErrClosedEngine
+	}
+
+	var in Introspection
+	for _, pred := range schema.State().Predicates() {
+		if x.ParseNamespace(pred) != ns.ID() || x.IsReservedPredicate(pred) {
+			continue
+		}
+		su, ok := schema.State().Get(ctx, pred)
+		if !ok {
+			continue
+		}
+		info := PredicateInfo{
+			Name:       x.ParseAttr(pred),
+			Type:       types.TypeID(su.ValueType).Name(),
+			List:       su.List,
+			Tokenizers: su.Tokenizer,
+			Reverse:    su.Directive == pb.SchemaUpdate_REVERSE,
+			Count:      su.Count,
+			Upsert:     su.Upsert,
+			Lang:       su.Lang,
+			Unique:     su.Unique,
+		}
+		for _, spec := range su.IndexSpecs {
+			info.Tokenizers = append(info.Tokenizers, spec.Name)
+		}
+		in.Predicates = append(in.Predicates, info)
+	}
+	for _, typ := range schema.State().Types() {
+		if x.ParseNamespace(typ) != ns.ID() || x.IsReservedType(typ) {
+			continue
+		}
+		tu, ok := schema.State().GetType(typ)
+		if !ok {
+			continue
+		}
+		info := TypeInfo{Name: x.ParseAttr(typ)}
+		for _, f := range tu.Fields {
+			info.Fields = append(info.Fields, x.ParseAttr(f.Predicate))
+		}
+		in.Types = append(in.Types, info)
+	}
+	in.sort()
+	return in, nil
+}
+
+// sort orders the types, their fields, and the predicates by name.
+func (in *Introspection) sort() {
+	slices.SortFunc(in.Types, func(a, b TypeInfo) int { return strings.Compare(a.Name, b.Name) })
+	for _, t := range in.Types {
+		slices.Sort(t.Fields)
+	}
+	slices.SortFunc(in.Predicates, func(a, b PredicateInfo) int { return strings.Compare(a.Name, b.Name) })
+}
@@ -0,0 +1,146 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Shelf struct {
+	UID   string   `json:"uid,omitempty"`
+	Label string   `json:"shelf_label,omitempty" dgraph:"index=exact upsert"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+type Book struct {
+	UID     string   `json:"uid,omitempty"`
+	Title   string   `json:"book_title,omitempty" dgraph:"index=term,exact"`
+	Pages   int      `json:"book_pages,omitempty" dgraph:"index=int"`
+	Tags    []string `json:"book_tags,omitempty"`
+	OnShelf *Shelf   `json:"on_shelf,omitempty" dgraph:"reverse"`
+	DType   []string `json:"dgraph.type,omitempty"`
+}
+
+func TestClientIntrospect(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "IntrospectWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "IntrospectWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			book := &Book{
+				Title:   "Dune",
+				Pages:   412,
+				Tags:    []string{"scifi"},
+				OnShelf: &Shelf{Label: "A1"},
+			}
+			require.NoError(t, client.Insert(ctx, book), "Insert should succeed")
+
+			in, err := client.Introspect(ctx)
+			require.NoError(t, err, "Introspect should succeed")
+
+			typ, ok := in.Type("Book")
+			require.True(t, ok, "Book type should be present: %+v", in.Types)
+			require.Equal(t, []string{"book_pages", "book_tags", "book_title", "on_shelf"}, typ.Fields)
+			_, ok = in.Type("Shelf")
+			require.True(t, ok, "Shelf type should be present")
+
+			title, ok := in.Predicate("book_title")
+			require.True(t, ok)
+			require.Equal(t, "string", title.Type)
+			require.ElementsMatch(t, []string{"term", "exact"}, title.Tokenizers)
+
+			pages, _ := in.Predicate("book_pages")
+			require.Equal(t, "int", pages.Type)
+
+			tags, _ := in.Predicate("book_tags")
+			require.True(t, tags.List, "book_tags should be a list")
+
+			shelf, _ := in.Predicate("on_shelf")
+			require.Equal(t, "uid", shelf.Type)
+			require.True(t, shelf.Reverse, "on_shelf should carry @reverse")
+
+			label, _ := in.Predicate("shelf_label")
+			require.True(t, label.Upsert, "shelf_label should carry @upsert")
+
+			for _, p := range in.Predicates {
+				require.NotEqual(t, "dgraph.type", p.Name, "reserved predicates are left out")
+			}
+		})
+	}
+}
+
+func TestClientListPredicates(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "ListPredicatesWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "ListPredicatesWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			exists, err := client.HasPredicate(ctx, "shelf_label")
+			require.NoError(t, err)
+			require.False(t, exists, "shelf_label should not exist before anything is inserted")
+
+			require.NoError(t, client.Insert(ctx, &Shelf{Label: "B2"}), "Insert should succeed")
+
+			exists, err = client.HasPredicate(ctx, "shelf_label")
+			require.NoError(t, err)
+			require.True(t, exists, "shelf_label should exist after inserting a Shelf")
+
+			names, err := client.ListPredicates(ctx)
+			require.NoError(t, err)
+			require.Contains(t, names, "shelf_label")
+			require.NotContains(t, names, "dgraph.type", "reserved predicates are left out")
+			require.IsIncreasing(t, names, "predicates should be sorted")
+		})
+	}
+}
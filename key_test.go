@@ -0,0 +1,76 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestKeyDistinguishesBaseContext(t *testing.T) {
+	first, cancelFirst := context.WithCancel(context.Background())
+	defer cancelFirst()
+	second, cancelSecond := context.WithCancel(context.Background())
+	defer cancelSecond()
+
+	a := client{uri: "file:///tmp/db"}
+	b := client{uri: "file:///tmp/db"}
+	WithBaseContext(first)(&a.options)
+	WithBaseContext(second)(&b.options)
+	if a.key() == b.key() {
+		t.Fatal("client.key() must differ when base contexts differ, else one caller's cancellation is lost")
+	}
+
+	c := client{uri: "file:///tmp/db"}
+	WithBaseContext(first)(&c.options)
+	if a.key() != c.key() {
+		t.Fatal("clients with the same base context must share a key")
+	}
+	d := client{uri: "file:///tmp/db", options: clientOptions{baseCtx: context.Background()}}
+	e := client{uri: "file:///tmp/db", options: clientOptions{baseCtx: context.Background()}}
+	if d.key() != e.key() {
+		t.Fatal("clients on context.Background() must share a key")
+	}
+}
+
+func TestKeyDistinguishesRecover(t *testing.T) {
+	plain := client{uri: "file:///tmp/db"}
+	recovering := client{uri: "file:///tmp/db"}
+	WithRecover(true)(&recovering.options)
+	if plain.key() == recovering.key() {
+		t.Fatal("client.key() must differ when WithRecover differs, else a recovering caller gets a client that panics")
+	}
+}
+
+func TestKeyDistinguishesCommitHook(t *testing.T) {
+	var seen []CommitInfo
+	record := func(_ context.Context, info CommitInfo) { seen = append(seen, info) }
+	drop := func(context.Context, CommitInfo) {}
+
+	plain := client{uri: "file:///tmp/db"}
+	a := client{uri: "file:///tmp/db"}
+	b := client{uri: "file:///tmp/db"}
+	WithCommitHook(record)(&a.options)
+	WithCommitHook(drop)(&b.options)
+	if plain.key() == a.key() || a.key() == b.key() {
+		t.Fatal("client.key() must differ when commit hooks differ, else a hook is never called")
+	}
+	c := client{uri: "file:///tmp/db"}
+	WithCommitHook(record)(&c.options)
+	if a.key() != c.key() {
+		t.Fatal("clients with the same commit hook must share a key")
+	}
+}
+
+func TestKeyDistinguishesLockWait(t *testing.T) {
+	plain := client{uri: "file:///tmp/db"}
+	waiting := client{uri: "file:///tmp/db"}
+	WithLockWait(5 * time.Second)(&waiting.options)
+	if plain.key() == waiting.key() {
+		t.Fatal("client.key() must differ when WithLockWait differs, else a waiting caller gets a client that fails at once")
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// typeLayout is what the client reads from the fields of a struct type on
+// every Get and write: its readFrom fields, its version field, and the
+// directives of its persisted fields. layoutOf computes it once per type.
+type typeLayout struct {
+	readFrom    []readFromField
+	readFromErr error // a malformed readFrom field, reported on read
+
+	version     int // index of the version field, or -1
+	versionPred string
+	versionErr  error // a version field of the wrong type, reported on write
+
+	// fields are the exported fields a node persists, leaving out UID,
+	// DType, and those tagged json:"-".
+	fields []layoutField
+}
+
+// readFromField is a field tagged readFrom.
+type readFromField struct {
+	index int
+	name  string
+	rf    readFrom
+	elem  reflect.Type // the struct type of the nodes it holds
+}
+
+// layoutField is a persisted field of a typeLayout.
+type layoutField struct {
+	index     int
+	predicate string
+	unique    bool
+}
+
+// layoutKey keys typeLayouts: a type's directives depend on the alternate
+// tag the client reads alongside the dgraph tag.
+type layoutKey struct {
+	t      reflect.Type
+	altTag string
+}
+
+// typeLayouts caches *typeLayout by layoutKey.
+var typeLayouts sync.Map
+
+// layoutOf returns the layout of the struct type t under altTag, computing it
+// on first use.
+func layoutOf(t reflect.Type, altTag string) *typeLayout {
+	key := layoutKey{t, altTag}
+	if l, ok := typeLayouts.Load(key); ok {
+		return l.(*typeLayout)
+	}
+	l := &typeLayout{version: -1}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		directives := fieldDirectives(field, altTag)
+		if tag, ok := field.Tag.Lookup(readFromTag); ok && l.readFromErr == nil {
+			rf, err := readFromFieldOf(field, tag)
+			if err != nil {
+				l.readFromErr = err
+			}
+			rf.index = i
+			l.readFrom = append(l.readFrom, rf)
+		}
+		if l.version < 0 && l.versionErr == nil && hasDirective(directives, "version") {
+			switch field.Type.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				l.version, l.versionPred = i, predicateName(field, directives)
+			default:
+				l.versionErr = fmt.Errorf("version field %s must be a signed integer, got %s", field.Name, field.Type)
+			}
+		}
+		if !field.IsExported() || field.Name == "UID" || field.Name == "DType" || field.Tag.Get("json") == "-" {
+			continue
+		}
+		l.fields = append(l.fields, layoutField{
+			index:     i,
+			predicate: predicateName(field, directives),
+			unique:    hasDirective(directives, "unique"),
+		})
+	}
+	actual, _ := typeLayouts.LoadOrStore(key, l)
+	return actual.(*typeLayout)
+}
+
+// readFromFieldOf parses the readFrom tag of field.
+func readFromFieldOf(field reflect.StructField, tag string) (readFromField, error) {
+	rf, err := parseReadFrom(tag)
+	if err != nil {
+		return readFromField{}, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+	elem := field.Type
+	if elem.Kind() == reflect.Slice {
+		elem = elem.Elem()
+	}
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return readFromField{}, fmt.Errorf("readFrom field %s must hold structs", field.Name)
+	}
+	return readFromField{name: field.Name, rf: rf, elem: elem}, nil
+}
@@ -0,0 +1,110 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"fmt"
+	"reflect"
+
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// linkUniqueRefs points the edges of obj at existing nodes where they can be
+// identified by a unique value. A node reached through an edge that has no
+// UID and sets nothing but fields tagged dgraph:"unique" is a reference to
+// the node of its type holding that value: its UID is looked up in tx and
+// set, so the insert links to the node instead of creating a duplicate. A
+// reference nothing matches is left to be inserted as a new node. The nodes
+// passed in obj itself are never treated as references. Directives are read
+// from the dgraph tag and altTag, as WithTagName sets.
+func linkUniqueRefs(tx *dg.TxnContext, obj any, altTag string) error {
+	seen := make(map[uintptr]bool)
+	var walk func(v reflect.Value, root bool) error
+	walk = func(v reflect.Value, root bool) error {
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			if v.IsNil() {
+				return nil
+			}
+			if v.Kind() == reflect.Pointer {
+				if seen[v.Pointer()] {
+					return nil
+				}
+				seen[v.Pointer()] = true
+				if !root && v.Elem().Kind() == reflect.Struct {
+					if linked, err := linkUniqueRef(tx, v.Interface(), altTag); linked || err != nil {
+						return err
+					}
+				}
+			}
+			return walk(v.Elem(), root)
+		case reflect.Slice, reflect.Array:
+			if v.Type().Elem().Kind() == reflect.Uint8 {
+				return nil
+			}
+			for i := 0; i < v.Len(); i++ {
+				if err := walk(v.Index(i), root); err != nil {
+					return err
+				}
+			}
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				if !v.Type().Field(i).IsExported() {
+					continue
+				}
+				if err := walk(v.Field(i), false); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return walk(reflect.ValueOf(obj), true)
+}
+
+// linkUniqueRef sets the UID of node, a struct pointer, to that of the
+// existing node it references by unique value, reporting whether it did.
+func linkUniqueRef(tx *dg.TxnContext, node any, altTag string) (bool, error) {
+	preds, ok := uniqueRefPredicates(node, altTag)
+	if !ok {
+		return false, nil
+	}
+	q, vars := generateUniquePredicateQuery(preds, getNodeType(node))
+	resp, err := tx.Txn().QueryWithVars(tx.Context(), q, vars)
+	if err != nil {
+		return false, fmt.Errorf("resolving %s reference: %w", getNodeType(node), err)
+	}
+	uid, err := extractUIDFromDgraphQueryResult(resp.GetJson())
+	if err != nil || uid == "" {
+		return false, err
+	}
+	setUIDValue(node, uid)
+	return true, nil
+}
+
+// uniqueRefPredicates returns the unique predicates node, a struct pointer,
+// sets, by name, when node is a reference: it has no UID, sets at least one
+// field whose directives carry unique, and sets no other persisted field
+// besides its dgraph.type. ok is false when node is not a reference.
+func uniqueRefPredicates(node any, altTag string) (preds map[string]any, ok bool) {
+	v := reflect.ValueOf(node).Elem()
+	uidField := v.FieldByName("UID")
+	if !uidField.IsValid() || uidField.Kind() != reflect.String || uidField.String() != "" {
+		return nil, false
+	}
+	preds = make(map[string]any)
+	for _, field := range layoutOf(v.Type(), altTag).fields {
+		value := v.Field(field.index)
+		if value.IsZero() {
+			continue
+		}
+		if !field.unique {
+			return nil, false
+		}
+		preds[field.predicate] = value.Interface()
+	}
+	return preds, len(preds) > 0
+}
@@ -0,0 +1,132 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package load_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+// BenchmarkCodecMarshal compares encoding a BenchmarkEntity by reflection
+// (encoding/json, as dgman does) with the precompiled cached codec.
+func BenchmarkCodecMarshal(b *testing.B) {
+	entity := generateEntity(1)
+	entity.DType = []string{"BenchmarkEntity"}
+
+	b.Run("Reflection", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(entity); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("CachedCodec", func(b *testing.B) {
+		codec, err := modusgraph.NewCachedCodec(BenchmarkEntity{})
+		require.NoError(b, err)
+		buf := make([]byte, 0, 512)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if buf, err = codec.Marshal(buf[:0], entity); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkCodecInsert compares batch inserts of BenchmarkEntity through
+// dgman's reflection walk with inserts through WithCodec.
+func BenchmarkCodecInsert(b *testing.B) {
+	const batchSize = 100
+
+	run := func(b *testing.B, opts ...modusgraph.ClientOpt) {
+		opts = append(opts, modusgraph.WithAutoSchema(true))
+		client, err := modusgraph.NewClient("file://"+b.TempDir(), opts...)
+		require.NoError(b, err)
+		defer func() {
+			client.Close()
+			modusgraph.Shutdown()
+		}()
+
+		ctx := context.Background()
+		require.NoError(b, client.UpdateSchema(ctx, BenchmarkEntity{}))
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			entities := make([]*BenchmarkEntity, batchSize)
+			for j := range entities {
+				entities[j] = generateEntity(i*batchSize + j)
+			}
+			b.StartTimer()
+			require.NoError(b, client.Insert(ctx, entities))
+		}
+	}
+
+	b.Run("Reflection", func(b *testing.B) {
+		run(b)
+	})
+
+	b.Run("CachedCodec", func(b *testing.B) {
+		codec, err := modusgraph.NewCachedCodec(BenchmarkEntity{})
+		require.NoError(b, err)
+		run(b, modusgraph.WithCodec(codec))
+	})
+}
+
+// BenchmarkDecodePooling compares reading a batch of BenchmarkEntity nodes
+// through QueryInterface and the cached codec with and without
+// WithDecodePooling.
+func BenchmarkDecodePooling(b *testing.B) {
+	const batchSize = 500
+
+	run := func(b *testing.B, pooling bool) {
+		codec, err := modusgraph.NewCachedCodec(BenchmarkEntity{})
+		require.NoError(b, err)
+		client, err := modusgraph.NewClient("file://"+b.TempDir(), modusgraph.WithAutoSchema(true),
+			modusgraph.WithCodec(codec), modusgraph.WithDecodePooling(pooling))
+		require.NoError(b, err)
+		defer func() {
+			client.Close()
+			modusgraph.Shutdown()
+		}()
+
+		ctx := context.Background()
+		entities := make([]*BenchmarkEntity, batchSize)
+		for i := range entities {
+			entities[i] = generateEntity(i)
+		}
+		require.NoError(b, client.Insert(ctx, entities))
+		factory := func() any { return &BenchmarkEntity{} }
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			nodes, err := client.QueryInterface(ctx, "BenchmarkEntity", factory)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(nodes) != batchSize {
+				b.Fatalf("got %d nodes, want %d", len(nodes), batchSize)
+			}
+		}
+	}
+
+	b.Run("Unpooled", func(b *testing.B) {
+		run(b, false)
+	})
+
+	b.Run("Pooled", func(b *testing.B) {
+		run(b, true)
+	})
+}
@@ -0,0 +1,63 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package load_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+// UniqueBenchmarkEntity carries a @unique predicate, which the embedded engine
+// checks for every element of a batch.
+type UniqueBenchmarkEntity struct {
+	UID   string   `json:"uid,omitempty"`
+	Key   string   `json:"key,omitempty" dgraph:"index=exact unique"`
+	Value int      `json:"value,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+// BenchmarkUniquenessCheckInsert compares batch inserts of entities with a
+// @unique predicate with and without WithUniquenessCheck.
+func BenchmarkUniquenessCheckInsert(b *testing.B) {
+	const batchSize = 500
+
+	run := func(b *testing.B, check bool) {
+		client, err := modusgraph.NewClient("file://"+b.TempDir(), modusgraph.WithAutoSchema(true),
+			modusgraph.WithUniquenessCheck(check))
+		require.NoError(b, err)
+		defer func() {
+			client.Close()
+			modusgraph.Shutdown()
+		}()
+
+		ctx := context.Background()
+		require.NoError(b, client.UpdateSchema(ctx, UniqueBenchmarkEntity{}))
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			entities := make([]*UniqueBenchmarkEntity, batchSize)
+			for j := range entities {
+				entities[j] = &UniqueBenchmarkEntity{Key: fmt.Sprintf("key-%d", i*batchSize+j), Value: j}
+			}
+			b.StartTimer()
+			require.NoError(b, client.Insert(ctx, entities))
+		}
+	}
+
+	b.Run("Checked", func(b *testing.B) {
+		run(b, true)
+	})
+
+	b.Run("Unchecked", func(b *testing.B) {
+		run(b, false)
+	})
+}
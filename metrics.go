@@ -0,0 +1,70 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// ResponseMetrics is the server-side cost of one query as Dgraph reports it
+// in the response: the time spent in each stage of the query and the number
+// of UIDs each predicate touched. Both the embedded engine and a remote
+// cluster report it; it does not include the client's own network or
+// decoding time.
+type ResponseMetrics struct {
+	Parsing         time.Duration
+	Processing      time.Duration
+	Encoding        time.Duration
+	AssignTimestamp time.Duration
+	Total           time.Duration
+
+	// NumUIDs is the number of UIDs processed, by predicate.
+	NumUIDs map[string]uint64
+}
+
+// newResponseMetrics reads the latency and metrics of resp.
+func newResponseMetrics(resp *api.Response) ResponseMetrics {
+	l := resp.GetLatency()
+	return ResponseMetrics{
+		Parsing:         time.Duration(l.GetParsingNs()),
+		Processing:      time.Duration(l.GetProcessingNs()),
+		Encoding:        time.Duration(l.GetEncodingNs()),
+		AssignTimestamp: time.Duration(l.GetAssignTimestampNs()),
+		Total:           time.Duration(l.GetTotalNs()),
+		NumUIDs:         maps.Clone(resp.GetMetrics().GetNumUids()),
+	}
+}
+
+// QueryRawWithMetrics runs a raw query like QueryRaw and also returns the
+// server's metrics for it. It always runs the query: the WithQueryCache cache
+// holds no metrics, so it is neither read nor filled.
+func (c client) QueryRawWithMetrics(ctx context.Context, q string,
+	vars map[string]string) ([]byte, ResponseMetrics, error) {
+	resp, err := c.queryResponse(ctx, q, vars)
+	if err != nil {
+		return nil, ResponseMetrics{}, err
+	}
+	return resp.GetJson(), newResponseMetrics(resp), nil
+}
+
+// queryResponse runs a raw query in a read-only transaction and returns the
+// full response.
+func (c client) queryResponse(ctx context.Context, q string, vars map[string]string) (*api.Response, error) {
+	client, err := c.pool.get()
+	if err != nil {
+		c.logger.Error(err, "Failed to get client from pool")
+		return nil, err
+	}
+	defer c.pool.put(client)
+
+	txn := dg.NewReadOnlyTxnContext(ctx, client)
+	return txn.Txn().QueryWithVars(ctx, q, vars)
+}
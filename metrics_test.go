@@ -0,0 +1,61 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientQueryRawWithMetrics(t *testing.T) {
+
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "QueryRawWithMetricsWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "QueryRawWithMetricsWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			for _, name := range []string{"First", "Second", "Third"} {
+				require.NoError(t, client.Insert(ctx, &TestEntity{Name: name}), "Insert should succeed")
+			}
+
+			const query = `query q($name: string) { q(func: type(TestEntity)) @filter(NOT eq(name, $name)) { name } }`
+			data, metrics, err := client.QueryRawWithMetrics(ctx, query, map[string]string{"$name": "Second"})
+			require.NoError(t, err, "QueryRawWithMetrics should succeed")
+			require.JSONEq(t, `{"q": [{"name": "First"}, {"name": "Third"}]}`, string(data))
+
+			require.Positive(t, metrics.Total, "Total latency should be reported")
+			require.Positive(t, metrics.Parsing, "Parsing latency should be reported")
+			require.Positive(t, metrics.Processing, "Processing latency should be reported")
+			require.GreaterOrEqual(t, metrics.Total, metrics.Processing, "Total should cover processing")
+			require.Positive(t, metrics.NumUIDs["name"], "UID counts should be reported by predicate")
+		})
+	}
+}
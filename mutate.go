@@ -11,7 +11,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -24,8 +26,9 @@ func parseNamespaceID(ns string) (uint64, error) {
 }
 
 // checkObject validates the passed obj. If it's a slice or a pointer
-// to a slice, it returns the first element of the slice. Ultimately,
-// the object discovered must be pointer.
+// to a slice, it returns the first element of the slice, addressed if the
+// slice holds struct values. Ultimately, the object discovered must be
+// pointer.
 func checkObject(obj any) (any, error) {
 	val := reflect.ValueOf(obj)
 
@@ -35,8 +38,13 @@ func checkObject(obj any) (any, error) {
 		}
 
 		firstElem := val.Index(0)
+		// Struct elements are addressed in place: the slice's backing array
+		// is shared with the caller, so assigned UIDs reach its elements.
+		if firstElem.Kind() == reflect.Struct {
+			return firstElem.Addr().Interface(), nil
+		}
 		if firstElem.Kind() != reflect.Ptr {
-			return nil, errors.New("slice elements must be pointers")
+			return nil, errors.New("slice elements must be pointers or structs")
 		}
 
 		return firstElem.Interface(), nil
@@ -59,16 +67,33 @@ func checkObject(obj any) (any, error) {
 func (c client) process(ctx context.Context,
 	obj any, operation string,
 	txFunc func(*dg.TxnContext, any) ([]string, error)) error {
+	return c.processTxn(ctx, obj, operation, false, txFunc)
+}
+
+// processTxn is process for a txFunc that issues several mutations
+// (multiMutation), whose transaction must commit once they have all run
+// rather than with the first.
+func (c client) processTxn(ctx context.Context,
+	obj any, operation string, multiMutation bool,
+	txFunc func(*dg.TxnContext, any) ([]string, error)) error {
 
 	schemaObj, err := checkObject(obj)
 	if err != nil {
 		return err
 	}
-	if c.options.autoSchema {
+	c.names.learn(schemaObj)
+	overrides := presetDTypes(obj)
+	// Dgraph rejects schema changes to predicates a pending transaction has
+	// written, so operations in a PendingTxn only check the schema exists.
+	pending := pendingTxnFrom(ctx)
+	if c.options.autoSchema && pending == nil {
 		err := c.UpdateSchema(ctx, schemaObj)
 		if err != nil {
 			return err
 		}
+		if err := c.declareDTypes(ctx, overrides); err != nil {
+			return err
+		}
 	} else {
 		// When AutoSchema is disabled, check schema consistency
 		currentSchema, err := c.GetSchema(ctx)
@@ -90,25 +115,36 @@ func (c client) process(ctx context.Context,
 		}
 	}
 
-	client, err := c.pool.get()
-	if err != nil {
-		c.logger.Error(err, "Failed to get client from pool")
-		return err
-	}
-	defer c.pool.put(client)
-
 	provider := c.options.embeddingProvider
-	hasEmbedding := provider != nil && hasSimStringFields(obj)
+	hasEmbedding := c.embeds(obj)
+
+	deferCommit := hasEmbedding || len(overrides) > 0 || multiMutation
 
 	var tx *dg.TxnContext
-	if hasEmbedding {
-		// Do not use SetCommitNow: we need to inject shadow vectors before committing.
-		tx = dg.NewTxnContext(ctx, client)
-		// Discard is a no-op after a successful Commit but ensures resources are
-		// cleaned up on all paths (error returns, panics, etc.).
-		defer func() { _ = tx.Txn().Discard(ctx) }()
+	if pending != nil {
+		// The caller commits through the PendingTxn (DeferCommit).
+		if tx, err = pending.join(ctx, c.pool); err != nil {
+			return err
+		}
+		deferCommit = false
 	} else {
-		tx = dg.NewTxnContext(ctx, client).SetCommitNow()
+		client, err := c.pool.get()
+		if err != nil {
+			c.logger.Error(err, "Failed to get client from pool")
+			return err
+		}
+		defer c.pool.put(client)
+
+		if deferCommit {
+			// Do not use SetCommitNow: we need to inject shadow vectors and preset
+			// types, or run every mutation of txFunc, before committing.
+			tx = dg.NewTxnContext(ctx, client)
+			// Discard is a no-op after a successful Commit but ensures resources are
+			// cleaned up on all paths (error returns, panics, etc.).
+			defer func() { _ = tx.Txn().Discard(ctx) }()
+		} else {
+			tx = dg.NewTxnContext(ctx, client).SetCommitNow()
+		}
 	}
 
 	uids, err := txFunc(tx, obj)
@@ -124,8 +160,15 @@ func (c client) process(ctx context.Context,
 		if err := injectShadowVectors(ctx, provider, tx, obj, uids); err != nil {
 			return fmt.Errorf("injecting shadow vectors: %w", err)
 		}
+	}
+	if len(overrides) > 0 {
+		if err := applyDTypeOverrides(ctx, tx, overrides); err != nil {
+			return fmt.Errorf("applying preset dgraph.type: %w", err)
+		}
+	}
+	if deferCommit {
 		if err := tx.Txn().Commit(ctx); err != nil {
-			return fmt.Errorf("committing transaction with shadow vectors: %w", err)
+			return fmt.Errorf("committing transaction: %w", err)
 		}
 	}
 
@@ -133,6 +176,12 @@ func (c client) process(ctx context.Context,
 	return nil
 }
 
+// embeds reports whether obj carries SimString fields the configured
+// EmbeddingProvider must embed before the mutation commits.
+func (c client) embeds(obj any) bool {
+	return c.options.embeddingProvider != nil && hasSimStringFields(obj)
+}
+
 func generateUniquePredicateQuery(predicates map[string]interface{}, nodeType string) (string, map[string]string) {
 	var queryBuf bytes.Buffer
 	vars := make(map[string]string)
@@ -140,7 +189,8 @@ func generateUniquePredicateQuery(predicates map[string]interface{}, nodeType st
 	// Build variable declarations and OR conditions
 	varDecls := make([]string, 0, len(predicates))
 	conditions := make([]string, 0, len(predicates))
-	for key, val := range predicates {
+	for _, key := range slices.Sorted(maps.Keys(predicates)) {
+		val := predicates[key]
 		varType := "string"
 		switch val.(type) {
 		case int, int32, int64, float32, float64:
@@ -184,6 +234,70 @@ func getUIDValue(obj any) string {
 	return v.FieldByName("UID").String()
 }
 
+// prefixBlankNodes rewrites every blank node UID ("_:name") in obj, on the
+// nodes themselves and on the nodes reached through their edges, to
+// "_:<prefix>name", including names that already begin with prefix. The
+// returned restore puts back the names it rewrote that still hold the
+// prefixed name, so an insert that fails can be retried without prefixing
+// twice. Each node is visited once, so cyclic graphs terminate.
+func prefixBlankNodes(obj any, prefix string) (restore func()) {
+	type rewrite struct {
+		field    reflect.Value
+		original string
+	}
+	var rewritten []rewrite
+	restore = func() {
+		for _, r := range rewritten {
+			if r.field.String() == "_:"+prefix+strings.TrimPrefix(r.original, "_:") {
+				r.field.SetString(r.original)
+			}
+		}
+	}
+	if prefix == "" {
+		return restore
+	}
+	seen := make(map[uintptr]bool)
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			if v.IsNil() {
+				return
+			}
+			if v.Kind() == reflect.Pointer {
+				if seen[v.Pointer()] {
+					return
+				}
+				seen[v.Pointer()] = true
+			}
+			walk(v.Elem())
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				field := v.Type().Field(i)
+				if !field.IsExported() {
+					continue
+				}
+				f := v.Field(i)
+				name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+				if name == "uid" && f.Kind() == reflect.String && f.CanSet() {
+					if blank, ok := strings.CutPrefix(f.String(), "_:"); ok {
+						rewritten = append(rewritten, rewrite{field: f, original: f.String()})
+						f.SetString("_:" + prefix + blank)
+					}
+					continue
+				}
+				walk(f)
+			}
+		}
+	}
+	walk(reflect.ValueOf(obj))
+	return restore
+}
+
 func extractUIDFromDgraphQueryResult(resp []byte) (string, error) {
 	var result map[string]interface{}
 	if err := json.Unmarshal(resp, &result); err != nil {
@@ -31,6 +31,13 @@ func (ns *Namespace) DropData(ctx context.Context) error {
 	return ns.engine.dropData(ctx, ns)
 }
 
+// DropSchema drops the predicate and type definitions of this namespace,
+// along with the data stored under those predicates, while the engine stays
+// open. Reserved dgraph.* definitions are kept.
+func (ns *Namespace) DropSchema(ctx context.Context) error {
+	return ns.engine.dropSchema(ctx, ns)
+}
+
 func (ns *Namespace) AlterSchema(ctx context.Context, sch string) error {
 	return ns.engine.alterSchema(ctx, ns, sch)
 }
@@ -7,6 +7,7 @@ package modusgraph_test
 
 import (
 	"context"
+	"strconv"
 	"testing"
 
 	"github.com/dgraph-io/dgo/v250/protos/api"
@@ -89,6 +90,73 @@ func TestDropDataNamespace(t *testing.T) {
 	require.JSONEq(t, `{"me":[]}`, string(resp.GetJson()))
 }
 
+func TestDropSchemaNamespace(t *testing.T) {
+	engine, err := modusgraph.NewEngine(modusgraph.NewDefaultConfig(t.TempDir()))
+	require.NoError(t, err)
+	defer engine.Close()
+
+	ns1, err := engine.CreateNamespace()
+	require.NoError(t, err)
+	ns2, err := engine.CreateNamespace()
+	require.NoError(t, err)
+
+	sch := `
+		name: string @index(exact) .
+		age: int .
+		type Person {
+			name
+			age
+		}`
+	require.NoError(t, ns1.AlterSchema(context.Background(), sch))
+	require.NoError(t, ns2.AlterSchema(context.Background(), sch))
+
+	// Predicates are listed only when asked for by name; types by schema {}.
+	predQuery := `schema(pred: [name, age]) { type }`
+	typeQuery := `schema {}`
+	schemaOf := func(ns *modusgraph.Namespace) string {
+		preds, err := ns.Query(context.Background(), predQuery)
+		require.NoError(t, err)
+		types, err := ns.Query(context.Background(), typeQuery)
+		require.NoError(t, err)
+		return string(preds.GetJson()) + string(types.GetJson())
+	}
+
+	sch1 := schemaOf(ns1)
+	require.Contains(t, sch1, `"predicate":"name"`)
+	require.Contains(t, sch1, `"predicate":"age"`)
+	require.Contains(t, sch1, `"name":"Person"`)
+
+	require.NoError(t, ns1.DropSchema(context.Background()))
+
+	sch1 = schemaOf(ns1)
+	require.NotContains(t, sch1, `"predicate":"name"`)
+	require.NotContains(t, sch1, `"predicate":"age"`)
+	require.NotContains(t, sch1, `"name":"Person"`)
+	require.Contains(t, sch1, `"name":"dgraph.graphql"`, "reserved types are kept")
+
+	sch2 := schemaOf(ns2)
+	require.Contains(t, sch2, `"predicate":"name"`, "other namespaces keep their schema")
+	require.Contains(t, sch2, `"name":"Person"`)
+
+	// The engine stays open, so the namespace can take a fresh schema.
+	require.NoError(t, ns1.AlterSchema(context.Background(), "name: string @index(term) ."))
+	_, err = ns1.Mutate(context.Background(), []*api.Mutation{
+		{
+			Set: []*api.NQuad{
+				{
+					Subject:     "_:aman",
+					Predicate:   "name",
+					ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: "A B"}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	resp, err := ns1.Query(context.Background(), `{ me(func: anyofterms(name, "B")) { name } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"me":[{"name":"A B"}]}`, string(resp.GetJson()))
+}
+
 func TestMultipleDBs(t *testing.T) {
 	engine, err := modusgraph.NewEngine(modusgraph.NewDefaultConfig(t.TempDir()))
 	require.NoError(t, err)
@@ -361,3 +429,62 @@ func TestDBDBRestart(t *testing.T) {
 	require.NoError(t, err)
 	require.JSONEq(t, `{"me":[{"bar":"B"}]}`, string(resp.GetJson()))
 }
+
+type TenantAccount struct {
+	UID   string   `json:"uid,omitempty"`
+	Email string   `json:"tenant_email,omitempty" dgraph:"index=exact unique"`
+	Plan  string   `json:"tenant_plan,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+func TestCreateNamespaceWithSchema(t *testing.T) {
+	ctx := context.Background()
+	dataDir := t.TempDir()
+	engine, err := modusgraph.NewEngine(modusgraph.NewDefaultConfig(dataDir))
+	require.NoError(t, err)
+	defer func() { engine.Close() }()
+
+	ns, err := engine.CreateNamespaceWithSchema(ctx, TenantAccount{})
+	require.NoError(t, err)
+
+	in, err := ns.Introspect(ctx)
+	require.NoError(t, err)
+	email, ok := in.Predicate("tenant_email")
+	require.True(t, ok, "The namespace should have the model's predicates at once")
+	require.Equal(t, []string{"exact"}, email.Tokenizers)
+	require.True(t, email.Unique)
+	_, ok = in.Predicate("tenant_plan")
+	require.True(t, ok)
+	typ, ok := in.Type("TenantAccount")
+	require.True(t, ok, "The namespace should have the model's type")
+	require.Contains(t, typ.Fields, "tenant_email")
+
+	other, err := engine.GetDefaultNamespace().Introspect(ctx)
+	require.NoError(t, err)
+	_, ok = other.Predicate("tenant_email")
+	require.False(t, ok, "Other namespaces should not get the schema")
+
+	// The index is ready for the first query
+	_, err = ns.Mutate(ctx, []*api.Mutation{{
+		SetNquads: []byte(`_:a <tenant_email> "a@example.com" .
+_:a <dgraph.type> "TenantAccount" .`),
+	}})
+	require.NoError(t, err)
+	resp, err := ns.Query(ctx, `{ q(func: eq(tenant_email, "a@example.com")) { tenant_email } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"q":[{"tenant_email":"a@example.com"}]}`, string(resp.GetJson()))
+
+	// A client on the namespace reports the schema too
+	nsID := ns.ID()
+	engine.Close()
+	client, err := modusgraph.NewClient("file://"+dataDir, modusgraph.WithNamespace(strconv.FormatUint(nsID, 10)))
+	require.NoError(t, err)
+	defer func() {
+		client.Close()
+		modusgraph.Shutdown()
+	}()
+	sch, err := client.GetSchema(ctx)
+	require.NoError(t, err)
+	require.Contains(t, sch, "tenant_email")
+	require.Contains(t, sch, "type TenantAccount")
+}
@@ -0,0 +1,483 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	dg "github.com/dolan-in/dgman/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// NamingStrategy is a convention for predicate names, from which
+// WithNamingStrategy derives the predicates a client's models are stored
+// under.
+type NamingStrategy int
+
+const (
+	// NamingAsIs keeps every predicate name. It is the default.
+	NamingAsIs NamingStrategy = iota
+	// NamingSnakeCase joins lower-case words by underscores, such as
+	// workspace_id.
+	NamingSnakeCase
+	// NamingCamelCase joins words with each after the first capitalized,
+	// such as workspaceId.
+	NamingCamelCase
+)
+
+// String returns the strategy's name.
+func (s NamingStrategy) String() string {
+	switch s {
+	case NamingAsIs:
+		return "AsIs"
+	case NamingSnakeCase:
+		return "snake_case"
+	case NamingCamelCase:
+		return "camelCase"
+	}
+	return fmt.Sprintf("NamingStrategy(%d)", int(s))
+}
+
+// Convert returns name rewritten to follow s. In a dotted name, such as
+// Person.firstName, only the part after the last dot is rewritten, the part
+// before it naming a type. A run of capitals is read as one word, so
+// workspaceID becomes workspace_id, and workspace_id becomes workspaceId;
+// camelCase keeps the capitals of such a word after the first.
+func (s NamingStrategy) Convert(name string) string {
+	if s != NamingSnakeCase && s != NamingCamelCase {
+		return name
+	}
+	dot := strings.LastIndex(name, ".") + 1
+	words := splitWords(name[dot:])
+	for i, w := range words {
+		switch {
+		case s == NamingSnakeCase || i == 0:
+			w = strings.ToLower(w)
+		case strings.ToUpper(w) != w:
+			r := []rune(strings.ToLower(w))
+			r[0] = unicode.ToUpper(r[0])
+			w = string(r)
+		}
+		words[i] = w
+	}
+	sep := ""
+	if s == NamingSnakeCase {
+		sep = "_"
+	}
+	return name[:dot] + strings.Join(words, sep)
+}
+
+// splitWords splits name at underscores, at each lower-case letter or digit
+// followed by a capital, and before the last capital of a run followed by a
+// lower-case letter.
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i, r := range runes {
+		if r == '_' {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+		if i == start || !unicode.IsUpper(r) {
+			continue
+		}
+		prev := runes[i-1]
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+			(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// derive returns the predicate s stores name under. Like Convert, but the
+// predicates Dgraph and dgman name (uid, dgraph.type, and the like) and
+// wildcards keep their name, and only the predicate of a reverse edge
+// (~name), a facet or language key (name|facet, name@lang), or an embedding's
+// shadow vector (name__vec) is rewritten.
+func (s NamingStrategy) derive(name string) string {
+	if s == NamingAsIs || name == "" || name == "uid" || strings.HasPrefix(name, "dgraph.") ||
+		strings.HasPrefix(name, "_") {
+		return name
+	}
+	if inner, ok := strings.CutPrefix(name, "<"); ok && strings.HasSuffix(inner, ">") {
+		return "<" + s.derive(inner[:len(inner)-1]) + ">"
+	}
+	if rest, ok := strings.CutPrefix(name, "~"); ok {
+		return "~" + s.derive(rest)
+	}
+	if i := strings.IndexAny(name, "|@"); i >= 0 {
+		return s.derive(name[:i]) + name[i:]
+	}
+	if base, ok := strings.CutSuffix(name, "__vec"); ok {
+		return s.derive(base) + "__vec"
+	}
+	return s.Convert(name)
+}
+
+// deriveTypeSchema renames the predicates of ts, and the fields of its types,
+// to the names s derives.
+func (s NamingStrategy) deriveTypeSchema(ts *dg.TypeSchema) {
+	if s == NamingAsIs {
+		return
+	}
+	schema := make(dg.SchemaMap, len(ts.Schema))
+	for pred, sc := range ts.Schema {
+		sc.Predicate = s.derive(sc.Predicate)
+		if sc.ForwardPredicate != "" {
+			sc.ForwardPredicate = s.derive(sc.ForwardPredicate)
+		}
+		schema[s.derive(pred)] = sc
+	}
+	ts.Schema = schema
+	for name, fields := range ts.Types {
+		derived := make(dg.SchemaMap, len(fields))
+		for pred, sc := range fields {
+			derived[s.derive(pred)] = sc
+		}
+		ts.Types[name] = derived
+	}
+}
+
+// predicateNames translates between the predicate names a client's models
+// put on the wire, their json names or predicate= names, and the names its
+// NamingStrategy stores them under. Mutations are rewritten to the derived
+// names. Queries name predicates in DQL text, where a name cannot be told
+// from a keyword, alias, or type, so only the wire names of the models the
+// client has seen are rewritten there; results carry each derived name's
+// wire names alongside it, so they decode into the models.
+type predicateNames struct {
+	strategy NamingStrategy
+	altTag   string
+
+	mu      sync.RWMutex
+	derived map[string]string   // wire name -> derived name, where they differ
+	wire    map[string][]string // derived name -> the wire names deriving it
+
+	learned sync.Map // reflect.Type -> struct{}, the models already recorded
+}
+
+// newPredicateNames returns the predicateNames of a client deriving names by
+// strategy, reading directives from the dgraph tag and altTag, or nil for
+// NamingAsIs.
+func newPredicateNames(strategy NamingStrategy, altTag string) *predicateNames {
+	if strategy == NamingAsIs {
+		return nil
+	}
+	return &predicateNames{
+		strategy: strategy,
+		altTag:   altTag,
+		derived:  make(map[string]string),
+		wire:     make(map[string][]string),
+	}
+}
+
+// derive returns the predicate name is stored under; a nil n derives none.
+func (n *predicateNames) derive(name string) string {
+	if n == nil {
+		return name
+	}
+	return n.strategy.derive(name)
+}
+
+// learn records the predicates of models and of the edge types they
+// reference. Each type is walked once.
+func (n *predicateNames) learn(models ...any) {
+	if n == nil {
+		return
+	}
+	for _, model := range models {
+		t := reflect.TypeOf(model)
+		if t == nil {
+			continue
+		}
+		if _, ok := n.learned.Load(t); ok {
+			continue
+		}
+		walkFields(func(field reflect.StructField) {
+			if !field.IsExported() || field.Tag.Get("json") == "-" || isComputedField(field) {
+				return
+			}
+			n.record(predicateName(field, fieldDirectives(field, n.altTag)))
+		}, model)
+		n.learned.Store(t, struct{}{})
+	}
+}
+
+// record notes that the wire name pred is stored under its derived name.
+func (n *predicateNames) record(pred string) {
+	if strings.ContainsAny(pred, "~@|<") {
+		return
+	}
+	derived := n.strategy.derive(pred)
+	if derived == pred {
+		return
+	}
+	n.mu.RLock()
+	_, ok := n.derived[pred]
+	n.mu.RUnlock()
+	if ok {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.derived[pred]; !ok {
+		n.derived[pred] = derived
+		n.wire[derived] = append(n.wire[derived], pred)
+	}
+}
+
+// request returns in with its mutations and query rewritten to the derived
+// names. in itself is left as it is.
+func (n *predicateNames) request(in *api.Request) (*api.Request, error) {
+	out := proto.Clone(in).(*api.Request)
+	for _, mu := range out.Mutations {
+		var err error
+		if mu.SetJson, err = n.mutationJSON(mu.SetJson); err != nil {
+			return nil, err
+		}
+		if mu.DeleteJson, err = n.mutationJSON(mu.DeleteJson); err != nil {
+			return nil, err
+		}
+		for _, nq := range mu.Set {
+			nq.Predicate = n.derive(nq.Predicate)
+		}
+		for _, nq := range mu.Del {
+			nq.Predicate = n.derive(nq.Predicate)
+		}
+		mu.Cond = n.query(mu.Cond)
+	}
+	// The mutations are rewritten first so the query of an upsert finds
+	// the names they record.
+	out.Query = n.query(out.Query)
+	return out, nil
+}
+
+// mutationJSON rewrites the keys of a JSON mutation to the derived names,
+// recording each.
+func (n *predicateNames) mutationJSON(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+	v, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return encodeJSON(n.deriveKeys(v))
+}
+
+func (n *predicateNames) deriveKeys(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			n.record(key)
+			out[n.derive(key)] = n.deriveKeys(value)
+		}
+		return out
+	case []any:
+		for i := range v {
+			v[i] = n.deriveKeys(v[i])
+		}
+	}
+	return v
+}
+
+// query rewrites the wire names the client has recorded in the DQL text q to
+// their derived names. String literals, variables, and names before a colon,
+// which are aliases and arguments, are left alone, as is the type a type()
+// function names.
+func (n *predicateNames) query(q string) string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if q == "" || len(n.derived) == 0 {
+		return q
+	}
+	var b strings.Builder
+	for i := 0; i < len(q); {
+		switch c := q[i]; {
+		case c == '"':
+			j := i + 1
+			for j < len(q) && q[j] != '"' {
+				if q[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			j = min(j+1, len(q))
+			b.WriteString(q[i:j])
+			i = j
+		case c == '$' || isNameByte(c):
+			j := i + 1
+			for j < len(q) && isNameByte(q[j]) {
+				j++
+			}
+			name := q[i:j]
+			if c != '$' && !strings.HasSuffix(strings.TrimRight(b.String(), " \t\n"), "type(") &&
+				!strings.HasPrefix(strings.TrimLeft(q[j:], " \t\n"), ":") {
+				name = n.derivedLocked(name)
+			}
+			b.WriteString(name)
+			i = j
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// derivedLocked returns the derived name of the recorded wire name, or name
+// itself. n.mu is held.
+func (n *predicateNames) derivedLocked(name string) string {
+	if derived, ok := n.derived[name]; ok {
+		return derived
+	}
+	if base, ok := strings.CutSuffix(name, "__vec"); ok {
+		if derived, ok := n.derived[base]; ok {
+			return derived + "__vec"
+		}
+	}
+	return name
+}
+
+// isNameByte reports whether c can be part of a DQL name.
+func isNameByte(c byte) bool {
+	return c == '_' || c == '.' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// response adds to the objects of resp's JSON, beside each key holding a
+// derived name, the wire names deriving it.
+func (n *predicateNames) response(resp *api.Response) error {
+	n.mu.RLock()
+	recorded := len(n.wire) > 0
+	n.mu.RUnlock()
+	if resp == nil || len(resp.Json) == 0 || !recorded {
+		return nil
+	}
+	v, err := decodeJSON(resp.Json)
+	if err != nil {
+		return err
+	}
+	n.mu.RLock()
+	n.addWireKeys(v)
+	n.mu.RUnlock()
+	resp.Json, err = encodeJSON(v)
+	return err
+}
+
+// addWireKeys adds the wire keys to the objects of v. n.mu is held.
+func (n *predicateNames) addWireKeys(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		added := make(map[string]any)
+		for key, value := range v {
+			n.addWireKeys(value)
+			prefix, pred, suffix := splitPredicateKey(key)
+			for _, wire := range n.wire[pred] {
+				added[prefix+wire+suffix] = value
+			}
+		}
+		for key, value := range added {
+			if _, ok := v[key]; !ok {
+				v[key] = value
+			}
+		}
+	case []any:
+		for _, elem := range v {
+			n.addWireKeys(elem)
+		}
+	}
+}
+
+// splitPredicateKey splits a result key into the predicate it names and the
+// reverse prefix and facet or language suffix around it.
+func splitPredicateKey(key string) (prefix, pred, suffix string) {
+	if rest, ok := strings.CutPrefix(key, "~"); ok {
+		prefix, key = "~", rest
+	}
+	if i := strings.IndexAny(key, "|@"); i >= 0 {
+		return prefix, key[:i], key[i:]
+	}
+	return prefix, key, ""
+}
+
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func encodeJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// unaryInterceptor applies n to the queries and mutations of a remote
+// client's gRPC connection.
+func (n *predicateNames) unaryInterceptor(ctx context.Context, method string, req, reply any,
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	in, ok := req.(*api.Request)
+	if method != api.Dgraph_Query_FullMethodName || !ok {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	in, err := n.request(in)
+	if err != nil {
+		return err
+	}
+	if err := invoker(ctx, method, in, reply, cc, opts...); err != nil {
+		return err
+	}
+	resp, _ := reply.(*api.Response)
+	return n.response(resp)
+}
+
+// namingDgraphClient applies names to the queries and mutations of an
+// embedded client.
+type namingDgraphClient struct {
+	api.DgraphClient
+	names *predicateNames
+}
+
+func (c namingDgraphClient) Query(ctx context.Context, in *api.Request,
+	opts ...grpc.CallOption) (*api.Response, error) {
+	in, err := c.names.request(in)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.DgraphClient.Query(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, c.names.response(resp)
+}
@@ -0,0 +1,131 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+// NamedClerk has camelCase json names, which a NamingSnakeCase client stores
+// as snake_case predicates.
+type NamedClerk struct {
+	UID           string      `json:"uid,omitempty"`
+	ClerkID       string      `json:"clerkID,omitempty" dgraph:"index=exact upsert"`
+	WorkspaceName string      `json:"workspaceName,omitempty"`
+	ReportsTo     *NamedClerk `json:"reportsTo,omitempty"`
+	DType         []string    `json:"dgraph.type,omitempty"`
+}
+
+// SnakeClerk has snake_case json names, which a NamingCamelCase client stores
+// as camelCase predicates.
+type SnakeClerk struct {
+	UID       string   `json:"uid,omitempty"`
+	ClerkID   string   `json:"clerk_id,omitempty" dgraph:"index=exact"`
+	DeskLabel string   `json:"desk_label,omitempty" dgraph:"predicate=desk_name"`
+	DType     []string `json:"dgraph.type,omitempty"`
+}
+
+func TestNamingStrategyConvert(t *testing.T) {
+	tests := []struct {
+		name, snake, camel string
+	}{
+		{"clerk_id", "clerk_id", "clerkId"},
+		{"workspaceID", "workspace_id", "workspaceID"},
+		{"HTTPServer", "http_server", "httpServer"},
+		{"ownerID2", "owner_id2", "ownerID2"},
+		{"Person.firstName", "Person.first_name", "Person.firstName"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.snake, modusgraph.NamingSnakeCase.Convert(tt.name), tt.name)
+		require.Equal(t, tt.camel, modusgraph.NamingCamelCase.Convert(tt.name), tt.name)
+		require.Equal(t, tt.name, modusgraph.NamingAsIs.Convert(tt.name), tt.name)
+	}
+}
+
+func TestClientWithNamingStrategy(t *testing.T) {
+	ctx := context.Background()
+	client, err := modusgraph.NewClient("file://"+GetTempDir(t), modusgraph.WithAutoSchema(true),
+		modusgraph.WithNamingStrategy(modusgraph.NamingSnakeCase))
+	require.NoError(t, err)
+	defer func() {
+		client.Close()
+		modusgraph.Shutdown()
+	}()
+
+	require.NoError(t, client.UpdateSchema(ctx, NamedClerk{}))
+	in, err := client.Introspect(ctx)
+	require.NoError(t, err)
+	for _, pred := range []string{"clerk_id", "workspace_name", "reports_to"} {
+		_, ok := in.Predicate(pred)
+		require.True(t, ok, "Predicate %s should be declared", pred)
+	}
+	for _, pred := range []string{"clerkID", "workspaceName", "reportsTo"} {
+		_, ok := in.Predicate(pred)
+		require.False(t, ok, "No predicate should take the json name %s", pred)
+	}
+
+	manager := &NamedClerk{ClerkID: "m1", WorkspaceName: "eng"}
+	clerk := &NamedClerk{ClerkID: "c1", WorkspaceName: "eng", ReportsTo: manager}
+	require.NoError(t, client.Insert(ctx, clerk))
+
+	var got NamedClerk
+	require.NoError(t, client.Get(ctx, &got, clerk.UID))
+	require.Equal(t, "c1", got.ClerkID)
+	require.Equal(t, "eng", got.WorkspaceName)
+	require.NotNil(t, got.ReportsTo, "The edge should be read under its derived name")
+	require.Equal(t, "m1", got.ReportsTo.ClerkID)
+
+	// A filter may name the predicate by its json or its derived name.
+	for _, filter := range []string{`eq(clerkID, "c1")`, `eq(clerk_id, "c1")`} {
+		var found []NamedClerk
+		require.NoError(t, client.Query(ctx, NamedClerk{}).Filter(filter).Nodes(&found), filter)
+		require.Len(t, found, 1, filter)
+		require.Equal(t, "eng", found[0].WorkspaceName, filter)
+	}
+
+	// The stored data uses the derived names.
+	raw, err := client.QueryRaw(ctx, `{ q(func: eq(clerk_id, "c1")) { workspace_name } }`, nil)
+	require.NoError(t, err)
+	require.Contains(t, string(raw), `"workspace_name":"eng"`)
+
+	// Upsert finds the node by its upsert predicate.
+	require.NoError(t, client.Upsert(ctx, &NamedClerk{ClerkID: "c1", WorkspaceName: "ops"}))
+	var all []NamedClerk
+	require.NoError(t, client.Query(ctx, NamedClerk{}).Nodes(&all))
+	require.Len(t, all, 2)
+	got = NamedClerk{}
+	require.NoError(t, client.Get(ctx, &got, clerk.UID))
+	require.Equal(t, "ops", got.WorkspaceName)
+}
+
+func TestClientWithCamelCaseNamingStrategy(t *testing.T) {
+	ctx := context.Background()
+	client, err := modusgraph.NewClient("file://"+GetTempDir(t), modusgraph.WithAutoSchema(true),
+		modusgraph.WithNamingStrategy(modusgraph.NamingCamelCase))
+	require.NoError(t, err)
+	defer func() {
+		client.Close()
+		modusgraph.Shutdown()
+	}()
+
+	clerk := &SnakeClerk{ClerkID: "c1", DeskLabel: "north"}
+	require.NoError(t, client.Insert(ctx, clerk))
+	in, err := client.Introspect(ctx)
+	require.NoError(t, err)
+	for _, pred := range []string{"clerkId", "deskName"} {
+		_, ok := in.Predicate(pred)
+		require.True(t, ok, "Predicate %s should be declared", pred)
+	}
+
+	var got SnakeClerk
+	require.NoError(t, client.Get(ctx, &got, clerk.UID))
+	require.Equal(t, "c1", got.ClerkID)
+	require.Equal(t, "north", got.DeskLabel, "A predicate= name should be derived too")
+}
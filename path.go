@@ -0,0 +1,98 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ShortestPath returns the UIDs of the nodes on a shortest path from the node
+// from to the node to along edge, both ends included, using Dgraph's shortest
+// path query. edge may be a managed reverse edge given with its leading "~".
+// maxDepth caps the number of edges in the path; zero or less leaves it
+// unbounded. It returns an empty path, and no error, when to cannot be reached.
+func (c client) ShortestPath(ctx context.Context, from, to string, edge string,
+	maxDepth int) ([]string, error) {
+	fromUID, err := strconv.ParseUint(from, 0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("ShortestPath: invalid from UID %q", from)
+	}
+	toUID, err := strconv.ParseUint(to, 0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("ShortestPath: invalid to UID %q", to)
+	}
+	pred := edge
+	if len(pred) > 1 && pred[0] == '~' {
+		pred = pred[1:]
+	}
+	if !isValidPredicateName(pred) {
+		return nil, fmt.Errorf("ShortestPath: invalid edge %q", edge)
+	}
+
+	args := fmt.Sprintf("from: 0x%x, to: 0x%x", fromUID, toUID)
+	if maxDepth > 0 {
+		args += fmt.Sprintf(", depth: %d", maxDepth)
+	}
+	// Dgraph rejects a query variable that no block uses, so the path block
+	// reads the path's nodes back; their order is the UID order, though, and
+	// the path itself comes from the response's _path_ entry.
+	query := fmt.Sprintf("{\n\tpath as shortest(%s) { %s }\n\tpath(func: uid(path)) { uid }\n}", args, edge)
+
+	data, err := c.queryRaw(ctx, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	path, err := parseShortestPath(data, edge)
+	if err != nil {
+		return nil, fmt.Errorf("ShortestPath: %w", err)
+	}
+	return path, nil
+}
+
+// parseShortestPath reads the UIDs of the first path in the _path_ entry of a
+// shortest path response. Each node of the path holds the next one under
+// edge, as a single node or a one-element list.
+func parseShortestPath(data []byte, edge string) ([]string, error) {
+	var resp struct {
+		Path []map[string]json.RawMessage `json:"_path_"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	path := []string{}
+	if len(resp.Path) == 0 {
+		return path, nil
+	}
+	node := resp.Path[0]
+	for node != nil {
+		var uid string
+		if err := json.Unmarshal(node["uid"], &uid); err != nil {
+			return nil, fmt.Errorf("decoding path node: %w", err)
+		}
+		path = append(path, uid)
+
+		raw, ok := node[edge]
+		if !ok {
+			break
+		}
+		var next []map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &next); err != nil {
+			var single map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &single); err != nil {
+				return nil, fmt.Errorf("decoding path edge: %w", err)
+			}
+			next = append(next, single)
+		}
+		node = nil
+		if len(next) > 0 {
+			node = next[0]
+		}
+	}
+	return path, nil
+}
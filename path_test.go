@@ -0,0 +1,87 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShortestPath(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "ShortestPathWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "ShortestPathWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			ctx := context.Background()
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			// Alice -> Bob -> Carol -> Dave, plus Eve, who knows nobody
+			dave := &FoafPerson{Name: "Dave"}
+			carol := &FoafPerson{Name: "Carol", Friends: []*FoafPerson{dave}}
+			bob := &FoafPerson{Name: "Bob", Friends: []*FoafPerson{carol}}
+			alice := &FoafPerson{Name: "Alice", Friends: []*FoafPerson{bob}}
+			require.NoError(t, client.Insert(ctx, alice))
+			eve := &FoafPerson{Name: "Eve"}
+			require.NoError(t, client.Insert(ctx, eve))
+
+			path, err := client.ShortestPath(ctx, alice.UID, dave.UID, "friends", 0)
+			require.NoError(t, err)
+			require.Equal(t, []string{alice.UID, bob.UID, carol.UID, dave.UID}, path,
+				"Alice should reach Dave through Bob and Carol")
+
+			// A shortcut shortens the path
+			alice.Friends = append(alice.Friends, carol)
+			require.NoError(t, client.Update(ctx, alice))
+			path, err = client.ShortestPath(ctx, alice.UID, dave.UID, "friends", 0)
+			require.NoError(t, err)
+			require.Equal(t, []string{alice.UID, carol.UID, dave.UID}, path)
+
+			// The reverse edge walks the chain backwards
+			path, err = client.ShortestPath(ctx, dave.UID, bob.UID, "~friends", 0)
+			require.NoError(t, err)
+			require.Equal(t, []string{dave.UID, carol.UID, bob.UID}, path)
+
+			path, err = client.ShortestPath(ctx, bob.UID, dave.UID, "friends", 1)
+			require.NoError(t, err)
+			require.Empty(t, path, "Dave is two edges from Bob")
+			path, err = client.ShortestPath(ctx, bob.UID, dave.UID, "friends", 2)
+			require.NoError(t, err)
+			require.Equal(t, []string{bob.UID, carol.UID, dave.UID}, path)
+
+			path, err = client.ShortestPath(ctx, alice.UID, eve.UID, "friends", 0)
+			require.NoError(t, err)
+			require.Empty(t, path, "Eve cannot be reached")
+
+			_, err = client.ShortestPath(ctx, alice.UID, dave.UID, "friends) { uid }", 0)
+			require.Error(t, err, "The edge must be a predicate name")
+			_, err = client.ShortestPath(ctx, "alice", dave.UID, "friends", 0)
+			require.Error(t, err, "The ends must be UIDs")
+		})
+	}
+}
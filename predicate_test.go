@@ -7,6 +7,7 @@ package modusgraph_test
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"testing"
 	"time"
@@ -34,6 +35,17 @@ type PredicateFilm struct {
 	DType []string `json:"dgraph.type,omitempty"`
 }
 
+// PredicateWorkspace is a test struct whose Go field name, json tag name, and
+// dgraph predicate name all differ: WorkspaceID is serialized as workspaceID
+// and stored under workspace_id.
+type PredicateWorkspace struct {
+	WorkspaceID string `json:"workspaceID,omitempty" dgraph:"predicate=workspace_id index=exact"`
+	DisplayName string `json:"displayName,omitempty" dgraph:"predicate=workspace_display_name"`
+
+	UID   string   `json:"uid,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
 // PredicateBook and PredicateAuthor test forward and reverse edges using the
 // predicate= tag. This mirrors the pattern used by modusGraphGen where:
 //   - PredicateBook has a forward edge: predicate=written_by reverse
@@ -508,3 +520,105 @@ func TestPredicateReverseEdgeQuery(t *testing.T) {
 		})
 	}
 }
+
+// TestPredicateDistinctFromJSON tests that a field whose Go name, json tag, and
+// predicate name all differ is stored under the predicate name, serialized
+// under the json tag name, and round-trips through Get, Query, and Update.
+func TestPredicateDistinctFromJSON(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "PredicateDistinctFromJSONWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "PredicateDistinctFromJSONWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+
+			workspace := PredicateWorkspace{
+				WorkspaceID: "ws-42",
+				DisplayName: "Research",
+			}
+			err := client.Insert(ctx, &workspace)
+			require.NoError(t, err, "Insert should succeed")
+			require.NotEmpty(t, workspace.UID, "UID should be assigned")
+
+			// The values are stored under the predicate names.
+			raw, err := client.QueryRaw(ctx, `{
+				q(func: uid(`+workspace.UID+`)) {
+					workspace_id
+					workspace_display_name
+				}
+			}`, nil)
+			require.NoError(t, err, "QueryRaw should succeed")
+			var stored struct {
+				Q []map[string]any `json:"q"`
+			}
+			require.NoError(t, json.Unmarshal(raw, &stored))
+			require.Len(t, stored.Q, 1)
+			assert.Equal(t, map[string]any{
+				"workspace_id":           "ws-42",
+				"workspace_display_name": "Research",
+			}, stored.Q[0], "Values should be stored under the predicate names")
+
+			// Get maps the predicate names back onto the Go fields.
+			var retrieved PredicateWorkspace
+			err = client.Get(ctx, &retrieved, workspace.UID)
+			require.NoError(t, err, "Get should succeed")
+			assert.Equal(t, "ws-42", retrieved.WorkspaceID,
+				"WorkspaceID should round-trip (predicate=workspace_id)")
+			assert.Equal(t, "Research", retrieved.DisplayName,
+				"DisplayName should round-trip (predicate=workspace_display_name)")
+
+			// JSON serialization of the retrieved struct still uses the json tags.
+			encoded, err := json.Marshal(retrieved)
+			require.NoError(t, err)
+			var asJSON map[string]any
+			require.NoError(t, json.Unmarshal(encoded, &asJSON))
+			assert.Equal(t, "ws-42", asJSON["workspaceID"])
+			assert.Equal(t, "Research", asJSON["displayName"])
+			assert.NotContains(t, asJSON, "workspace_id")
+			assert.NotContains(t, asJSON, "WorkspaceID")
+
+			// Filters reference the predicate name.
+			var results []PredicateWorkspace
+			err = client.Query(ctx, PredicateWorkspace{}).
+				Filter(`eq(workspace_id, "ws-42")`).
+				Nodes(&results)
+			require.NoError(t, err, "Query should succeed")
+			require.Len(t, results, 1, "Should find the workspace by workspace_id")
+			assert.Equal(t, "ws-42", results[0].WorkspaceID)
+
+			// Update writes back under the predicate name.
+			retrieved.WorkspaceID = "ws-43"
+			err = client.Update(ctx, &retrieved)
+			require.NoError(t, err, "Update should succeed")
+
+			var updated PredicateWorkspace
+			err = client.Get(ctx, &updated, workspace.UID)
+			require.NoError(t, err, "Get after update should succeed")
+			assert.Equal(t, "ws-43", updated.WorkspaceID,
+				"WorkspaceID should reflect the update")
+			assert.Equal(t, "Research", updated.DisplayName,
+				"DisplayName should be unchanged")
+		})
+	}
+}
@@ -9,11 +9,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"testing"
 	"time"
 
 	dg "github.com/dolan-in/dgman/v2"
+	"github.com/matthewmcneely/modusgraph"
 	"github.com/stretchr/testify/require"
 )
 
@@ -62,6 +64,350 @@ func TestClientSimpleGet(t *testing.T) {
 	}
 }
 
+func TestClientGetMap(t *testing.T) {
+
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "GetMapWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "GetMapWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			entities := []*TestEntity{
+				{Name: "Alpha", Description: "first"},
+				{Name: "Beta", Description: "second"},
+				{Name: "Gamma", Description: "third"},
+			}
+			err := client.Insert(ctx, entities)
+			require.NoError(t, err, "Insert should succeed")
+
+			uids := []string{entities[2].UID, entities[0].UID, entities[1].UID, entities[0].UID, "0xfffffff"}
+			nodes, err := client.GetMap(ctx, uids, func() any { return &TestEntity{} })
+			require.NoError(t, err, "GetMap should succeed")
+			require.Len(t, nodes, len(entities), "Duplicate and missing UIDs should not add entries")
+			for _, want := range entities {
+				got, ok := nodes[want.UID].(*TestEntity)
+				require.True(t, ok, "Node %s should be a *TestEntity", want.UID)
+				require.Equal(t, want.UID, got.UID)
+				require.Equal(t, want.Name, got.Name)
+				require.Equal(t, want.Description, got.Description)
+			}
+
+			_, err = client.GetMap(ctx, uids, func() any { return TestEntity{} })
+			require.Error(t, err, "A factory that does not return a pointer should fail")
+		})
+	}
+}
+
+func TestClientQueryInterface(t *testing.T) {
+
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "QueryInterfaceWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "QueryInterfaceWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			entities := []*TestEntity{
+				{Name: "First", Description: "first entity"},
+				{Name: "Second", Description: "second entity"},
+			}
+			err := client.Insert(ctx, entities)
+			require.NoError(t, err, "Insert should succeed")
+
+			// Decode into a generic map, as a caller without the domain struct would.
+			results, err := client.QueryInterface(ctx, "TestEntity", func() any { return &map[string]any{} })
+			require.NoError(t, err, "QueryInterface should succeed")
+			require.Len(t, results, 2)
+			names := make([]string, 0, len(results))
+			for _, r := range results {
+				m, ok := r.(*map[string]any)
+				require.True(t, ok, "result should be the factory's type")
+				require.NotEmpty(t, (*m)["uid"])
+				names = append(names, (*m)["name"].(string))
+			}
+			require.ElementsMatch(t, []string{"First", "Second"}, names)
+
+			results, err = client.QueryInterface(ctx, "TestEntity", func() any { return &TestEntity{} })
+			require.NoError(t, err)
+			require.Len(t, results, 2)
+			for _, r := range results {
+				e := r.(*TestEntity)
+				require.NotEmpty(t, e.UID)
+				require.Equal(t, []string{"TestEntity"}, e.DType)
+				require.Contains(t, []string{"first entity", "second entity"}, e.Description)
+			}
+
+			results, err = client.QueryInterface(ctx, "NoSuchType", func() any { return &TestEntity{} })
+			require.NoError(t, err)
+			require.Empty(t, results)
+
+			_, err = client.QueryInterface(ctx, "TestEntity) { uid } }", func() any { return &TestEntity{} })
+			require.Error(t, err, "a type name with DQL metacharacters should be rejected")
+
+			_, err = client.QueryInterface(ctx, "TestEntity", func() any { return TestEntity{} })
+			require.Error(t, err, "a non-pointer result should be rejected")
+		})
+	}
+}
+
+func TestClientMultiQuery(t *testing.T) {
+
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "MultiQueryWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "MultiQueryWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			entities := []*TestEntity{
+				{Name: "First", Description: "first entity"},
+				{Name: "Second", Description: "second entity"},
+				{Name: "Third", Description: "third entity"},
+			}
+			require.NoError(t, client.Insert(ctx, entities), "Insert should succeed")
+			departments := []*Department{{Name: "Sales"}, {Name: "Support"}}
+			require.NoError(t, client.Insert(ctx, departments), "Insert should succeed")
+
+			results, err := client.MultiQuery(ctx, map[string]*dg.Query{
+				"recent":      client.Query(ctx, TestEntity{}).OrderAsc("name").First(2),
+				"departments": client.Query(ctx, Department{}).Filter(`eq(dept_name, "Support")`),
+			})
+			require.NoError(t, err, "MultiQuery should succeed")
+			require.Len(t, results, 2)
+
+			var recent []TestEntity
+			require.NoError(t, json.Unmarshal(results["recent"], &recent))
+			require.Len(t, recent, 2)
+			require.Equal(t, "First", recent[0].Name)
+			require.Equal(t, "Second", recent[1].Name)
+
+			var found []Department
+			require.NoError(t, json.Unmarshal(results["departments"], &found))
+			require.Len(t, found, 1)
+			require.Equal(t, "Support", found[0].Name)
+			require.NotEmpty(t, found[0].UID)
+
+			results, err = client.MultiQuery(ctx, map[string]*dg.Query{
+				"none": client.Query(ctx, Department{}).Filter(`eq(dept_name, "Nobody")`),
+			})
+			require.NoError(t, err)
+			require.JSONEq(t, "[]", string(results["none"]), "an empty block should decode as an empty list")
+
+			_, err = client.MultiQuery(ctx, map[string]*dg.Query{
+				"bad name": client.Query(ctx, Department{}),
+			})
+			require.Error(t, err, "a block name with DQL metacharacters should be rejected")
+
+			_, err = client.MultiQuery(ctx, map[string]*dg.Query{
+				"vars": client.Query(ctx, Department{}).
+					Vars("getByName($name: string)", map[string]string{"$name": "Sales"}).
+					Filter("eq(dept_name, $name)"),
+			})
+			require.Error(t, err, "a query with variables should be rejected")
+		})
+	}
+}
+
+// SyncedDocument carries the timestamp ModifiedSince polls on.
+type SyncedDocument struct {
+	UID       string    `json:"uid,omitempty"`
+	Title     string    `json:"title,omitempty" dgraph:"index=exact"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+	DType     []string  `json:"dgraph.type,omitempty"`
+}
+
+func TestClientModifiedSince(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "ModifiedSinceWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "ModifiedSinceWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			docs := make([]*SyncedDocument, 4)
+			for i := range docs {
+				docs[i] = &SyncedDocument{Title: fmt.Sprintf("doc-%d", i), UpdatedAt: created}
+			}
+			require.NoError(t, client.Insert(ctx, docs))
+
+			cutoff := created.Add(time.Hour)
+			for i, doc := range []*SyncedDocument{docs[2], docs[0]} {
+				doc.UpdatedAt = cutoff.Add(time.Duration(i+1) * time.Minute)
+				require.NoError(t, client.Update(ctx, doc))
+			}
+
+			var changed []SyncedDocument
+			require.NoError(t, client.ModifiedSince(ctx, SyncedDocument{}, "updatedAt", cutoff).Nodes(&changed))
+			require.Len(t, changed, 2, "Only the updated documents should be returned")
+			require.Equal(t, "doc-2", changed[0].Title, "Results should be ordered by updatedAt")
+			require.Equal(t, "doc-0", changed[1].Title)
+
+			var all []SyncedDocument
+			require.NoError(t, client.ModifiedSince(ctx, SyncedDocument{}, "updatedAt", created).Nodes(&all))
+			require.Len(t, all, 4, "The cutoff should be inclusive")
+
+			require.Nil(t, client.ModifiedSince(ctx, SyncedDocument{}, "updatedAt)", cutoff),
+				"An invalid predicate should yield no query")
+		})
+	}
+}
+
+// LargeCounter holds integers beyond the 2^53 that float64 represents exactly.
+type LargeCounter struct {
+	UID      string   `json:"uid,omitempty"`
+	Name     string   `json:"name,omitempty" dgraph:"index=exact"`
+	Value    int64    `json:"value,omitempty"`
+	Unsigned uint64   `json:"unsigned,omitempty"`
+	DType    []string `json:"dgraph.type,omitempty"`
+}
+
+func TestClientLargeIntegerRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "LargeIntegerRoundTripWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "LargeIntegerRoundTripWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			values := []int64{1<<53 - 1, 1<<53 + 1, 1<<62 + 3, math.MaxInt64, math.MinInt64 + 1}
+			for i, v := range values {
+				name := fmt.Sprintf("counter-%d", i)
+				stored := &LargeCounter{Name: name, Value: v, Unsigned: uint64(v) >> 1}
+				require.NoError(t, client.Insert(ctx, stored))
+
+				var got LargeCounter
+				require.NoError(t, client.Get(ctx, &got, stored.UID))
+				require.Equal(t, v, got.Value, "Get should round-trip %d exactly", v)
+				require.Equal(t, stored.Unsigned, got.Unsigned)
+
+				var found []LargeCounter
+				require.NoError(t, client.Query(ctx, LargeCounter{}).Filter("eq(name, $1)", name).Nodes(&found))
+				require.Len(t, found, 1)
+				require.Equal(t, v, found[0].Value, "Query should round-trip %d exactly", v)
+
+				raw, err := modusgraph.QueryRawInto[LargeCounter](ctx, client,
+					`query q($n: string) { q(func: eq(name, $n)) { value } }`, map[string]string{"$n": name}, "q")
+				require.NoError(t, err)
+				require.Len(t, raw, 1)
+				require.Equal(t, v, raw[0].Value, "QueryRawInto should round-trip %d exactly", v)
+			}
+
+			nodes, err := client.QueryInterface(ctx, "LargeCounter", func() any { return &LargeCounter{} })
+			require.NoError(t, err)
+			require.Len(t, nodes, len(values))
+			for _, n := range nodes {
+				c := n.(*LargeCounter)
+				var i int
+				_, err := fmt.Sscanf(c.Name, "counter-%d", &i)
+				require.NoError(t, err)
+				require.Equal(t, values[i], c.Value, "QueryInterface should round-trip %d exactly", values[i])
+			}
+		})
+	}
+}
+
 type GeoLocation struct {
 	Type  string    `json:"type"`
 	Coord []float64 `json:"coordinates"`
@@ -233,6 +579,29 @@ func TestClientQuery(t *testing.T) {
 				}
 			})
 
+			t.Run("QueryRawInto", func(t *testing.T) {
+				result, err := modusgraph.QueryRawInto[QueryTestRecord](ctx, client,
+					`query older_than_inclusive($1: int) { q(func: ge(age, $1), orderasc: age) { uid name age birthDate }}`,
+					map[string]string{"$1": "37"}, "q")
+				require.NoError(t, err, "QueryRawInto should succeed")
+				require.Len(t, result, 3, "Should have 3 entities")
+				for i := range 3 {
+					require.NotEmpty(t, result[i].UID, "UID should be decoded")
+					require.Equal(t, fmt.Sprintf("Test Entity %d", 7+i), result[i].Name, "Name should match")
+					require.Equal(t, 37+i, result[i].Age, "Age should match")
+					require.Equal(t, birthDate.AddDate(0, 0, 7+i), result[i].BirthDate, "BirthDate should match")
+				}
+
+				result, err = modusgraph.QueryRawInto[QueryTestRecord](ctx, client,
+					`query { q(func: type(QueryTestRecord)) { uid }}`, nil, "missing")
+				require.NoError(t, err, "QueryRawInto should succeed")
+				require.Empty(t, result, "A missing block should decode as no results")
+
+				_, err = modusgraph.QueryRawInto[string](ctx, client,
+					`query { q(func: type(QueryTestRecord)) { uid }}`, nil, "q")
+				require.Error(t, err, "A block that does not match T should fail to decode")
+			})
+
 			t.Run("QueryRawWithVars", func(t *testing.T) {
 				var result struct {
 					Data []QueryTestRecord `json:"q"`
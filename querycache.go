@@ -0,0 +1,106 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// noCacheKey is the context key NoCache sets.
+type noCacheKey struct{}
+
+// NoCache returns a context under which QueryRaw, and the reads built on it,
+// bypass the client's query cache: the query always runs, and its result is
+// not cached. It has no effect on a client without WithQueryCache.
+func NoCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// queryCache is a least-recently-used cache of query results, each valid for
+// ttl after it was fetched. It is safe for concurrent use.
+type queryCache struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // of *queryCacheEntry, most recently used first
+	entries map[string]*list.Element
+}
+
+type queryCacheEntry struct {
+	key     string
+	data    []byte
+	expires time.Time
+}
+
+func newQueryCache(size int, ttl time.Duration) *queryCache {
+	return &queryCache{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+// queryCacheKey identifies a query by namespace, text, and variables.
+func queryCacheKey(namespace, q string, vars map[string]string) string {
+	var b bytes.Buffer
+	b.WriteString(namespace)
+	b.WriteByte(0)
+	b.WriteString(q)
+	for _, name := range slices.Sorted(maps.Keys(vars)) {
+		b.WriteByte(0)
+		b.WriteString(name)
+		b.WriteByte(0)
+		b.WriteString(vars[name])
+	}
+	return b.String()
+}
+
+// get returns a copy of the unexpired result cached under key.
+func (qc *queryCache) get(key string) ([]byte, bool) {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	el, ok := qc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*queryCacheEntry)
+	if !qc.now().Before(entry.expires) {
+		qc.order.Remove(el)
+		delete(qc.entries, key)
+		return nil, false
+	}
+	qc.order.MoveToFront(el)
+	return bytes.Clone(entry.data), true
+}
+
+// put caches a copy of data under key, evicting the least recently used
+// result when the cache is full.
+func (qc *queryCache) put(key string, data []byte) {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	entry := &queryCacheEntry{key: key, data: bytes.Clone(data), expires: qc.now().Add(qc.ttl)}
+	if el, ok := qc.entries[key]; ok {
+		el.Value = entry
+		qc.order.MoveToFront(el)
+		return
+	}
+	qc.entries[key] = qc.order.PushFront(entry)
+	for qc.order.Len() > qc.size {
+		oldest := qc.order.Back()
+		qc.order.Remove(oldest)
+		delete(qc.entries, oldest.Value.(*queryCacheEntry).key)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientWithQueryCache(t *testing.T) {
+	const ttl = 500 * time.Millisecond
+	ctx := context.Background()
+	client, err := modusgraph.NewClient("file://"+GetTempDir(t), modusgraph.WithAutoSchema(true),
+		modusgraph.WithQueryCache(16, ttl))
+	require.NoError(t, err)
+	defer func() {
+		client.Close()
+		modusgraph.Shutdown()
+	}()
+
+	require.NoError(t, client.Insert(ctx, &TestEntity{Name: "First"}))
+	const query = `query q($name: string) { q(func: eq(name, $name)) { name } }`
+	vars := map[string]string{"$name": "Second"}
+	cached, err := client.QueryRaw(ctx, query, vars)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"q": []}`, string(cached))
+
+	// Write behind the cache's back, straight through the engine's client.
+	dgo, cleanup, err := client.DgraphClient()
+	require.NoError(t, err)
+	_, err = dgo.NewTxn().Mutate(ctx, &api.Mutation{
+		SetNquads: []byte(`_:n <name> "Second" .
+_:n <dgraph.type> "TestEntity" .`),
+		CommitNow: true,
+	})
+	cleanup()
+	require.NoError(t, err)
+
+	again, err := client.QueryRaw(ctx, query, vars)
+	require.NoError(t, err)
+	require.Equal(t, cached, again, "A repeated query should be served from the cache")
+
+	fresh, err := client.QueryRaw(modusgraph.NoCache(ctx), query, vars)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"q": [{"name": "Second"}]}`, string(fresh), "NoCache should run the query")
+
+	other, err := client.QueryRaw(ctx, query, map[string]string{"$name": "First"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"q": [{"name": "First"}]}`, string(other), "Other variables should not share an entry")
+
+	time.Sleep(ttl)
+	expired, err := client.QueryRaw(ctx, query, vars)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"q": [{"name": "Second"}]}`, string(expired), "An expired result should be refetched")
+}
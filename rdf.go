@@ -0,0 +1,178 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// rdfExportPageSize is the number of nodes ExportRDF reads per query.
+const rdfExportPageSize = 1000
+
+// ExportRDF implements writing every triple in the database to w as N-Quads,
+// the inverse of Engine.Load. Each predicate in the schema, and dgraph.type,
+// is read in pages of nodes that have it, in UID order; uid edges are written
+// as UID objects, language-tagged strings keep their tag, and other values
+// carry the XSD or Dgraph type of their predicate. Subjects and objects are
+// the nodes' UIDs, which Engine.Load and Dgraph's live loader map to fresh
+// UIDs. Facets and password predicates, which cannot be read back, are not
+// exported.
+//
+// Together with the output of GetSchema, the result can be loaded by
+// Engine.Load, Dgraph's live or bulk loader, or another modusgraph instance.
+// Each page is read in its own transaction, so writes made during an export
+// may or may not appear in it.
+func (c client) ExportRDF(ctx context.Context, w io.Writer) error {
+	in, err := c.Introspect(ctx)
+	if err != nil {
+		return fmt.Errorf("ExportRDF: reading schema: %w", err)
+	}
+	preds := append([]PredicateInfo{{Name: "dgraph.type", Type: "string", List: true}}, in.Predicates...)
+
+	bw := bufio.NewWriter(w)
+	for _, pred := range preds {
+		if pred.Type == "password" {
+			continue
+		}
+		if err := c.exportPredicateRDF(ctx, bw, pred); err != nil {
+			return fmt.Errorf("ExportRDF: exporting %s: %w", pred.Name, err)
+		}
+	}
+	return bw.Flush()
+}
+
+// exportPredicateRDF writes the triples of one predicate, a page of subjects
+// at a time.
+func (c client) exportPredicateRDF(ctx context.Context, w *bufio.Writer, pred PredicateInfo) error {
+	selection := "<" + pred.Name + ">"
+	switch {
+	case pred.Type == "uid":
+		selection += " { uid }"
+	case pred.Lang:
+		selection += "@*"
+	}
+
+	after := ""
+	for {
+		page := fmt.Sprintf("first: %d", rdfExportPageSize)
+		if after != "" {
+			page += ", after: " + after
+		}
+		q := fmt.Sprintf("{ q(func: has(<%s>), %s) { uid %s } }", pred.Name, page, selection)
+		data, err := c.queryRaw(ctx, q, nil)
+		if err != nil {
+			return err
+		}
+		var resp struct {
+			Q []map[string]json.RawMessage `json:"q"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return err
+		}
+		for _, node := range resp.Q {
+			var uid string
+			if err := json.Unmarshal(node["uid"], &uid); err != nil {
+				return fmt.Errorf("reading uid: %w", err)
+			}
+			for key, raw := range node {
+				lang, ok := strings.CutPrefix(key, pred.Name)
+				if !ok || (lang != "" && !strings.HasPrefix(lang, "@")) {
+					continue
+				}
+				if err := writeRDFValues(w, uid, pred, lang, raw); err != nil {
+					return err
+				}
+			}
+			after = uid
+		}
+		if len(resp.Q) < rdfExportPageSize {
+			return nil
+		}
+	}
+}
+
+// writeRDFValues writes a triple for each value of pred in raw, which holds a
+// list predicate's values as a JSON array.
+func writeRDFValues(w *bufio.Writer, subject string, pred PredicateInfo, lang string, raw json.RawMessage) error {
+	values := []json.RawMessage{raw}
+	// A vector is one value even though it is encoded as an array.
+	if pred.Type != "float32vector" && bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+		values = nil
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return err
+		}
+	}
+	for _, v := range values {
+		object, err := rdfObject(pred.Type, lang, v)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "<%s> <%s> %s .\n", subject, pred.Name, object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rdfObject renders one JSON value of a predicate of Dgraph type typ as the
+// object of an N-Quad.
+func rdfObject(typ, lang string, v json.RawMessage) (string, error) {
+	if typ == "uid" {
+		var edge struct {
+			UID string `json:"uid"`
+		}
+		if err := json.Unmarshal(v, &edge); err != nil {
+			return "", err
+		}
+		return "<" + edge.UID + ">", nil
+	}
+
+	text := string(v)
+	var s string
+	if err := json.Unmarshal(v, &s); err == nil {
+		text = s
+	} else if typ == "geo" || typ == "float32vector" {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, v); err != nil {
+			return "", err
+		}
+		text = compact.String()
+	}
+	literal := `"` + escapeRDFString(text) + `"`
+
+	switch typ {
+	case "int":
+		return literal + "^^<xs:int>", nil
+	case "float":
+		return literal + "^^<xs:float>", nil
+	case "bool":
+		return literal + "^^<xs:boolean>", nil
+	case "datetime":
+		return literal + "^^<xs:dateTime>", nil
+	case "geo":
+		return literal + "^^<geo:geojson>", nil
+	case "float32vector":
+		return literal + "^^<float32vector>", nil
+	}
+	return literal + lang, nil
+}
+
+// escapeRDFString escapes s for use inside a quoted N-Quad literal.
+func escapeRDFString(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	).Replace(s)
+}
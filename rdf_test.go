@@ -0,0 +1,115 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+const rdfExportSchema = `
+name: string @index(exact) @lang .
+visits: int .
+rating: float .
+open: bool .
+built: datetime .
+location: geo @index(geo) .
+tags: [string] .
+friend: [uid] @reverse .
+type Landmark {
+	name
+	visits
+	rating
+	open
+	built
+	location
+	tags
+	friend
+}
+`
+
+const rdfExportData = `
+_:eiffel <dgraph.type> "Landmark" .
+_:eiffel <name> "Eiffel Tower" .
+_:eiffel <name> "Tour Eiffel"@fr .
+_:eiffel <visits> "7000000"^^<xs:int> .
+_:eiffel <rating> "4.7"^^<xs:float> .
+_:eiffel <open> "true"^^<xs:boolean> .
+_:eiffel <built> "1889-03-31T00:00:00Z"^^<xs:dateTime> .
+_:eiffel <location> "{\"type\":\"Point\",\"coordinates\":[2.2945,48.8584]}"^^<geo:geojson> .
+_:eiffel <tags> "iron" .
+_:eiffel <tags> "tower \"tall\"\nline two" .
+_:eiffel <friend> _:louvre .
+_:louvre <dgraph.type> "Landmark" .
+_:louvre <name> "Louvre" .
+_:louvre <open> "false"^^<xs:boolean> .
+_:louvre <friend> _:eiffel .
+`
+
+const rdfExportQuery = `{
+	q(func: type(Landmark), orderasc: name) {
+		dgraph.type
+		name
+		name@fr
+		visits
+		rating
+		open
+		built
+		location
+		tags
+		friend { name }
+		~friend { name }
+	}
+}`
+
+func TestClientExportRDF(t *testing.T) {
+	ctx := context.Background()
+	writeFile := func(dir, name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	// loadAndQuery loads schema and data into a fresh engine in dir and
+	// returns the landmarks it then holds.
+	loadAndQuery := func(dir, schema, data string) string {
+		files := t.TempDir()
+		engine, err := modusgraph.NewEngine(modusgraph.NewDefaultConfig(dir))
+		require.NoError(t, err)
+		defer engine.Close()
+		require.NoError(t, engine.Load(ctx, writeFile(files, "schema.dql", schema),
+			writeFile(files, "data.rdf", data)))
+		resp, err := engine.GetDefaultNamespace().Query(ctx, rdfExportQuery)
+		require.NoError(t, err)
+		return string(resp.GetJson())
+	}
+
+	sourceDir := t.TempDir()
+	original := loadAndQuery(sourceDir, rdfExportSchema, rdfExportData)
+	require.Contains(t, original, "Tour Eiffel")
+
+	client, err := modusgraph.NewClient("file://" + sourceDir)
+	require.NoError(t, err)
+	var exported bytes.Buffer
+	require.NoError(t, client.ExportRDF(ctx, &exported))
+	schema, err := client.GetSchema(ctx)
+	require.NoError(t, err)
+	client.Close()
+	modusgraph.Shutdown()
+
+	require.Contains(t, exported.String(), `<name> "Tour Eiffel"@fr .`)
+	require.Contains(t, exported.String(), `<visits> "7000000"^^<xs:int> .`)
+	require.Contains(t, exported.String(), `<tags> "tower \"tall\"\nline two" .`)
+	require.NotContains(t, exported.String(), "_:", "subjects should be exported as UIDs")
+
+	reloaded := loadAndQuery(t.TempDir(), schema, exported.String())
+	require.JSONEq(t, original, reloaded, "the reloaded database should match the original")
+}
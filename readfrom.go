@@ -0,0 +1,99 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// readFromTag is the struct tag that declares a field read from another
+// type's forward edge, as readFrom:"type=Branch,field=proj": the field holds
+// the Branch nodes whose proj edge points at the node being read.
+const readFromTag = "readFrom"
+
+// readFrom is a parsed readFrom tag.
+type readFrom struct {
+	typeName  string // the type of the nodes holding the edge
+	predicate string // their forward edge to the node being read
+}
+
+// parseReadFrom parses the value of a readFrom tag.
+func parseReadFrom(tag string) (readFrom, error) {
+	var rf readFrom
+	for part := range strings.SplitSeq(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "type":
+			rf.typeName = value
+		case "field":
+			rf.predicate = value
+		default:
+			return rf, fmt.Errorf("unknown readFrom key %q", key)
+		}
+	}
+	if !isValidPredicateName(rf.typeName) || !isValidPredicateName(rf.predicate) {
+		return rf, fmt.Errorf("readFrom %q needs a type and a field", tag)
+	}
+	return rf, nil
+}
+
+// populateReadFrom fills every field of obj tagged readFrom with the nodes
+// of the named type whose forward edge points at uid, reading them within
+// txn to the client's edge traversal depth. The edge needs no @reverse
+// index, since each field is read by a query of its own.
+func (c client) populateReadFrom(txn *dg.TxnContext, obj any, uid string) error {
+	v := reflect.ValueOf(obj).Elem()
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	layout := layoutOf(v.Type(), c.options.tagName)
+	if layout.readFromErr != nil {
+		return layout.readFromErr
+	}
+	if len(layout.readFrom) == 0 {
+		return nil
+	}
+	id, err := strconv.ParseUint(uid, 0, 64)
+	if err != nil {
+		return fmt.Errorf("invalid UID %q", uid)
+	}
+	for _, field := range layout.readFrom {
+		model := reflect.New(field.elem).Interface()
+		q := c.expandAll(txn.Context(), txn.Get(model), model).
+			RootFunc("type(" + field.rf.typeName + ")").
+			Filter(fmt.Sprintf("uid_in(%s, 0x%x)", field.rf.predicate, id))
+
+		dst := v.Field(field.index)
+		if dst.Kind() == reflect.Slice {
+			if err := q.Nodes(dst.Addr().Interface()); err != nil {
+				return fmt.Errorf("reading field %s: %w", field.name, err)
+			}
+			continue
+		}
+		// A single node: the first one holding the edge, if any
+		node := reflect.New(field.elem)
+		err = q.First(1).Node(node.Interface())
+		if errors.Is(err, dg.ErrNodeNotFound) {
+			dst.SetZero()
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reading field %s: %w", field.name, err)
+		}
+		if dst.Kind() == reflect.Pointer {
+			dst.Set(node)
+		} else {
+			dst.Set(node.Elem())
+		}
+	}
+	return nil
+}
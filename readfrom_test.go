@@ -0,0 +1,96 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ProjectBranches reads a Project together with the Branches whose project
+// edge points at it; the edge has no @reverse index.
+type ProjectBranches struct {
+	UID      string    `json:"uid,omitempty"`
+	Name     string    `json:"name,omitempty"`
+	Branches []*Branch `json:"-" readFrom:"type=Branch,field=project"`
+	AnyOne   *Branch   `json:"-" readFrom:"type=Branch,field=project"`
+	DType    []string  `json:"dgraph.type,omitempty"`
+}
+
+type ProjectBadReadFrom struct {
+	UID      string    `json:"uid,omitempty"`
+	Branches []*Branch `json:"-" readFrom:"type=Branch"`
+}
+
+func TestReadFromGet(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "ReadFromGetWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "ReadFromGetWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			ctx := context.Background()
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			modus := &Project{Name: "modus"}
+			other := &Project{Name: "other"}
+			require.NoError(t, client.Insert(ctx, []*Project{modus, other}))
+			branches := []*Branch{
+				{Name: "main", Project: &Project{UID: modus.UID}},
+				{Name: "dev", Project: &Project{UID: modus.UID}},
+				{Name: "unrelated", Project: &Project{UID: other.UID}},
+			}
+			require.NoError(t, client.Insert(ctx, branches))
+
+			var got ProjectBranches
+			require.NoError(t, client.Get(ctx, &got, modus.UID))
+			require.Equal(t, "modus", got.Name)
+			require.Len(t, got.Branches, 2, "Only the branches pointing at the project should be read")
+			names := []string{got.Branches[0].Name, got.Branches[1].Name}
+			require.ElementsMatch(t, []string{"main", "dev"}, names)
+			require.NotNil(t, got.AnyOne, "A single readFrom field should get one of the branches")
+			require.Contains(t, names, got.AnyOne.Name)
+
+			// A project without branches reads an empty field
+			lonely := &Project{Name: "lonely"}
+			require.NoError(t, client.Insert(ctx, lonely))
+			var empty ProjectBranches
+			require.NoError(t, client.Get(ctx, &empty, lonely.UID))
+			require.Empty(t, empty.Branches)
+			require.Nil(t, empty.AnyOne)
+
+			// GetMap reads the field too
+			nodes, err := client.GetMap(ctx, []string{other.UID}, func() any { return &ProjectBranches{} })
+			require.NoError(t, err)
+			require.Len(t, nodes[other.UID].(*ProjectBranches).Branches, 1)
+			require.Equal(t, "unrelated", nodes[other.UID].(*ProjectBranches).Branches[0].Name)
+
+			var bad ProjectBadReadFrom
+			require.Error(t, client.Get(ctx, &bad, modus.UID), "A readFrom tag without a field should be rejected")
+		})
+	}
+}
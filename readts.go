@@ -0,0 +1,83 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrEmbeddedOnly is returned by operations only an embedded (file://) client
+// supports, when called on a client connected to a remote cluster.
+var ErrEmbeddedOnly = errors.New("operation needs an embedded (file://) client")
+
+// ReadTs implements returning the embedded engine's latest commit timestamp.
+func (c client) ReadTs(ctx context.Context) (uint64, error) {
+	if c.engine == nil {
+		return 0, fmt.Errorf("ReadTs: %w", ErrEmbeddedOnly)
+	}
+	return c.engine.latestTs()
+}
+
+// QueryAt implements running a raw query at a past read timestamp. The query
+// bypasses the query cache, whose entries hold the latest data.
+func (c client) QueryAt(ctx context.Context, readTs uint64, q string, vars map[string]string) ([]byte, error) {
+	if c.engine == nil {
+		return nil, fmt.Errorf("QueryAt: %w", ErrEmbeddedOnly)
+	}
+	if readTs == 0 {
+		return nil, errors.New("QueryAt: read timestamp must be positive")
+	}
+	latest, err := c.engine.latestTs()
+	if err != nil {
+		return nil, err
+	}
+	if readTs > latest {
+		return nil, fmt.Errorf("QueryAt: read timestamp %d is ahead of the latest commit %d", readTs, latest)
+	}
+	ns, err := c.engineNamespace()
+	if err != nil {
+		return nil, err
+	}
+	if c.names != nil {
+		q = c.names.query(q)
+	}
+	resp, err := c.engine.queryAt(ctx, ns, q, vars, readTs)
+	if err != nil {
+		return nil, err
+	}
+	if c.names != nil {
+		if err := c.names.response(resp); err != nil {
+			return nil, err
+		}
+	}
+	return resp.GetJson(), nil
+}
+
+// engineNamespace returns the embedded engine's namespace the client reads
+// and writes.
+func (c client) engineNamespace() (*Namespace, error) {
+	if c.options.namespace == "" {
+		return c.engine.GetDefaultNamespace(), nil
+	}
+	nsID, err := parseNamespaceID(c.options.namespace)
+	if err != nil {
+		return nil, err
+	}
+	return c.engine.GetNamespace(nsID)
+}
+
+// latestTs returns the timestamp of the latest commit, the one queries read
+// at by default.
+func (engine *Engine) latestTs() (uint64, error) {
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+	if !engine.isOpen.Load() {
+		return 0, ErrClosedEngine
+	}
+	return engine.z.readTs(), nil
+}
@@ -0,0 +1,108 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+type LedgerEntry struct {
+	UID   string   `json:"uid,omitempty"`
+	Memo  string   `json:"ledger_memo,omitempty" dgraph:"index=exact"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+func TestQueryAt(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "QueryAtWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "QueryAtWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			ctx := context.Background()
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			const query = `{ q(func: type(LedgerEntry), orderasc: ledger_memo) { ledger_memo } }`
+			memos := func(data []byte) []string {
+				var resp struct {
+					Q []LedgerEntry `json:"q"`
+				}
+				require.NoError(t, json.Unmarshal(data, &resp))
+				var got []string
+				for _, e := range resp.Q {
+					got = append(got, e.Memo)
+				}
+				return got
+			}
+
+			require.NoError(t, client.Insert(ctx, &LedgerEntry{Memo: "opening"}))
+			readTs, err := client.ReadTs(ctx)
+			if !strings.HasPrefix(tc.uri, "file://") {
+				require.ErrorIs(t, err, modusgraph.ErrEmbeddedOnly)
+				_, err = client.QueryAt(ctx, 1, query, nil)
+				require.ErrorIs(t, err, modusgraph.ErrEmbeddedOnly)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, readTs)
+
+			later := &LedgerEntry{Memo: "payment"}
+			require.NoError(t, client.Insert(ctx, later))
+
+			data, err := client.QueryAt(ctx, readTs, query, nil)
+			require.NoError(t, err)
+			require.Equal(t, []string{"opening"}, memos(data), "The old read should not see the later insert")
+
+			data, err = client.QueryRaw(ctx, query, nil)
+			require.NoError(t, err)
+			require.Equal(t, []string{"opening", "payment"}, memos(data))
+
+			// A later delete is invisible at a timestamp taken before it
+			beforeDelete, err := client.ReadTs(ctx)
+			require.NoError(t, err)
+			require.Greater(t, beforeDelete, readTs)
+			require.NoError(t, client.Delete(ctx, []string{later.UID}))
+			data, err = client.QueryAt(ctx, beforeDelete, query, nil)
+			require.NoError(t, err)
+			require.Equal(t, []string{"opening", "payment"}, memos(data))
+			latest, err := client.ReadTs(ctx)
+			require.NoError(t, err)
+			data, err = client.QueryAt(ctx, latest, query, nil)
+			require.NoError(t, err)
+			require.Equal(t, []string{"opening"}, memos(data))
+
+			_, err = client.QueryAt(ctx, 0, query, nil)
+			require.Error(t, err, "A zero read timestamp should be rejected")
+			_, err = client.QueryAt(ctx, latest+100, query, nil)
+			require.Error(t, err, "A read timestamp ahead of the latest commit should be rejected")
+		})
+	}
+}
@@ -5,7 +5,10 @@
 
 package modusgraph
 
-import "reflect"
+import (
+	"reflect"
+	"sync"
+)
 
 // Schema identifies a value as a record of a generated schema-defining type.
 // modusgraph-gen-emitted schema structs implement this via a generated
@@ -59,29 +62,57 @@ func UnwrapSchema(obj any) any {
 	if v.Kind() == reflect.Pointer && v.IsNil() {
 		return obj
 	}
-	m := v.MethodByName("Unwrap")
-	if !m.IsValid() && v.Kind() != reflect.Pointer {
-		// Unwrap may be declared with a pointer receiver while obj was passed by
-		// value; a value's method set excludes pointer-receiver methods, so look
-		// it up on an addressable copy.
-		pv := reflect.New(v.Type())
-		pv.Elem().Set(v)
-		m = pv.MethodByName("Unwrap")
-	}
-	if !m.IsValid() {
+	um := unwrapMethodOf(v.Type())
+	if um.index < 0 {
 		return obj
 	}
-	mt := m.Type()
-	if mt.NumIn() != 0 || mt.NumOut() != 1 {
-		return obj
+	if um.viaPointer {
+		// Unwrap is declared with a pointer receiver while obj was passed by
+		// value; a value's method set excludes pointer-receiver methods, so
+		// call it on an addressable copy.
+		pv := reflect.New(v.Type())
+		pv.Elem().Set(v)
+		v = pv
 	}
-	inner := m.Call(nil)[0].Interface()
+	inner := v.Method(um.index).Call(nil)[0].Interface()
 	if _, ok := inner.(Schema); ok {
 		return inner
 	}
 	return obj
 }
 
+// unwrapMethod records where UnwrapSchema finds a type's Unwrap method: its
+// index in the method set of the type, or of a pointer to it when viaPointer
+// is set. index is -1 when the type has no Unwrap method taking no arguments
+// and returning one value.
+type unwrapMethod struct {
+	index      int
+	viaPointer bool
+}
+
+// unwrapMethods caches unwrapMethod by reflect.Type. UnwrapSchema runs on
+// every Client call, so the method-set lookup is done once per type.
+var unwrapMethods sync.Map
+
+// unwrapMethodOf returns the unwrapMethod of t, looking it up on first use.
+func unwrapMethodOf(t reflect.Type) unwrapMethod {
+	if um, ok := unwrapMethods.Load(t); ok {
+		return um.(unwrapMethod)
+	}
+	um := unwrapMethod{index: -1}
+	method, ok := t.MethodByName("Unwrap")
+	if !ok && t.Kind() != reflect.Pointer {
+		method, ok = reflect.PointerTo(t).MethodByName("Unwrap")
+		um.viaPointer = ok
+	}
+	// A method looked up on a type counts its receiver among the arguments.
+	if ok && method.Type.NumIn() == 1 && method.Type.NumOut() == 1 {
+		um.index = method.Index
+	}
+	actual, _ := unwrapMethods.LoadOrStore(t, um)
+	return actual.(unwrapMethod)
+}
+
 // unwrapSchemaSlice unwraps each element of a slice or array. It returns obj
 // unchanged when no element is a wrapper, so existing callers passing slices
 // of plain structs are unaffected — important because dgman writes generated
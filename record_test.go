@@ -6,6 +6,7 @@
 package modusgraph
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
@@ -214,3 +215,157 @@ func TestUnwrapSchema_ArrayOfWrappersUnwrapsEach(t *testing.T) {
 		t.Fatalf("expected inner records [a b], got %v", got)
 	}
 }
+
+// getRecord is a plain node type read back through a real client.
+type getRecord struct {
+	UID   string   `json:"uid,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+	Name  string   `json:"name,omitempty"`
+}
+
+func TestUnwrapSchema_CachesMethodLookupPerType(t *testing.T) {
+	c, err := NewClient("file://"+t.TempDir(), WithAutoSchema(true))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	rec := &getRecord{Name: "cached"}
+	if err := c.Insert(ctx, rec); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	typ := reflect.TypeOf(rec)
+	cached, ok := unwrapMethods.Load(typ)
+	if !ok {
+		t.Fatalf("expected the Unwrap lookup for %v to be cached after Insert", typ)
+	}
+	for i := 0; i < 3; i++ {
+		var got getRecord
+		if err := c.Get(ctx, &got, rec.UID); err != nil {
+			t.Fatalf("Get %d: %v", i, err)
+		}
+		if got.Name != "cached" {
+			t.Fatalf("Get %d: Name = %q, want %q", i, got.Name, "cached")
+		}
+		if again, _ := unwrapMethods.Load(typ); again != cached {
+			t.Fatalf("Get %d: cached lookup changed from %v to %v", i, cached, again)
+		}
+	}
+	if um := cached.(unwrapMethod); um.index != -1 {
+		t.Fatalf("expected no Unwrap method recorded for a plain struct, got %+v", um)
+	}
+
+	// Wrappers resolve through the cache to the same result on every call,
+	// whether passed by pointer or by value.
+	inner := &fakeRecord{name: "Studio"}
+	for i := 0; i < 2; i++ {
+		if out := UnwrapSchema(&fakeWrapper{inner: inner}); out != any(inner) {
+			t.Fatalf("pointer wrapper call %d: got %T", i, out)
+		}
+		if out := UnwrapSchema(fakeWrapper{inner: inner}); out != any(inner) {
+			t.Fatalf("value wrapper call %d: got %T", i, out)
+		}
+	}
+	if um, _ := unwrapMethods.Load(reflect.TypeOf(fakeWrapper{})); !um.(unwrapMethod).viaPointer {
+		t.Fatalf("expected the value wrapper's Unwrap to be found through a pointer, got %+v", um)
+	}
+}
+
+// layoutProject carries a version field, so Update consults its layout.
+type layoutProject struct {
+	UID     string   `json:"uid,omitempty"`
+	DType   []string `json:"dgraph.type,omitempty"`
+	Name    string   `json:"layout_name,omitempty" dgraph:"index=exact"`
+	Version int      `json:"layout_version,omitempty" dgraph:"version"`
+}
+
+type layoutBranch struct {
+	UID     string         `json:"uid,omitempty"`
+	DType   []string       `json:"dgraph.type,omitempty"`
+	Label   string         `json:"layout_label,omitempty"`
+	Project *layoutProject `json:"layout_project,omitempty"`
+}
+
+// layoutProjectBranches reads a layoutProject with the layoutBranch nodes
+// pointing at it, so Get consults its layout.
+type layoutProjectBranches struct {
+	UID      string          `json:"uid,omitempty"`
+	Name     string          `json:"layout_name,omitempty"`
+	Branches []*layoutBranch `json:"-" readFrom:"type=layoutBranch,field=layout_project"`
+}
+
+func TestGet_CachesTypeLayout(t *testing.T) {
+	c, err := NewClient("file://"+t.TempDir(), WithAutoSchema(true))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	project := &layoutProject{Name: "cached"}
+	if err := c.Insert(ctx, project); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	if err := c.Insert(ctx, &layoutBranch{Label: "main", Project: &layoutProject{UID: project.UID}}); err != nil {
+		t.Fatalf("Insert branch: %v", err)
+	}
+
+	key := layoutKey{reflect.TypeOf(layoutProjectBranches{}), ""}
+	var cached any
+	for i := 0; i < 3; i++ {
+		var got layoutProjectBranches
+		if err := c.Get(ctx, &got, project.UID); err != nil {
+			t.Fatalf("Get %d: %v", i, err)
+		}
+		if got.Name != "cached" || len(got.Branches) != 1 || got.Branches[0].Label != "main" {
+			t.Fatalf("Get %d: got %+v, want the project and its branch", i, got)
+		}
+		layout, ok := typeLayouts.Load(key)
+		if !ok {
+			t.Fatalf("Get %d: expected the layout of %v to be cached", i, key.t)
+		}
+		if i == 0 {
+			cached = layout
+		} else if layout != cached {
+			t.Fatalf("Get %d: the cached layout was rebuilt", i)
+		}
+	}
+	if rf := cached.(*typeLayout).readFrom; len(rf) != 1 || rf[0].rf.typeName != "layoutBranch" {
+		t.Fatalf("readFrom fields = %+v, want the Branches field", rf)
+	}
+
+	// Update reads the version field from the cached layout
+	key = layoutKey{reflect.TypeOf(layoutProject{}), ""}
+	cached = nil
+	for i := 1; i <= 2; i++ {
+		if err := c.Update(ctx, project); err != nil {
+			t.Fatalf("Update %d: %v", i, err)
+		}
+		if project.Version != i {
+			t.Fatalf("Update %d: Version = %d, want %d", i, project.Version, i)
+		}
+		layout, ok := typeLayouts.Load(key)
+		if !ok {
+			t.Fatalf("Update %d: expected the layout of %v to be cached", i, key.t)
+		}
+		if cached == nil {
+			cached = layout
+		} else if layout != cached {
+			t.Fatalf("Update %d: the cached layout was rebuilt", i)
+		}
+	}
+	if pred := cached.(*typeLayout).versionPred; pred != "layout_version" {
+		t.Fatalf("version predicate = %q, want layout_version", pred)
+	}
+}
+
+func BenchmarkUnwrapSchema(b *testing.B) {
+	plain := &fakeNonSchema{X: "hi"}
+	wrapper := fakeWrapper{inner: &fakeRecord{name: "Studio"}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = UnwrapSchema(plain)
+		_ = UnwrapSchema(wrapper)
+	}
+}
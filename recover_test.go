@@ -0,0 +1,32 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRecoverReturnsEnginePanicAsError(t *testing.T) {
+	client, err := modusgraph.NewClient("file://"+t.TempDir(), modusgraph.WithRecover(true))
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := context.Background()
+	require.NoError(t, client.AlterSchema(ctx, "label: string @index(exact) ."))
+
+	// Changing the index of an existing predicate starts an index rebuild, a
+	// cluster task the embedded engine has no task tracker for; Dgraph panics.
+	err = client.AlterSchema(ctx, "label: string @index(term) .")
+	require.ErrorIs(t, err, modusgraph.ErrEnginePanic, "the panic should surface as an error")
+
+	// The client keeps serving requests after the recovered panic.
+	_, err = client.QueryRaw(ctx, `{ q(func: has(label)) { uid } }`, nil)
+	require.NoError(t, err)
+}
@@ -0,0 +1,133 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// SchemaConflictError is returned by RegisterModels when node types declare
+// the same predicate with different definitions, for example a name field
+// indexed by exact on one type and by term on another. Dgraph holds one
+// definition per predicate, so UpdateSchema would silently keep whichever it
+// met first.
+type SchemaConflictError struct {
+	Predicate   string
+	Definitions map[string]string // schema line of the predicate, by type name
+}
+
+func (e *SchemaConflictError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "conflicting definitions of predicate %s:", e.Predicate)
+	for i, name := range slices.Sorted(maps.Keys(e.Definitions)) {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, " %s declares %q", name, e.Definitions[name])
+	}
+	return b.String()
+}
+
+// RegisterModels implements registering every model type of an application
+// in one schema update.
+func (c client) RegisterModels(ctx context.Context, models ...any) error {
+	seen := make(map[reflect.Type]bool, len(models))
+	unique := make([]any, 0, len(models))
+	for _, model := range models {
+		model = UnwrapSchema(model)
+		t := reflect.TypeOf(model)
+		for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice) {
+			t = t.Elem()
+		}
+		if t == nil || seen[t] {
+			continue
+		}
+		seen[t] = true
+		unique = append(unique, model)
+	}
+
+	if err := schemaConflicts(c.options.tagName, c.options.indexProfile, unique...); err != nil {
+		return err
+	}
+	return c.UpdateSchema(ctx, unique...)
+}
+
+// schemaConflicts returns a SchemaConflictError for each predicate that the
+// node types of models, and the edge types they reference, define
+// differently, joined in predicate order, or nil if they all agree.
+func schemaConflicts(altTag, profile string, models ...any) error {
+	byPredicate := make(map[string]map[string]string)
+	for name, fields := range declaredPredicates(altTag, profile, models...) {
+		for pred, s := range fields {
+			if byPredicate[pred] == nil {
+				byPredicate[pred] = make(map[string]string)
+			}
+			byPredicate[pred][name] = s.String()
+		}
+	}
+
+	var errs []error
+	for _, pred := range slices.Sorted(maps.Keys(byPredicate)) {
+		definitions := byPredicate[pred]
+		if len(slices.Compact(slices.Sorted(maps.Values(definitions)))) > 1 {
+			errs = append(errs, &SchemaConflictError{Predicate: pred, Definitions: definitions})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// declaredPredicates returns, by node type name, the schema each predicate of
+// the node types of models and the edge types they reference is declared
+// with, including the directives of altTag and the indexes of profile as
+// createTaggedSchema applies them. dgman keeps a single definition per
+// predicate in a TypeSchema's Schema, but each type's own field definitions
+// in its Types.
+func declaredPredicates(altTag, profile string, models ...any) map[string]dg.SchemaMap {
+	ts := dg.NewTypeSchema()
+	ts.Marshal("", models...)
+	computed := computedPredicates(models...)
+
+	declared := make(map[string]dg.SchemaMap, len(ts.Types))
+	walkTypes(func(t reflect.Type) {
+		name := dg.GetNodeType(reflect.New(t).Interface())
+		fields, ok := ts.Types[name]
+		if !ok || declared[name] != nil {
+			return
+		}
+		own := make(dg.SchemaMap, len(fields))
+		for pred, s := range fields {
+			if !slices.Contains(computed, pred) {
+				field := *s
+				own[pred] = &field
+			}
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			directives := fieldDirectives(field, altTag)
+			s, ok := own[predicateName(field, directives)]
+			if !ok {
+				continue
+			}
+			if altTag != "" && altTag != "dgraph" {
+				applyDirectives(s, translateAltTag(field.Tag.Get(altTag)))
+			}
+			if profiles, ok := indexProfiles(directives); ok && profile != "" && !slices.Contains(profiles, profile) {
+				s.Index = false
+				s.Tokenizer = nil
+			}
+		}
+		declared[name] = own
+	}, models...)
+	return declared
+}
@@ -0,0 +1,104 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+type RegisteredTeam struct {
+	Name    string              `json:"name,omitempty" dgraph:"index=exact"`
+	Members []*RegisteredMember `json:"members,omitempty" dgraph:"reverse"`
+
+	UID   string   `json:"uid,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+type RegisteredMember struct {
+	Name  string `json:"name,omitempty" dgraph:"index=exact"`
+	Email string `json:"email,omitempty" dgraph:"index=hash upsert"`
+
+	UID   string   `json:"uid,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+// RegisteredProject shares name with the types above but indexes it by term.
+type RegisteredProject struct {
+	Name  string          `json:"name,omitempty" dgraph:"index=term"`
+	Owner *RegisteredTeam `json:"owner,omitempty"`
+
+	UID   string   `json:"uid,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+func TestClientRegisterModels(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "RegisterModelsWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "RegisterModelsWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+
+			// The project's term index on name conflicts with the exact index
+			// the team, and the member type it reaches, declare.
+			err := client.RegisterModels(ctx, &RegisteredTeam{}, &RegisteredProject{})
+			require.Error(t, err)
+			var conflict *modusgraph.SchemaConflictError
+			require.True(t, errors.As(err, &conflict), "want a SchemaConflictError, got %v", err)
+			require.Equal(t, "name", conflict.Predicate)
+			require.Equal(t, map[string]string{
+				"RegisteredTeam":    "name: string @index(exact) .",
+				"RegisteredMember":  "name: string @index(exact) .",
+				"RegisteredProject": "name: string @index(term) .",
+			}, conflict.Definitions)
+			require.Contains(t, err.Error(), "RegisteredProject declares \"name: string @index(term) .\"")
+
+			schema, err := client.GetSchema(ctx)
+			require.NoError(t, err)
+			require.NotContains(t, schema, "RegisteredTeam", "a conflict leaves the schema untouched")
+
+			// Shared predicates declared alike register once, as do models
+			// passed more than once.
+			require.NoError(t, client.RegisterModels(ctx,
+				&RegisteredTeam{}, &RegisteredMember{}, RegisteredTeam{}, []RegisteredMember{}))
+
+			schema, err = client.GetSchema(ctx)
+			require.NoError(t, err)
+			require.Contains(t, schema, "name: string @index(exact)")
+			require.Contains(t, schema, "email: string @index(hash) @upsert")
+			require.Contains(t, schema, "members: [uid] @reverse")
+			require.Contains(t, schema, "type RegisteredTeam")
+			require.Contains(t, schema, "type RegisteredMember")
+		})
+	}
+}
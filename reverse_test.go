@@ -10,6 +10,7 @@ import (
 	"os"
 	"testing"
 
+	mg "github.com/matthewmcneely/modusgraph"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -859,6 +860,85 @@ func TestFriendOfFriendChain(t *testing.T) {
 	}
 }
 
+func TestFriendOfFriendReverseDepth(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "ReverseDepthWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "ReverseDepthWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			ctx := context.Background()
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			// Create chain: Alice -> Bob -> Carol -> Dave -> Erin
+			erin := &FoafPerson{Name: "Erin"}
+			require.NoError(t, client.Insert(ctx, erin))
+			next := erin
+			for _, name := range []string{"Dave", "Carol", "Bob", "Alice"} {
+				p := &FoafPerson{Name: name, Friends: []*FoafPerson{next}}
+				require.NoError(t, client.Insert(ctx, p))
+				next = p
+			}
+
+			// reverseChain follows FriendsOf from p, naming each level.
+			reverseChain := func(p FoafPerson) []string {
+				var names []string
+				for len(p.FriendsOf) > 0 {
+					require.Len(t, p.FriendsOf, 1)
+					p = *p.FriendsOf[0]
+					names = append(names, p.Name)
+				}
+				return names
+			}
+
+			var got FoafPerson
+			require.NoError(t, client.Get(mg.ContextWithReverseDepth(ctx, 3), &got, erin.UID))
+			assert.Equal(t, "Erin", got.Name)
+			assert.Equal(t, []string{"Dave", "Carol", "Bob"}, reverseChain(got),
+				"exactly three reverse levels should be populated")
+
+			got = FoafPerson{}
+			require.NoError(t, client.Get(mg.ContextWithReverseDepth(ctx, 0), &got, erin.UID))
+			assert.Equal(t, "Erin", got.Name)
+			assert.Empty(t, got.FriendsOf, "depth 0 should read no reverse edges")
+
+			// Without a reverse depth the whole chain comes back.
+			got = FoafPerson{}
+			require.NoError(t, client.Get(ctx, &got, erin.UID))
+			assert.Equal(t, []string{"Dave", "Carol", "Bob", "Alice"}, reverseChain(got))
+
+			// Query honors it too, and forward edges still expand fully.
+			var people []FoafPerson
+			err := client.Query(mg.ContextWithReverseDepth(ctx, 1), FoafPerson{}).
+				Filter(`eq(person_name, "Carol")`).Nodes(&people)
+			require.NoError(t, err)
+			require.Len(t, people, 1)
+			assert.Equal(t, []string{"Bob"}, reverseChain(people[0]))
+			require.Len(t, people[0].Friends, 1)
+			require.Len(t, people[0].Friends[0].Friends, 1)
+			assert.Equal(t, "Erin", people[0].Friends[0].Friends[0].Name)
+		})
+	}
+}
+
 func TestFriendOfFriendMutualFriends(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -0,0 +1,101 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// reverseDepthKey is the context key of a per-call reverse edge depth.
+type reverseDepthKey struct{}
+
+// ContextWithReverseDepth returns a copy of ctx under which Get, GetMap, and
+// Query expand managed reverse edges — fields tagged json:"~predicate" and
+// dgraph:"reverse" — exactly n levels deep: the node's reverse edges, the
+// reverse edges of the nodes they reach, and so on, n times. Beyond that
+// level reverse edge fields are left empty; n of 0 reads none. Forward edges
+// still expand to the client's WithMaxEdgeTraversal depth. Without it, reverse
+// edges expand as deep as forward ones.
+func ContextWithReverseDepth(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, reverseDepthKey{}, n)
+}
+
+// reverseDepthFromContext returns the depth ContextWithReverseDepth set on
+// ctx, if it set one that is not negative.
+func reverseDepthFromContext(ctx context.Context) (int, bool) {
+	n, ok := ctx.Value(reverseDepthKey{}).(int)
+	return n, ok && n >= 0
+}
+
+// expandAll selects every predicate of model on q to the client's edge
+// traversal depth, bounding its reverse edges to the depth ctx carries, if
+// any.
+func (c client) expandAll(ctx context.Context, q *dg.Query, model any) *dg.Query {
+	q.All(c.options.maxEdgeTraversal)
+	if n, ok := reverseDepthFromContext(ctx); ok {
+		q.Query(reverseDepthSelection(model, c.options.tagName, c.options.maxEdgeTraversal, n))
+	}
+	return q
+}
+
+// reverseDepthSelection renders the selection dgman's All(depth) renders for
+// model, but with its managed reverse edges nested reverse levels deep rather
+// than depth. Directives are read from the dgraph tag and altTag.
+func reverseDepthSelection(model any, altTag string, depth, reverse int) string {
+	var b strings.Builder
+	b.WriteString("{\n")
+	writeExpandAll(&b, depth, 1)
+	writeReverseBlocks(&b, reflect.TypeOf(model), altTag, depth, reverse, 1)
+	b.WriteString("}")
+	return b.String()
+}
+
+// writeExpandAll writes uid, dgraph.type, and expand(_all_), with forward
+// edges expanded depth levels below it, at indent tabs.
+func writeExpandAll(b *strings.Builder, depth, indent int) {
+	tabs := strings.Repeat("\t", indent)
+	b.WriteString(tabs + "uid\n")
+	b.WriteString(tabs + "dgraph.type\n")
+	b.WriteString(tabs + "expand(_all_)")
+	if depth > 0 {
+		b.WriteString(" {\n")
+		writeExpandAll(b, depth-1, indent+1)
+		b.WriteString(tabs + "}")
+	}
+	b.WriteString("\n")
+}
+
+// writeReverseBlocks writes a block for each managed reverse edge of t, on to
+// the given remaining reverse levels; forward edges inside each shrink with
+// depth as dgman's do.
+func writeReverseBlocks(b *strings.Builder, t reflect.Type, altTag string, depth, reverse, indent int) {
+	if reverse <= 0 {
+		return
+	}
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	tabs := strings.Repeat("\t", indent)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		directives := fieldDirectives(field, altTag)
+		pred := predicateName(field, directives)
+		if !strings.HasPrefix(pred, "~") || !hasDirective(directives, "reverse") {
+			continue
+		}
+		b.WriteString(tabs + pred + " {\n")
+		writeExpandAll(b, max(depth-1, 0), indent+1)
+		writeReverseBlocks(b, field.Type, altTag, max(depth-1, 0), reverse-1, indent+1)
+		b.WriteString(tabs + "}\n")
+	}
+}
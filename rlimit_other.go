@@ -0,0 +1,14 @@
+//go:build !linux && !darwin
+
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+// ensureOpenFilesLimit leaves the open-file limit alone on platforms
+// where the engine does not manage it.
+func ensureOpenFilesLimit(n uint64) error {
+	return nil
+}
@@ -0,0 +1,33 @@
+//go:build linux || darwin
+
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// ensureOpenFilesLimit raises the process's soft open-file limit to n if it
+// is lower, failing if the hard limit does not allow n.
+func ensureOpenFilesLimit(n uint64) error {
+	var lim syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &lim); err != nil {
+		return fmt.Errorf("error reading the open-file limit: %w", err)
+	}
+	if uint64(lim.Cur) >= n {
+		return nil
+	}
+	if uint64(lim.Max) < n {
+		return fmt.Errorf("%w: want %d, hard limit is %d", ErrOpenFilesLimit, n, lim.Max)
+	}
+	lim.Cur = n
+	if err := syscall.Setrlimit(syscall.RLIMIT_NOFILE, &lim); err != nil {
+		return fmt.Errorf("error raising the open-file limit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+//go:build linux || darwin
+
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+// setSoftOpenFilesLimit sets the process's soft open-file limit for the rest
+// of the test, restoring the original limit when it ends.
+func setSoftOpenFilesLimit(t *testing.T, n uint64) syscall.Rlimit {
+	t.Helper()
+	var orig syscall.Rlimit
+	require.NoError(t, syscall.Getrlimit(syscall.RLIMIT_NOFILE, &orig))
+	t.Cleanup(func() {
+		require.NoError(t, syscall.Setrlimit(syscall.RLIMIT_NOFILE, &orig))
+	})
+	lim := orig
+	lim.Cur = min(n, orig.Max)
+	require.NoError(t, syscall.Setrlimit(syscall.RLIMIT_NOFILE, &lim))
+	return lim
+}
+
+func TestEngineWithLowOpenFilesLimit(t *testing.T) {
+	ctx := context.Background()
+	setSoftOpenFilesLimit(t, 256)
+
+	conf := NewDefaultConfig(t.TempDir()).
+		WithMaxOpenFiles(128).
+		WithNumMemtables(1).
+		WithDirMode(0o750)
+	engine, err := NewEngine(conf)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	for _, dir := range []string{conf.postingDir(), conf.walDir(), conf.tmpDir()} {
+		info, err := os.Stat(dir)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o750), info.Mode().Perm(), dir)
+	}
+
+	var lim syscall.Rlimit
+	require.NoError(t, syscall.Getrlimit(syscall.RLIMIT_NOFILE, &lim))
+	require.EqualValues(t, 256, lim.Cur, "a limit above max open files is left alone")
+
+	ns := engine.GetDefaultNamespace()
+	require.NoError(t, ns.AlterSchema(ctx, "name: string @index(exact) ."))
+	_, err = ns.Mutate(ctx, []*api.Mutation{{
+		Set: []*api.NQuad{{
+			Subject:     "_:a",
+			Predicate:   "name",
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: "A"}},
+		}},
+	}})
+	require.NoError(t, err)
+	resp, err := ns.Query(ctx, `{ q(func: eq(name, "A")) { name } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"q":[{"name":"A"}]}`, string(resp.GetJson()))
+}
+
+func TestEngineRaisesOpenFilesLimit(t *testing.T) {
+	lim := setSoftOpenFilesLimit(t, 128)
+	if lim.Max < 512 {
+		t.Skipf("hard open-file limit %d is too low to raise the soft limit", lim.Max)
+	}
+
+	engine, err := NewEngine(NewDefaultConfig(t.TempDir()).WithMaxOpenFiles(512))
+	require.NoError(t, err)
+	defer engine.Close()
+
+	require.NoError(t, syscall.Getrlimit(syscall.RLIMIT_NOFILE, &lim))
+	require.EqualValues(t, 512, lim.Cur, "the soft limit is raised to max open files")
+}
+
+func TestEngineOpenFilesLimitAboveHardLimit(t *testing.T) {
+	var lim syscall.Rlimit
+	require.NoError(t, syscall.Getrlimit(syscall.RLIMIT_NOFILE, &lim))
+	if lim.Max >= 1<<40 {
+		t.Skip("hard open-file limit is unlimited")
+	}
+
+	_, err := NewEngine(NewDefaultConfig(t.TempDir()).WithMaxOpenFiles(int(lim.Max) + 1))
+	require.ErrorIs(t, err, ErrOpenFilesLimit)
+
+	// The failed attempt does not hold the engine slot.
+	engine, err := NewEngine(NewDefaultConfig(t.TempDir()))
+	require.NoError(t, err)
+	engine.Close()
+}
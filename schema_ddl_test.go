@@ -8,6 +8,7 @@ package modusgraph_test
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/dgraph-io/dgo/v250/protos/api"
@@ -82,3 +83,129 @@ func TestDropPredicateEmbedded(t *testing.T) {
 		})
 	}
 }
+
+// ArchivedReport is a node type whose legacy_code field is being retired.
+type ArchivedReport struct {
+	UID        string   `json:"uid,omitempty"`
+	Title      string   `json:"report_title,omitempty" dgraph:"index=exact"`
+	LegacyCode string   `json:"legacy_code,omitempty" dgraph:"index=exact"`
+	DType      []string `json:"dgraph.type,omitempty"`
+}
+
+func TestClientDropPredicate(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "ClientDropPredicateWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "ClientDropPredicateWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			reports := []*ArchivedReport{
+				{Title: "Q1", LegacyCode: "A-1"},
+				{Title: "Q2", LegacyCode: "A-2"},
+			}
+			require.NoError(t, client.Insert(ctx, reports), "Insert should succeed")
+
+			require.NoError(t, client.DropPredicate(ctx, "legacy_code"), "DropPredicate should succeed")
+
+			has, err := client.HasPredicate(ctx, "legacy_code")
+			require.NoError(t, err)
+			require.False(t, has, "The dropped predicate should be gone from the schema")
+			has, err = client.HasPredicate(ctx, "report_title")
+			require.NoError(t, err)
+			require.True(t, has, "Other predicates should stay in the schema")
+
+			raw, err := client.QueryRaw(ctx, `{ q(func: has(report_title), orderasc: report_title) { report_title legacy_code } }`, nil)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"q": [{"report_title": "Q1"}, {"report_title": "Q2"}]}`, string(raw),
+				"Existing nodes should keep their other values and lose the dropped ones")
+
+			require.Error(t, client.DropPredicate(ctx, "dgraph.type"), "Pre-defined predicates cannot be dropped")
+			require.Error(t, client.DropPredicate(ctx, ""), "An empty predicate should be rejected")
+		})
+	}
+}
+
+func TestApplySchema(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "ApplySchemaWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "ApplySchemaWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+
+			err := client.ApplySchemaString(ctx, `
+				title: string @index(term) .
+				isbn: string @index(exact) @upsert .
+				type Book {
+					title
+					isbn
+				}
+			`)
+			require.NoError(t, err, "ApplySchemaString should succeed")
+
+			schema, err := client.GetSchema(ctx)
+			require.NoError(t, err, "GetSchema should succeed")
+			require.Contains(t, schema, "type Book", "type from the raw schema should be present")
+
+			raw, err := client.QueryRaw(ctx, `schema(pred: [title, isbn]) { type index tokenizer }`, nil)
+			require.NoError(t, err, "schema query should succeed")
+			require.Contains(t, string(raw), `"predicate":"title"`)
+			require.Contains(t, string(raw), `"predicate":"isbn"`)
+			require.Contains(t, string(raw), `"term"`, "title should carry its term index")
+
+			path := filepath.Join(t.TempDir(), "library.schema")
+			err = os.WriteFile(path, []byte("author: string @index(exact) .\ntype Author {\n\tauthor\n}\n"), 0600)
+			require.NoError(t, err)
+			require.NoError(t, client.ApplySchemaFile(ctx, path), "ApplySchemaFile should succeed")
+
+			schema, err = client.GetSchema(ctx)
+			require.NoError(t, err, "GetSchema should succeed")
+			require.Contains(t, schema, "type Author", "type from the schema file should be present")
+
+			err = client.ApplySchemaFile(ctx, filepath.Join(t.TempDir(), "missing.schema"))
+			require.ErrorIs(t, err, os.ErrNotExist, "a missing schema file should surface the read error")
+		})
+	}
+}
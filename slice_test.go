@@ -390,3 +390,79 @@ func TestValueTypeSliceEmpty(t *testing.T) {
 		})
 	}
 }
+
+// TestValueTypeSliceUIDBackfill tests that inserting a value-type slice, at the
+// top level and nested within its elements, fills every element's UID in place.
+func TestValueTypeSliceUIDBackfill(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "ValueTypeSliceUIDBackfillWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "ValueTypeSliceUIDBackfillWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+
+			// A top-level []Genre, passed by value rather than by pointer.
+			genres := []Genre{{Name: "Drama"}, {Name: "Comedy"}, {Name: "Horror"}}
+			err := client.Insert(ctx, genres)
+			require.NoError(t, err, "Insert of []Genre should succeed")
+
+			uids := make(map[string]bool)
+			for i, g := range genres {
+				require.NotEmpty(t, g.UID, "Genre[%d] UID should be backfilled", i)
+				uids[g.UID] = true
+
+				var retrieved Genre
+				err = client.Get(ctx, &retrieved, g.UID)
+				require.NoError(t, err)
+				assert.Equal(t, g.Name, retrieved.Name, "Genre[%d] UID should be its own node", i)
+			}
+			assert.Len(t, uids, len(genres), "each genre should get its own UID")
+
+			// A top-level []MovieWithValueSlice whose elements hold []Genre.
+			movies := []MovieWithValueSlice{
+				{Title: "Alien", Genres: []Genre{{Name: "Sci-Fi"}, {Name: "Thriller"}}},
+				{Title: "Heat", Genres: []Genre{{Name: "Crime"}}},
+			}
+			err = client.Insert(ctx, movies)
+			require.NoError(t, err, "Insert of []MovieWithValueSlice should succeed")
+
+			for i, m := range movies {
+				require.NotEmpty(t, m.UID, "Movie[%d] UID should be backfilled", i)
+				var retrieved MovieWithValueSlice
+				err = client.Get(ctx, &retrieved, m.UID)
+				require.NoError(t, err)
+
+				stored := make(map[string]string)
+				for _, g := range retrieved.Genres {
+					stored[g.Name] = g.UID
+				}
+				for j, g := range m.Genres {
+					require.NotEmpty(t, g.UID, "Movie[%d].Genres[%d] UID should be backfilled", i, j)
+					assert.Equal(t, stored[g.Name], g.UID,
+						"Movie[%d].Genres[%d] UID should match the stored edge", i, j)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/dgraph-io/dgraph/v25/schema"
+	"github.com/dgraph-io/dgraph/v25/worker"
+	"github.com/dgraph-io/dgraph/v25/x"
+)
+
+// ErrSnapshotDirNotEmpty is returned by Snapshot when the target directory
+// already holds files.
+var ErrSnapshotDirNotEmpty = errors.New("snapshot directory must be empty")
+
+// Snapshot writes a consistent, point-in-time copy of the engine's data to
+// dir, which must be empty or not yet exist. The copy holds every write
+// committed before Snapshot was called and none after, and it opens as an
+// engine of its own with NewEngine(NewDefaultConfig(dir)).
+//
+// Queries and mutations keep running while the snapshot is written; schema
+// changes, RunGC, DropAll, DropData, dropping a predicate and Close wait for
+// it, as they rewrite or discard the versions it reads. A snapshot started
+// while an index is being built waits for the build to finish.
+func (engine *Engine) Snapshot(ctx context.Context, dir string) error {
+	engine.snapshotMu.RLock()
+	defer engine.snapshotMu.RUnlock()
+
+	if err := checkSnapshotDir(dir); err != nil {
+		return err
+	}
+	readTs, err := engine.snapshotTs(ctx)
+	if err != nil {
+		return err
+	}
+
+	postingDir := filepath.Join(dir, "p")
+	opt := badger.DefaultOptions(postingDir).
+		WithNumVersionsToKeep(math.MaxInt32).
+		WithNamespaceOffset(x.NamespaceOffset).
+		WithLogger(nil)
+	db, err := badger.OpenManaged(opt)
+	if err != nil {
+		return fmt.Errorf("error opening snapshot store: %w", err)
+	}
+
+	writer := db.NewStreamWriter()
+	if err := writer.Prepare(); err != nil {
+		_ = db.Close()
+		return fmt.Errorf("error preparing snapshot store: %w", err)
+	}
+	stream := worker.State.Pstore.NewStreamAt(readTs)
+	stream.LogPrefix = "modusGraph.Snapshot"
+	stream.Send = writer.Write
+	if err := stream.Orchestrate(ctx); err != nil {
+		writer.Cancel()
+		_ = db.Close()
+		return fmt.Errorf("error streaming snapshot: %w", err)
+	}
+	if err := writer.Flush(); err != nil {
+		_ = db.Close()
+		return fmt.Errorf("error writing snapshot: %w", err)
+	}
+	return db.Close()
+}
+
+// snapshotTs returns the timestamp to read a snapshot at, once no index is
+// being built: a schema change that adds an index writes the predicate's
+// schema only when its background rebuild completes.
+func (engine *Engine) snapshotTs(ctx context.Context) (uint64, error) {
+	for {
+		engine.mutex.RLock()
+		if !engine.isOpen.Load() {
+			engine.mutex.RUnlock()
+			return 0, ErrClosedEngine
+		}
+		if !schema.State().IndexingInProgress() {
+			readTs := engine.z.readTs()
+			engine.mutex.RUnlock()
+			return readTs, nil
+		}
+		engine.mutex.RUnlock()
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// checkSnapshotDir ensures dir is either absent or an empty directory.
+func checkSnapshotDir(dir string) error {
+	if dir == "" {
+		return ErrEmptyDataDir
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading snapshot directory: %w", err)
+	}
+	if len(entries) > 0 {
+		return ErrSnapshotDirNotEmpty
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngineSnapshot(t *testing.T) {
+	ctx := context.Background()
+	engine, err := modusgraph.NewEngine(modusgraph.NewDefaultConfig(t.TempDir()))
+	require.NoError(t, err)
+	defer func() { engine.Close() }()
+
+	ns := engine.GetDefaultNamespace()
+	require.NoError(t, ns.AlterSchema(ctx, "name: string @index(exact) ."))
+	setName := func(subject, name string) {
+		_, err := ns.Mutate(ctx, []*api.Mutation{{
+			Set: []*api.NQuad{{
+				Subject:     subject,
+				Predicate:   "name",
+				ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: name}},
+			}},
+		}})
+		require.NoError(t, err)
+	}
+	setName("_:a", "A")
+	setName("_:b", "B")
+
+	snapDir := filepath.Join(t.TempDir(), "snap")
+	require.NoError(t, engine.Snapshot(ctx, snapDir))
+
+	// Writes after the snapshot are not part of it.
+	setName("_:c", "C")
+
+	require.ErrorIs(t, engine.Snapshot(ctx, snapDir), modusgraph.ErrSnapshotDirNotEmpty)
+
+	query := `{ me(func: has(name), orderasc: name) { name } }`
+	resp, err := ns.Query(ctx, query)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"me":[{"name":"A"},{"name":"B"},{"name":"C"}]}`, string(resp.GetJson()))
+
+	engine.Close()
+	require.ErrorIs(t, engine.Snapshot(ctx, t.TempDir()), modusgraph.ErrClosedEngine)
+
+	engine, err = modusgraph.NewEngine(modusgraph.NewDefaultConfig(snapDir))
+	require.NoError(t, err)
+	ns = engine.GetDefaultNamespace()
+
+	resp, err = ns.Query(ctx, query)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"me":[{"name":"A"},{"name":"B"}]}`, string(resp.GetJson()))
+
+	// The index came along with the data, and the snapshot accepts new writes.
+	setName("_:d", "D")
+	resp, err = ns.Query(ctx, `{ me(func: eq(name, "D")) { name } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"me":[{"name":"D"}]}`, string(resp.GetJson()))
+
+	_, err = os.Stat(filepath.Join(snapDir, "p"))
+	require.NoError(t, err)
+}
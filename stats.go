@@ -0,0 +1,55 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"github.com/dgraph-io/dgraph/v25/worker"
+)
+
+// EngineStats is a point-in-time view of an engine's internal state, as
+// returned by Engine.Stats.
+type EngineStats struct {
+	// Open reports whether the engine is open. The other fields are zero once
+	// it is closed.
+	Open bool
+
+	// ReadTs is the timestamp read-only queries currently run at: that of the
+	// latest commit.
+	ReadTs uint64
+
+	// LastUID is the highest UID handed out to a node so far.
+	LastUID uint64
+
+	// Namespaces is the number of namespaces created, the default one
+	// included.
+	Namespaces uint64
+
+	// LSMSize and VlogSize are the bytes Badger holds in the LSM tree and the
+	// value log of the posting store.
+	LSMSize  int64
+	VlogSize int64
+}
+
+// Stats returns the engine's current read timestamp, UID and namespace
+// allocation, and storage sizes, for health checks and monitoring. It is cheap
+// enough to poll and never fails; a closed engine reports Open false.
+func (engine *Engine) Stats() EngineStats {
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+
+	if !engine.isOpen.Load() {
+		return EngineStats{}
+	}
+	lsm, vlog := worker.State.Pstore.Size()
+	return EngineStats{
+		Open:       true,
+		ReadTs:     engine.z.readTs(),
+		LastUID:    engine.z.minLeasedUID - 1,
+		Namespaces: engine.z.lastNamespace + 1,
+		LSMSize:    lsm,
+		VlogSize:   vlog,
+	}
+}
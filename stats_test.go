@@ -0,0 +1,53 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngineStats(t *testing.T) {
+	ctx := context.Background()
+	engine, err := modusgraph.NewEngine(modusgraph.NewDefaultConfig(t.TempDir()))
+	require.NoError(t, err)
+	defer engine.Close()
+
+	before := engine.Stats()
+	require.True(t, before.Open)
+	require.EqualValues(t, 1, before.Namespaces, "A new engine should hold only the default namespace")
+
+	ns, err := engine.CreateNamespace()
+	require.NoError(t, err)
+	require.NoError(t, ns.AlterSchema(ctx, "label: string ."))
+	var nquads strings.Builder
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(&nquads, "_:n%d <label> \"node %d\" .\n", i, i)
+	}
+	uids, err := ns.Mutate(ctx, []*api.Mutation{{SetNquads: []byte(nquads.String())}})
+	require.NoError(t, err)
+	require.Len(t, uids, 10)
+
+	after := engine.Stats()
+	require.True(t, after.Open)
+	require.EqualValues(t, 2, after.Namespaces, "The created namespace should be counted")
+	require.GreaterOrEqual(t, after.LastUID, before.LastUID+10, "Ten new nodes should advance the last UID")
+	for _, uid := range uids {
+		require.LessOrEqual(t, uid, after.LastUID)
+	}
+	require.Greater(t, after.ReadTs, before.ReadTs, "The commit should advance the read timestamp")
+	require.GreaterOrEqual(t, after.LSMSize, int64(0))
+	require.GreaterOrEqual(t, after.VlogSize, int64(0))
+
+	engine.Close()
+	require.Equal(t, modusgraph.EngineStats{}, engine.Stats(), "A closed engine should report only that it is closed")
+}
@@ -0,0 +1,343 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/dgraph-io/dgo/v250"
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// fieldDirectives returns the dgraph directives declared on field. The
+// `dgraph` tag is always read; when altTag is non-empty, directives from that
+// tag (for example the `db:"constraint=unique"` convention used by the modusDB
+// API) are translated and appended, so both conventions resolve through the
+// same path.
+func fieldDirectives(field reflect.StructField, altTag string) string {
+	tag := field.Tag.Get("dgraph")
+	if altTag == "" || altTag == "dgraph" {
+		return tag
+	}
+	alt := translateAltTag(field.Tag.Get(altTag))
+	switch {
+	case alt == "":
+		return tag
+	case tag == "":
+		return alt
+	default:
+		return tag + " " + alt
+	}
+}
+
+// translateAltTag rewrites an alternate-tag value into dgraph tag directives.
+// Tokens may be separated by commas or spaces. The modusDB `constraint=<name>`
+// form maps onto the directive of the same name (constraint=unique becomes
+// unique); every other token is passed through verbatim.
+func translateAltTag(tag string) string {
+	if tag == "" {
+		return ""
+	}
+	tokens := strings.FieldsFunc(tag, func(r rune) bool { return r == ',' || r == ' ' })
+	out := make([]string, 0, len(tokens))
+	for _, tok := range tokens {
+		if c, ok := strings.CutPrefix(tok, "constraint="); ok {
+			tok = c
+		}
+		out = append(out, tok)
+	}
+	return strings.Join(out, " ")
+}
+
+// createTaggedSchema is dg.CreateSchema for models carrying tags dgman does
+// not understand on its own: directives in an alternate tag (see WithTagName),
+// computed alias fields (dgraph:"alias=computed") and facet sidecars
+// (dgraph:"facets"), which hold query-time values rather than stored
+// predicates.
+//
+// The alternate-tag directives (indexes, @unique, @upsert, ...) are overlaid
+// on dgman's schema before it is applied. They have to go out in the same
+// alter: a follow-up alter would add indexes to predicates that already exist,
+// which forces a reindex, and the next plain CreateSchema would strip them
+// again. As with dgman, predicates the database already reports are left
+// untouched.
+//
+// When profile is non-empty, indexes restricted to other profiles are dropped;
+// see WithIndexProfile. When sorted is set the schema is rendered in name
+// order; see typeSchemaString.
+func createTaggedSchema(ctx context.Context, dgClient *dgo.Dgraph, altTag, profile string, sorted bool,
+	naming NamingStrategy, models ...any) error {
+	ts := dg.NewTypeSchema()
+	ts.Marshal("", models...)
+
+	for _, model := range models {
+		if err := requireDType(reflect.TypeOf(model)); err != nil {
+			return err
+		}
+	}
+	if altTag != "" && altTag != "dgraph" {
+		overlayAltTag(ts.Schema, altTag, models...)
+	}
+	if profile != "" {
+		dropProfileIndexes(ts.Schema, altTag, profile, models...)
+	}
+	for _, pred := range computedPredicates(models...) {
+		delete(ts.Schema, pred)
+		for _, fields := range ts.Types {
+			delete(fields, pred)
+		}
+	}
+	naming.deriveTypeSchema(ts)
+
+	existing, err := existingPredicates(ctx, dgClient)
+	if err != nil {
+		return err
+	}
+	for _, pred := range existing {
+		delete(ts.Schema, pred)
+	}
+
+	return dgClient.Alter(ctx, &api.Operation{Schema: typeSchemaString(ts, sorted)})
+}
+
+// typeSchemaString renders ts as dgman does, or, when sorted, with the
+// predicates, the types, and the fields of each type in name order, so the
+// same models always render the same schema text. dgman ranges over maps, so
+// its order changes from one call to the next.
+func typeSchemaString(ts *dg.TypeSchema, sorted bool) string {
+	if !sorted {
+		return ts.String()
+	}
+	var b strings.Builder
+	for _, pred := range slices.Sorted(maps.Keys(ts.Schema)) {
+		b.WriteString(ts.Schema[pred].String())
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(typeMapString(ts.Types, true))
+	return b.String()
+}
+
+// typeMapString renders types as dgman's TypeMap.String does, in name order
+// when sorted is set.
+func typeMapString(types dg.TypeMap, sorted bool) string {
+	if !sorted {
+		return types.String()
+	}
+	var b strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(types)) {
+		b.WriteString("type ")
+		b.WriteString(name)
+		b.WriteString(" {\n")
+		for _, pred := range slices.Sorted(maps.Keys(types[name])) {
+			b.WriteString("\t")
+			b.WriteString(pred)
+			b.WriteString("\n")
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// overlayAltTag applies the altTag directives of models, and of the edge
+// types they reference, onto the matching predicates in schema.
+func overlayAltTag(schema dg.SchemaMap, altTag string, models ...any) {
+	walkFields(func(field reflect.StructField) {
+		directives := translateAltTag(field.Tag.Get(altTag))
+		if directives == "" {
+			return
+		}
+		if s, ok := schema[predicateName(field, fieldDirectives(field, altTag))]; ok {
+			applyDirectives(s, directives)
+		}
+	}, models...)
+}
+
+// dropProfileIndexes removes from schema the indexes of the fields of models,
+// and of the edge types they reference, whose profile= directive does not
+// name profile.
+func dropProfileIndexes(schema dg.SchemaMap, altTag, profile string, models ...any) {
+	walkFields(func(field reflect.StructField) {
+		directives := fieldDirectives(field, altTag)
+		profiles, ok := indexProfiles(directives)
+		if !ok || slices.Contains(profiles, profile) {
+			return
+		}
+		if s, ok := schema[predicateName(field, directives)]; ok {
+			s.Index = false
+			s.Tokenizer = nil
+		}
+	}, models...)
+}
+
+// indexProfiles returns the profiles a profile= directive in directives names,
+// and whether directives has one.
+func indexProfiles(directives string) ([]string, bool) {
+	for _, tok := range strings.Fields(directives) {
+		if value, ok := strings.CutPrefix(tok, "profile="); ok {
+			return strings.Split(value, ","), true
+		}
+	}
+	return nil, false
+}
+
+// computedPredicates returns the names of the computed alias fields and facet
+// sidecars declared across models and the edge types they reference.
+func computedPredicates(models ...any) []string {
+	var preds []string
+	walkFields(func(field reflect.StructField) {
+		switch {
+		case isComputedField(field):
+			preds = append(preds, predicateName(field, field.Tag.Get("dgraph")))
+		case isFacetsField(field):
+			// A sidecar is tagged json:"-", which dgman takes verbatim as the
+			// predicate name.
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			preds = append(preds, name)
+		}
+	}, models...)
+	return preds
+}
+
+// isComputedField reports whether field is tagged dgraph:"alias=...": a slot
+// for a value computed by the query (math(), val()) rather than a predicate.
+func isComputedField(field reflect.StructField) bool {
+	for _, tok := range strings.Fields(field.Tag.Get("dgraph")) {
+		if strings.HasPrefix(tok, "alias=") {
+			return true
+		}
+	}
+	return false
+}
+
+// isFacetsField reports whether field is tagged dgraph:"facets": a sidecar
+// that receives the facets of the edge a node was read over.
+func isFacetsField(field reflect.StructField) bool {
+	for _, tok := range strings.Fields(field.Tag.Get("dgraph")) {
+		if tok == "facets" {
+			return true
+		}
+	}
+	return false
+}
+
+// walkFields calls fn for every field of the struct types of models and,
+// recursively, of the struct types their fields reference. Each type is
+// visited once.
+func walkFields(fn func(reflect.StructField), models ...any) {
+	walkTypes(func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			fn(t.Field(i))
+		}
+	}, models...)
+}
+
+// walkTypes calls fn for each struct type of models and, recursively, of the
+// struct types their fields reference. Each type is visited once.
+func walkTypes(fn func(reflect.Type), models ...any) {
+	seen := make(map[reflect.Type]bool)
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || seen[t] {
+			return
+		}
+		seen[t] = true
+		fn(t)
+		for i := 0; i < t.NumField(); i++ {
+			walk(t.Field(i).Type)
+		}
+	}
+	for _, m := range models {
+		walk(reflect.TypeOf(m))
+	}
+}
+
+// requireDType mirrors dgman's check that a model declares its dgraph.type.
+func requireDType(t reflect.Type) error {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ","); strings.TrimSpace(name) == "dgraph.type" {
+			return nil
+		}
+	}
+	return fmt.Errorf("missing required field DType []string `json:\"dgraph.type\"` in type %s", t.Name())
+}
+
+// existingPredicates lists the predicates the database reports in its schema.
+func existingPredicates(ctx context.Context, dgClient *dgo.Dgraph) ([]string, error) {
+	resp, err := dgClient.NewReadOnlyTxn().Query(ctx, "schema { type }")
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Schema []struct {
+			Predicate string `json:"predicate"`
+		} `json:"schema"`
+	}
+	if err := json.Unmarshal(resp.GetJson(), &result); err != nil {
+		return nil, err
+	}
+	preds := make([]string, 0, len(result.Schema))
+	for _, s := range result.Schema {
+		preds = append(preds, s.Predicate)
+	}
+	return preds, nil
+}
+
+// applyDirectives sets the schema properties named by directives on s,
+// mirroring the subset of dgman's tag grammar that affects a predicate's
+// schema line.
+func applyDirectives(s *dg.Schema, directives string) {
+	for _, tok := range strings.Fields(directives) {
+		key, value, _ := strings.Cut(tok, "=")
+		switch key {
+		case "index":
+			s.Index = true
+			s.Tokenizer = strings.Split(value, ",")
+		case "unique":
+			s.Unique = true
+		case "upsert":
+			s.Upsert = true
+			s.Unique = true
+		case "reverse":
+			s.Reverse = true
+		case "count":
+			s.Count = true
+		case "lang":
+			s.Lang = true
+		case "noconflict":
+			s.Noconflict = true
+		}
+	}
+}
+
+// predicateName resolves the Dgraph predicate a field maps to: an explicit
+// predicate= directive wins, then the json tag name, then the Go field name.
+func predicateName(field reflect.StructField, directives string) string {
+	for _, directive := range strings.Fields(directives) {
+		if p, ok := strings.CutPrefix(directive, "predicate="); ok {
+			return p
+		}
+	}
+	if jsonTag := field.Tag.Get("json"); jsonTag != "" && jsonTag != "-" {
+		if name := strings.Split(jsonTag, ",")[0]; name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
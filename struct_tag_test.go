@@ -0,0 +1,182 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	dg "github.com/dolan-in/dgman/v2"
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+// DbTagEntity declares its constraints with the modusDB `db` tag instead of
+// the dgraph tag.
+type DbTagEntity struct {
+	UID   string   `json:"uid,omitempty"`
+	Email string   `json:"email,omitempty" db:"constraint=unique" dgraph:"index=exact"`
+	Name  string   `json:"dbName,omitempty" db:"index=term"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+func TestClientWithTagName(t *testing.T) {
+
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "TagNameWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "TagNameWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, err := modusgraph.NewClient(tc.uri, modusgraph.WithAutoSchema(true),
+				modusgraph.WithTagName("db"))
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, client.DropAll(context.Background()))
+				client.Close()
+				modusgraph.Shutdown()
+			}()
+
+			ctx := context.Background()
+			first := DbTagEntity{Email: "ada@example.com", Name: "Ada Lovelace"}
+			require.NoError(t, client.Insert(ctx, &first), "Insert should succeed")
+			require.NotEmpty(t, first.UID, "UID should be assigned")
+
+			second := DbTagEntity{Email: "ada@example.com", Name: "Someone Else"}
+			err = client.Insert(ctx, &second)
+			require.Error(t, err, "Insert should fail on the db-tag unique constraint")
+			var uniqueErr *modusgraph.UniqueError
+			require.True(t, errors.As(err, &uniqueErr), "Error should be a UniqueError")
+			if strings.HasPrefix(tc.uri, "file://") {
+				require.Equal(t, first.UID, uniqueErr.UID, "UID should match the first entity")
+			}
+
+			var found []DbTagEntity
+			err = client.Query(ctx, DbTagEntity{}).Filter(`anyofterms(dbName, "ada")`).Nodes(&found)
+			require.NoError(t, err, "term index from the db tag should be queryable")
+			require.Len(t, found, 1)
+		})
+	}
+}
+
+// DbTagAccount declares its unique key, version field, and managed reverse
+// edge with the db tag only.
+type DbTagAccount struct {
+	UID     string         `json:"uid,omitempty"`
+	Email   string         `json:"email,omitempty" db:"constraint=unique" dgraph:"index=exact"`
+	Version int            `json:"version,omitempty" db:"version"`
+	Holders []*DbTagHolder `json:"~account,omitempty" db:"reverse"`
+	DType   []string       `json:"dgraph.type,omitempty"`
+}
+
+// DbTagHolder points at a DbTagAccount over an edge declared @reverse with
+// the db tag.
+type DbTagHolder struct {
+	UID     string        `json:"uid,omitempty"`
+	Label   string        `json:"label,omitempty"`
+	Account *DbTagAccount `json:"account,omitempty" db:"reverse"`
+	DType   []string      `json:"dgraph.type,omitempty"`
+}
+
+func TestClientWithTagNameDirectives(t *testing.T) {
+	ctx := context.Background()
+	client, err := modusgraph.NewClient("file://"+GetTempDir(t), modusgraph.WithAutoSchema(true),
+		modusgraph.WithTagName("db"))
+	require.NoError(t, err)
+	defer func() {
+		client.Close()
+		modusgraph.Shutdown()
+	}()
+
+	account := DbTagAccount{Email: "grace@example.com"}
+	require.NoError(t, client.Insert(ctx, &account))
+
+	// A reference by the db-tag unique value links to the existing node
+	holder := DbTagHolder{Label: "primary", Account: &DbTagAccount{Email: "grace@example.com"}}
+	require.NoError(t, client.Insert(ctx, &holder))
+	require.Equal(t, account.UID, holder.Account.UID, "The reference should link to the existing account")
+	var accounts []DbTagAccount
+	require.NoError(t, client.Query(ctx, DbTagAccount{}).Nodes(&accounts))
+	require.Len(t, accounts, 1, "No duplicate account should be inserted")
+
+	// The db-tag version field locks updates
+	var fresh, stale DbTagAccount
+	require.NoError(t, client.Get(ctx, &fresh, account.UID))
+	require.NoError(t, client.Get(ctx, &stale, account.UID))
+	require.NoError(t, client.Update(ctx, &fresh))
+	require.Equal(t, 1, fresh.Version, "Update should increment the db-tag version")
+	require.ErrorIs(t, client.Update(ctx, &stale), modusgraph.ErrVersionConflict)
+
+	// The db-tag managed reverse edge is read at the context's depth
+	var got DbTagAccount
+	require.NoError(t, client.Get(modusgraph.ContextWithReverseDepth(ctx, 1), &got, account.UID))
+	require.Len(t, got.Holders, 1, "The managed reverse edge should be read")
+	require.Equal(t, "primary", got.Holders[0].Label)
+}
+
+// ProfiledDoc restricts its vector index to the "prod" index profile.
+type ProfiledDoc struct {
+	UID       string            `json:"uid,omitempty"`
+	Title     string            `json:"profiled_title,omitempty" dgraph:"index=term"`
+	Embedding *dg.VectorFloat32 `json:"profiled_embedding,omitempty" dgraph:"index=hnsw(metric:\"cosine\") profile=prod"`
+	DType     []string          `json:"dgraph.type,omitempty"`
+}
+
+func TestClientWithIndexProfile(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		profile string
+		hnsw    bool
+	}{
+		{name: "ProdProfileBuildsVectorIndex", profile: "prod", hnsw: true},
+		{name: "TestProfileSkipsVectorIndex", profile: "test", hnsw: false},
+		{name: "NoProfileBuildsVectorIndex", profile: "", hnsw: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := modusgraph.NewClient("file://"+GetTempDir(t),
+				modusgraph.WithIndexProfile(tc.profile))
+			require.NoError(t, err)
+			defer func() {
+				client.Close()
+				modusgraph.Shutdown()
+			}()
+
+			ctx := context.Background()
+			require.NoError(t, client.UpdateSchema(ctx, &ProfiledDoc{}))
+			schema, err := client.GetSchema(ctx)
+			require.NoError(t, err)
+
+			require.Contains(t, schema, "profiled_embedding", "the predicate is declared under every profile")
+			require.Equal(t, tc.hnsw, strings.Contains(schema, "hnsw"),
+				"vector index under profile %q:\n%s", tc.profile, schema)
+			require.Contains(t, schema, "profiled_title: string @index(term)",
+				"indexes without a profile apply under every profile")
+		})
+	}
+}
@@ -0,0 +1,244 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/dgraph-io/dgo/v250"
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// ErrorTranslator maps an error returned by a Client method to the error the
+// caller receives. It is only called with non-nil errors.
+type ErrorTranslator func(error) error
+
+// translatingClient is the Client NewClient returns when WithErrorTranslator
+// is set. It passes every error the wrapped client returns through the
+// translator, so translation happens once, at the boundary: the client's own
+// methods, which call each other and inspect errors such as UniqueError and
+// aborted transactions, keep seeing the backend's errors. Query and
+// ModifiedSince return builders whose errors come from dgman, and WithRetry
+// returns fn's own error, so those are promoted unchanged.
+type translatingClient struct {
+	client
+}
+
+func (c translatingClient) translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	return c.options.errorTranslator(err)
+}
+
+func (c translatingClient) Insert(ctx context.Context, obj any) error {
+	return c.translate(c.client.Insert(ctx, obj))
+}
+
+func (c translatingClient) InsertRaw(ctx context.Context, obj any) error {
+	return c.translate(c.client.InsertRaw(ctx, obj))
+}
+
+func (c translatingClient) Upsert(ctx context.Context, obj any, predicates ...string) error {
+	return c.translate(c.client.Upsert(ctx, obj, predicates...))
+}
+
+func (c translatingClient) LoadOrStore(ctx context.Context, obj any, predicates ...string) (bool, error) {
+	loaded, err := c.client.LoadOrStore(ctx, obj, predicates...)
+	return loaded, c.translate(err)
+}
+
+func (c translatingClient) InsertIfAbsent(ctx context.Context, obj any) (bool, error) {
+	inserted, err := c.client.InsertIfAbsent(ctx, obj)
+	return inserted, c.translate(err)
+}
+
+func (c translatingClient) UpsertReturnOld(ctx context.Context, obj any, old any, predicates ...string) (bool, error) {
+	created, err := c.client.UpsertReturnOld(ctx, obj, old, predicates...)
+	return created, c.translate(err)
+}
+
+func (c translatingClient) UpsertIf(ctx context.Context, obj any, predicate, condition string) (bool, error) {
+	applied, err := c.client.UpsertIf(ctx, obj, predicate, condition)
+	return applied, c.translate(err)
+}
+
+func (c translatingClient) LoadAndDelete(ctx context.Context, obj any, key any, predicates ...string) (bool, error) {
+	loaded, err := c.client.LoadAndDelete(ctx, obj, key, predicates...)
+	return loaded, c.translate(err)
+}
+
+func (c translatingClient) DeleteAndReturn(ctx context.Context, obj any, uid string) error {
+	return c.translate(c.client.DeleteAndReturn(ctx, obj, uid))
+}
+
+func (c translatingClient) Increment(ctx context.Context, uid, predicate string, delta int64) (int64, error) {
+	n, err := c.client.Increment(ctx, uid, predicate, delta)
+	return n, c.translate(err)
+}
+
+func (c translatingClient) Update(ctx context.Context, obj any) error {
+	return c.translate(c.client.Update(ctx, obj))
+}
+
+func (c translatingClient) UpdateCount(ctx context.Context, obj any) (int, error) {
+	n, err := c.client.UpdateCount(ctx, obj)
+	return n, c.translate(err)
+}
+
+func (c translatingClient) UpdateWhere(ctx context.Context, model any, filter string,
+	patch map[string]any) (int, error) {
+	n, err := c.client.UpdateWhere(ctx, model, filter, patch)
+	return n, c.translate(err)
+}
+
+func (c translatingClient) Modify(ctx context.Context, obj any, uid string, fn func() error) error {
+	return c.translate(c.client.Modify(ctx, obj, uid, fn))
+}
+
+func (c translatingClient) Get(ctx context.Context, obj any, uid string) error {
+	return c.translate(c.client.Get(ctx, obj, uid))
+}
+
+func (c translatingClient) GetMap(ctx context.Context, uids []string, factory func() any) (map[string]any, error) {
+	nodes, err := c.client.GetMap(ctx, uids, factory)
+	return nodes, c.translate(err)
+}
+
+func (c translatingClient) QueryInterface(ctx context.Context, typeName string, resultFactory func() any) ([]any, error) {
+	results, err := c.client.QueryInterface(ctx, typeName, resultFactory)
+	return results, c.translate(err)
+}
+
+func (c translatingClient) MultiQuery(ctx context.Context, queries map[string]*dg.Query) (map[string]json.RawMessage, error) {
+	results, err := c.client.MultiQuery(ctx, queries)
+	return results, c.translate(err)
+}
+
+func (c translatingClient) Delete(ctx context.Context, uids []string) error {
+	return c.translate(c.client.Delete(ctx, uids))
+}
+
+func (c translatingClient) DeleteCount(ctx context.Context, uids []string) (int, error) {
+	n, err := c.client.DeleteCount(ctx, uids)
+	return n, c.translate(err)
+}
+
+func (c translatingClient) DeleteBy(ctx context.Context, model any, field, value string) (int, error) {
+	n, err := c.client.DeleteBy(ctx, model, field, value)
+	return n, c.translate(err)
+}
+
+func (c translatingClient) ImportGeoJSON(ctx context.Context, featureCollection []byte, typeName string) (int, error) {
+	n, err := c.client.ImportGeoJSON(ctx, featureCollection, typeName)
+	return n, c.translate(err)
+}
+
+func (c translatingClient) ExportRDF(ctx context.Context, w io.Writer) error {
+	return c.translate(c.client.ExportRDF(ctx, w))
+}
+
+func (c translatingClient) UpdateSchema(ctx context.Context, obj ...any) error {
+	return c.translate(c.client.UpdateSchema(ctx, obj...))
+}
+
+func (c translatingClient) RegisterModels(ctx context.Context, models ...any) error {
+	return c.translate(c.client.RegisterModels(ctx, models...))
+}
+
+func (c translatingClient) AlterSchema(ctx context.Context, schema string) error {
+	return c.translate(c.client.AlterSchema(ctx, schema))
+}
+
+func (c translatingClient) ApplySchemaString(ctx context.Context, schema string) error {
+	return c.translate(c.client.ApplySchemaString(ctx, schema))
+}
+
+func (c translatingClient) ApplySchemaFile(ctx context.Context, path string) error {
+	return c.translate(c.client.ApplySchemaFile(ctx, path))
+}
+
+func (c translatingClient) ValidateSchemaCompatibility(ctx context.Context,
+	models ...any) ([]Incompatibility, error) {
+	found, err := c.client.ValidateSchemaCompatibility(ctx, models...)
+	return found, c.translate(err)
+}
+
+func (c translatingClient) Introspect(ctx context.Context) (Introspection, error) {
+	in, err := c.client.Introspect(ctx)
+	return in, c.translate(err)
+}
+
+func (c translatingClient) ListPredicates(ctx context.Context) ([]string, error) {
+	names, err := c.client.ListPredicates(ctx)
+	return names, c.translate(err)
+}
+
+func (c translatingClient) HasPredicate(ctx context.Context, predicate string) (bool, error) {
+	ok, err := c.client.HasPredicate(ctx, predicate)
+	return ok, c.translate(err)
+}
+
+func (c translatingClient) GetSchema(ctx context.Context) (string, error) {
+	schema, err := c.client.GetSchema(ctx)
+	return schema, c.translate(err)
+}
+
+func (c translatingClient) DropAll(ctx context.Context) error {
+	return c.translate(c.client.DropAll(ctx))
+}
+
+func (c translatingClient) DropData(ctx context.Context) error {
+	return c.translate(c.client.DropData(ctx))
+}
+
+func (c translatingClient) DropPredicate(ctx context.Context, predicate string) error {
+	return c.translate(c.client.DropPredicate(ctx, predicate))
+}
+
+func (c translatingClient) QueryRaw(ctx context.Context, q string, vars map[string]string) ([]byte, error) {
+	data, err := c.client.QueryRaw(ctx, q, vars)
+	return data, c.translate(err)
+}
+
+func (c translatingClient) QueryRawWithMetrics(ctx context.Context, q string,
+	vars map[string]string) ([]byte, ResponseMetrics, error) {
+	data, metrics, err := c.client.QueryRawWithMetrics(ctx, q, vars)
+	return data, metrics, c.translate(err)
+}
+
+func (c translatingClient) ReadTs(ctx context.Context) (uint64, error) {
+	ts, err := c.client.ReadTs(ctx)
+	return ts, c.translate(err)
+}
+
+func (c translatingClient) QueryAt(ctx context.Context, readTs uint64, q string,
+	vars map[string]string) ([]byte, error) {
+	data, err := c.client.QueryAt(ctx, readTs, q, vars)
+	return data, c.translate(err)
+}
+
+func (c translatingClient) ShortestPath(ctx context.Context, from, to string, edge string,
+	maxDepth int) ([]string, error) {
+	path, err := c.client.ShortestPath(ctx, from, to, edge, maxDepth)
+	return path, c.translate(err)
+}
+
+func (c translatingClient) Tree(ctx context.Context, rootUID, childEdge string, maxDepth int, obj any) error {
+	return c.translate(c.client.Tree(ctx, rootUID, childEdge, maxDepth, obj))
+}
+
+func (c translatingClient) CountDistinct(ctx context.Context, model any, field string) (int, error) {
+	n, err := c.client.CountDistinct(ctx, model, field)
+	return n, c.translate(err)
+}
+
+func (c translatingClient) DgraphClient() (*dgo.Dgraph, func(), error) {
+	dgClient, cleanup, err := c.client.DgraphClient()
+	return dgClient, cleanup, c.translate(err)
+}
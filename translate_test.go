@@ -0,0 +1,60 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+// errNameTaken is the domain error the test's translator maps unique
+// constraint violations to.
+var errNameTaken = errors.New("name is already taken")
+
+func TestClientWithErrorTranslator(t *testing.T) {
+	ctx := context.Background()
+	var translated []error
+	translate := func(err error) error {
+		translated = append(translated, err)
+		var uniqueErr *modusgraph.UniqueError
+		if errors.As(err, &uniqueErr) {
+			return fmt.Errorf("%w: %w", errNameTaken, err)
+		}
+		return err
+	}
+	client, err := modusgraph.NewClient("file://"+GetTempDir(t), modusgraph.WithAutoSchema(true),
+		modusgraph.WithErrorTranslator(translate))
+	require.NoError(t, err)
+	defer func() {
+		client.Close()
+		modusgraph.Shutdown()
+	}()
+
+	require.NoError(t, client.Insert(ctx, &TestEntity{Name: "Taken"}))
+	require.Empty(t, translated, "A successful call should not invoke the translator")
+
+	err = client.Insert(ctx, &TestEntity{Name: "Taken"})
+	require.ErrorIs(t, err, errNameTaken, "The backend error should be translated")
+	var uniqueErr *modusgraph.UniqueError
+	require.ErrorAs(t, err, &uniqueErr, "A wrapping translator should keep the original error reachable")
+	require.Len(t, translated, 1)
+
+	// The client's own handling of the backend error is unaffected.
+	inserted, err := client.InsertIfAbsent(ctx, &TestEntity{Name: "Taken"})
+	require.NoError(t, err)
+	require.False(t, inserted)
+	require.Len(t, translated, 1, "Errors handled inside the client should not reach the translator")
+
+	err = client.Get(ctx, &TestEntity{}, "not-a-uid")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, errNameTaken)
+	require.Len(t, translated, 2, "Every returned error should pass through the translator")
+}
@@ -0,0 +1,116 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// Tree fetches the node rootUID and the subtree below it along childEdge
+// into obj, a pointer to a self-referential struct such as a person with a
+// Friends field, in a single @recurse query. Where Get expands edges to the
+// client's fixed WithMaxEdgeTraversal depth, Tree follows childEdge as deep
+// as the tree goes: maxDepth caps the levels below the root, and zero or less
+// leaves it unbounded. Each node is read only once, so a cycle ends the
+// branch that closes it. childEdge may be a managed reverse edge given with
+// its leading "~", to walk up from a child. Every level reads obj's scalar
+// fields; other edges are left empty. Like Get, Tree reads only nodes with a
+// dgraph.type, and returns dg.ErrNodeNotFound if rootUID names none.
+func (c client) Tree(ctx context.Context, rootUID, childEdge string, maxDepth int, obj any) error {
+	if err := checkPointer(obj); err != nil {
+		return err
+	}
+	uid, err := strconv.ParseUint(rootUID, 0, 64)
+	if err != nil {
+		return fmt.Errorf("Tree: invalid root UID %q", rootUID)
+	}
+	pred := childEdge
+	if len(pred) > 1 && pred[0] == '~' {
+		pred = pred[1:]
+	}
+	if !isValidPredicateName(pred) {
+		return fmt.Errorf("Tree: invalid child edge %q", childEdge)
+	}
+
+	// @recurse counts the root among the levels it returns
+	directive := "@recurse(loop: false)"
+	if maxDepth > 0 {
+		directive = fmt.Sprintf("@recurse(depth: %d, loop: false)", maxDepth+1)
+	}
+	var b strings.Builder
+	// As with dgman's reads, a node without a type, such as a deleted one,
+	// is not returned. Under @recurse the block's filter applies to the root
+	// alone, so the edge carries it for the levels below
+	fmt.Fprintf(&b, "{\n\ttree(func: uid(0x%x)) @filter(has(dgraph.type)) %s {\n\t\tuid\n\t\tdgraph.type\n",
+		uid, directive)
+	for _, p := range scalarPredicates(reflect.TypeOf(UnwrapSchema(obj)), c.options.tagName) {
+		b.WriteString("\t\t" + p + "\n")
+	}
+	b.WriteString("\t\t" + childEdge + " @filter(has(dgraph.type))\n\t}\n}")
+
+	data, err := c.queryRaw(ctx, b.String(), nil)
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Tree []json.RawMessage `json:"tree"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+	if len(resp.Tree) == 0 {
+		return dg.ErrNodeNotFound
+	}
+	if err := json.Unmarshal(resp.Tree[0], obj); err != nil {
+		return fmt.Errorf("Tree: decoding: %w", err)
+	}
+	return nil
+}
+
+// scalarPredicates returns the predicates of t's fields that hold values
+// rather than edges to other nodes, leaving out uid and dgraph.type.
+func scalarPredicates(t reflect.Type, altTag string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var preds []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("json") == "-" || isNodeType(field.Type) {
+			continue
+		}
+		pred := predicateName(field, fieldDirectives(field, altTag))
+		if pred == "uid" || pred == "dgraph.type" || strings.HasPrefix(pred, "~") {
+			continue
+		}
+		preds = append(preds, pred)
+	}
+	return preds
+}
+
+// isNodeType reports whether t, or the element type of a pointer or slice t,
+// is a struct with a uid field, which dgman stores as an edge.
+func isNodeType(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if strings.Split(t.Field(i).Tag.Get("json"), ",")[0] == "uid" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	dg "github.com/dolan-in/dgman/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// buildTree returns a person named name with two friends below it, and
+// theirs below them, down to levels levels in all.
+func buildTree(name string, levels int) *FoafPerson {
+	p := &FoafPerson{Name: name}
+	if levels > 1 {
+		for i := range 2 {
+			p.Friends = append(p.Friends, buildTree(fmt.Sprintf("%s.%d", name, i), levels-1))
+		}
+	}
+	return p
+}
+
+// treeNames returns the names in the tree under p, by level.
+func treeNames(p *FoafPerson) [][]string {
+	var levels [][]string
+	for level := []*FoafPerson{p}; len(level) > 0; {
+		var names []string
+		var next []*FoafPerson
+		for _, n := range level {
+			names = append(names, n.Name)
+			next = append(next, n.Friends...)
+		}
+		levels = append(levels, names)
+		level = next
+	}
+	return levels
+}
+
+func TestClientTree(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "TreeWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "TreeWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			ctx := context.Background()
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			root := buildTree("root", 5)
+			require.NoError(t, client.Insert(ctx, root))
+			want := treeNames(root)
+			require.Len(t, want, 5)
+
+			var got FoafPerson
+			require.NoError(t, client.Tree(ctx, root.UID, "friends", 0, &got))
+			require.Equal(t, root.UID, got.UID)
+			gotNames := treeNames(&got)
+			require.Len(t, gotNames, 5, "The whole tree should be fetched")
+			for i := range want {
+				require.ElementsMatch(t, want[i], gotNames[i], "level %d", i)
+			}
+
+			var top FoafPerson
+			require.NoError(t, client.Tree(ctx, root.UID, "friends", 2, &top))
+			require.Len(t, treeNames(&top), 3, "maxDepth should cap the levels below the root")
+
+			// The reverse edge walks from a leaf up to the root
+			leaf := root.Friends[1].Friends[0].Friends[1].Friends[0]
+			var up FoafPerson
+			require.NoError(t, client.Tree(ctx, leaf.UID, "~friends", 0, &up))
+			var chain []string
+			for n := &up; n != nil; {
+				chain = append(chain, n.Name)
+				if len(n.FriendsOf) == 0 {
+					break
+				}
+				n = n.FriendsOf[0]
+			}
+			require.Equal(t, []string{"root.1.0.1.0", "root.1.0.1", "root.1.0", "root.1", "root"}, chain)
+
+			var missing FoafPerson
+			require.ErrorIs(t, client.Tree(ctx, "0xfffffff", "friends", 0, &missing), dg.ErrNodeNotFound)
+			require.Error(t, client.Tree(ctx, root.UID, "friends { uid }", 0, &missing), "The edge must be a predicate name")
+			require.Error(t, client.Tree(ctx, "root", "friends", 0, &missing), "The root must be a UID")
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/dgraph-io/dgo/v250"
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// ErrTxnFinished is returned when an operation joins, or Commit or Discard is
+// called on, a PendingTxn that has already been committed or discarded.
+var ErrTxnFinished = errors.New("transaction already committed or discarded")
+
+type pendingTxnKey struct{}
+
+// PendingTxn is a transaction whose commit is deferred to an explicit Commit.
+// Mutating operations normally commit as they return; run with the context
+// DeferCommit returns, these instead add their mutations to the PendingTxn,
+// and other transactions see none of them until Commit succeeds:
+//
+//   - Insert, InsertRaw, InsertIfAbsent, LoadOrStore
+//   - Upsert, UpsertReturnOld, UpsertIf
+//   - Update, UpdateCount, UpdateWhere, Modify, Increment
+//   - Delete, DeleteCount, DeleteBy, DeleteAndReturn, LoadAndDelete
+//
+// Get and GetMap run with that context read through the PendingTxn, seeing
+// its snapshot and its uncommitted writes; wrap the context with ReadLatest,
+// or run with any other context, and they read the latest committed state as
+// usual. Every other operation ignores the PendingTxn: the queries read
+// committed state, and schema and drop operations, ImportGeoJSON among them,
+// apply at once.
+//
+// Dgraph rejects schema changes to predicates a pending transaction has
+// written, so operations in a PendingTxn do not apply WithAutoSchema; apply the
+// schema beforehand with UpdateSchema or WithSchema.
+//
+// A PendingTxn belongs to the first client that uses it and is not safe for
+// concurrent use. Operations that retry on conflict cannot retry a deferred
+// transaction: a conflict with a concurrent writer is reported by Commit. The
+// embedded engine does not check a commit for conflicts.
+type PendingTxn struct {
+	mu   sync.Mutex
+	pool *clientPool
+	dgo  *dgo.Dgraph
+	tx   *dg.TxnContext
+	done bool
+
+	// tsMu guards ts, the embedded engine's timestamp for the transaction; 0
+	// until it first reads or writes (see Engine.startPending).
+	tsMu sync.Mutex
+	ts   uint64
+}
+
+// DeferCommit returns a context whose mutating operations join a new
+// PendingTxn rather than committing, and that PendingTxn. Call Commit to make
+// the writes visible, or Discard to drop them; Discard after Commit is a no-op,
+// so it can be deferred.
+//
+//	txCtx, txn := modusgraph.DeferCommit(ctx)
+//	defer txn.Discard(ctx)
+//	if err := client.Insert(txCtx, &order); err != nil {
+//		return err
+//	}
+//	if err := client.Update(txCtx, &stock); err != nil {
+//		return err
+//	}
+//	return txn.Commit(ctx)
+func DeferCommit(ctx context.Context) (context.Context, *PendingTxn) {
+	p := &PendingTxn{}
+	return context.WithValue(ctx, pendingTxnKey{}, p), p
+}
+
+// pendingTxnFrom returns the PendingTxn ctx carries, or nil.
+func pendingTxnFrom(ctx context.Context) *PendingTxn {
+	p, _ := ctx.Value(pendingTxnKey{}).(*PendingTxn)
+	return p
+}
+
+type readLatestKey struct{}
+
+// ReadLatest returns a context whose Get and GetMap read the latest committed
+// state even when ctx carries a PendingTxn, rather than its snapshot and
+// uncommitted writes. Mutating operations run with it still join the
+// PendingTxn.
+//
+//	txCtx, txn := modusgraph.DeferCommit(ctx)
+//	// ...
+//	err := client.Get(modusgraph.ReadLatest(txCtx), &stock, uid)
+func ReadLatest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readLatestKey{}, true)
+}
+
+// pendingReadFrom returns the PendingTxn that Get and GetMap on ctx read
+// through, or nil when they read the latest commit.
+func pendingReadFrom(ctx context.Context) *PendingTxn {
+	if latest, _ := ctx.Value(readLatestKey{}).(bool); latest {
+		return nil
+	}
+	return pendingTxnFrom(ctx)
+}
+
+// join returns the transaction an operation on ctx runs in, starting it on a
+// connection from pool the first time.
+func (p *PendingTxn) join(ctx context.Context, pool *clientPool) (*dg.TxnContext, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		return nil, ErrTxnFinished
+	}
+	if p.tx == nil {
+		dgClient, err := pool.get()
+		if err != nil {
+			return nil, err
+		}
+		p.pool, p.dgo = pool, dgClient
+		p.tx = dg.NewTxnContext(ctx, dgClient)
+	} else if p.pool != pool {
+		return nil, errors.New("transaction belongs to another client")
+	}
+	p.tx.WithContext(ctx)
+	return p.tx, nil
+}
+
+// engineTs returns the transaction's timestamp on the embedded engine,
+// starting it there on first use.
+func (p *PendingTxn) engineTs(engine *Engine) (uint64, error) {
+	p.tsMu.Lock()
+	defer p.tsMu.Unlock()
+	if p.ts == 0 {
+		ts, err := engine.startPending()
+		if err != nil {
+			return 0, err
+		}
+		p.ts = ts
+	}
+	return p.ts, nil
+}
+
+// startedTs returns the transaction's embedded engine timestamp, or 0 if it
+// has not started there.
+func (p *PendingTxn) startedTs() uint64 {
+	p.tsMu.Lock()
+	defer p.tsMu.Unlock()
+	return p.ts
+}
+
+// Commit commits every mutation made in the transaction. An aborted commit,
+// from a conflict with a concurrent writer, wraps dgo.ErrAborted; the
+// transaction is finished either way.
+func (p *PendingTxn) Commit(ctx context.Context) error {
+	return p.finish(ctx, true)
+}
+
+// Discard drops every mutation made in the transaction.
+func (p *PendingTxn) Discard(ctx context.Context) error {
+	return p.finish(ctx, false)
+}
+
+func (p *PendingTxn) finish(ctx context.Context, commit bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		if commit {
+			return ErrTxnFinished
+		}
+		return nil
+	}
+	p.done = true
+	if p.tx == nil {
+		return nil
+	}
+	defer p.pool.put(p.dgo)
+	// The embedded engine finds the transaction to finish through ctx.
+	ctx = context.WithValue(ctx, pendingTxnKey{}, p)
+	if commit {
+		return p.tx.Txn().Commit(ctx)
+	}
+	return p.tx.Txn().Discard(ctx)
+}
@@ -0,0 +1,190 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientDeferCommit(t *testing.T) {
+
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "DeferCommitWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "DeferCommitWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			require.NoError(t, client.UpdateSchema(ctx, &TestEntity{}))
+			txCtx, txn := modusgraph.DeferCommit(ctx)
+			defer func() { _ = txn.Discard(ctx) }()
+
+			entity := TestEntity{Name: "Deferred", Description: "pending"}
+			require.NoError(t, client.Insert(txCtx, &entity), "Insert should join the pending transaction")
+			require.NotEmpty(t, entity.UID)
+
+			var got TestEntity
+			require.Error(t, client.Get(ctx, &got, entity.UID),
+				"A separate read should not see the uncommitted insert")
+			var entities []TestEntity
+			require.NoError(t, client.Query(ctx, TestEntity{}).Nodes(&entities))
+			require.Empty(t, entities, "A separate query should not see the uncommitted insert")
+
+			got = TestEntity{}
+			require.NoError(t, client.Get(txCtx, &got, entity.UID), "A read in the transaction should see its writes")
+			require.Equal(t, "pending", got.Description)
+
+			// ReadLatest reads the latest commit rather than the snapshot.
+			latestCtx := modusgraph.ReadLatest(txCtx)
+			require.Error(t, client.Get(latestCtx, &TestEntity{}, entity.UID),
+				"A ReadLatest read should not see the uncommitted insert")
+			// A write in its own PendingTxn skips WithAutoSchema, which Dgraph
+			// rejects while this transaction is pending.
+			laterCtx, laterTxn := modusgraph.DeferCommit(ctx)
+			later := TestEntity{Name: "Later"}
+			require.NoError(t, client.Insert(laterCtx, &later))
+			require.NoError(t, laterTxn.Commit(ctx))
+			require.Error(t, client.Get(txCtx, &TestEntity{}, later.UID),
+				"A read in the transaction should not see a later commit")
+			got = TestEntity{}
+			require.NoError(t, client.Get(latestCtx, &got, later.UID), "A ReadLatest read should see a later commit")
+			require.Equal(t, "Later", got.Name)
+			nodes, err := client.GetMap(latestCtx, []string{entity.UID, later.UID},
+				func() any { return &TestEntity{} })
+			require.NoError(t, err)
+			require.Len(t, nodes, 1)
+			require.Contains(t, nodes, later.UID)
+
+			entity.Description = "committed"
+			require.NoError(t, client.Update(latestCtx, &entity), "A write with ReadLatest should still join the transaction")
+
+			require.NoError(t, txn.Commit(ctx), "Commit should succeed")
+			got = TestEntity{}
+			require.NoError(t, client.Get(ctx, &got, entity.UID), "The insert should be visible after Commit")
+			require.Equal(t, "Deferred", got.Name)
+			require.Equal(t, "committed", got.Description)
+
+			require.ErrorIs(t, txn.Commit(ctx), modusgraph.ErrTxnFinished)
+			require.ErrorIs(t, client.Insert(txCtx, &TestEntity{Name: "Late"}), modusgraph.ErrTxnFinished,
+				"A finished transaction should not accept more writes")
+
+			// Discard drops the pending writes.
+			discardCtx, discarded := modusgraph.DeferCommit(ctx)
+			dropped := TestEntity{Name: "Dropped"}
+			require.NoError(t, client.Insert(discardCtx, &dropped))
+			require.NoError(t, discarded.Discard(ctx))
+			require.Error(t, client.Get(ctx, &TestEntity{}, dropped.UID), "A discarded insert should not be visible")
+		})
+	}
+}
+
+func TestClientDeferCommitDeletes(t *testing.T) {
+
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "DeferCommitDeletesWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "DeferCommitDeletesWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			entities := []*TestEntity{
+				{Name: "Kept", Description: "original"},
+				{Name: "ByKey", Description: "original"},
+				{Name: "Loaded", Description: "original"},
+				{Name: "Patched", Description: "original"},
+			}
+			require.NoError(t, client.Insert(ctx, entities))
+			page := PageCounter{Path: "/deferred"}
+			require.NoError(t, client.Insert(ctx, &page))
+
+			txCtx, txn := modusgraph.DeferCommit(ctx)
+			require.NoError(t, client.Delete(txCtx, []string{entities[0].UID}))
+			n, err := client.DeleteBy(txCtx, TestEntity{}, "name", "ByKey")
+			require.NoError(t, err)
+			require.Equal(t, 1, n)
+			var loaded TestEntity
+			ok, err := client.LoadAndDelete(txCtx, &loaded, "Loaded", "name")
+			require.NoError(t, err)
+			require.True(t, ok)
+			n, err = client.UpdateWhere(txCtx, TestEntity{}, `eq(name, "Patched")`,
+				map[string]any{"description": "patched"})
+			require.NoError(t, err)
+			require.Equal(t, 1, n)
+			views, err := client.Increment(txCtx, page.UID, "views", 7)
+			require.NoError(t, err)
+			require.Equal(t, int64(7), views)
+
+			require.Error(t, client.Get(txCtx, &TestEntity{}, entities[0].UID),
+				"A read in the transaction should see its delete")
+			var got TestEntity
+			require.NoError(t, client.Get(ctx, &got, entities[0].UID),
+				"A separate read should not see the uncommitted delete")
+
+			// Discard drops every deferred write.
+			require.NoError(t, txn.Discard(ctx))
+			for _, e := range entities {
+				got = TestEntity{}
+				require.NoError(t, client.Get(ctx, &got, e.UID), "%s should survive the discard", e.Name)
+				require.Equal(t, "original", got.Description)
+			}
+			var gotPage PageCounter
+			require.NoError(t, client.Get(ctx, &gotPage, page.UID))
+			require.Zero(t, gotPage.Views, "The discarded increment should not be visible")
+
+			// Committed, the deferred delete takes effect.
+			txCtx, txn = modusgraph.DeferCommit(ctx)
+			require.NoError(t, client.Delete(txCtx, []string{entities[0].UID}))
+			require.NoError(t, client.Get(ctx, &TestEntity{}, entities[0].UID))
+			require.NoError(t, txn.Commit(ctx))
+			require.Error(t, client.Get(ctx, &TestEntity{}, entities[0].UID), "The delete should apply on Commit")
+		})
+	}
+}
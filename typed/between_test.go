@@ -0,0 +1,47 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matthewmcneely/modusgraph/typed"
+	"github.com/matthewmcneely/modusgraph/typed/filter"
+)
+
+// logEntry carries an indexed timestamp.
+type logEntry struct {
+	UID      string    `json:"uid,omitempty"`
+	DType    []string  `json:"dgraph.type,omitempty"`
+	Message  string    `json:"log_message,omitempty" dgraph:"index=exact"`
+	LoggedAt time.Time `json:"logged_at,omitempty" dgraph:"index=hour"`
+}
+
+func TestQuery_FilterBetweenTime(t *testing.T) {
+	ctx := context.Background()
+	logs := typed.NewClient[logEntry](newConn(t))
+	base := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	for i, msg := range []string{"before", "first", "second", "after"} {
+		entry := &logEntry{Message: msg, LoggedAt: base.Add(time.Duration(i) * time.Hour)}
+		if err := logs.Add(ctx, entry); err != nil {
+			t.Fatalf("Add %s: %v", msg, err)
+		}
+	}
+
+	// Bounds are inclusive and need not be in UTC.
+	var b filter.Builder
+	b.BetweenTime("logged_at", base.Add(time.Hour).In(time.FixedZone("CET", 3600)), base.Add(2*time.Hour))
+	expr, params := b.Build()
+	rows, err := logs.Query(ctx).Filter(expr, params...).OrderAsc("logged_at").Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Message != "first" || rows[1].Message != "second" {
+		t.Fatalf("got %+v, want first and second", rows)
+	}
+}
@@ -17,11 +17,42 @@ import (
 // the struct's dgraph/json tags, so T needs no constraint.
 type Client[T any] struct {
 	conn modusgraph.Client
+	cfg  clientConfig
+}
+
+// clientConfig holds the query defaults a Client applies to every Query it
+// builds.
+type clientConfig struct {
+	maxResults    int  // 0 = unlimited
+	requireFilter bool // reject unfiltered queries
+}
+
+// ClientOption configures a Client at construction.
+type ClientOption func(*clientConfig)
+
+// WithMaxResults caps every query built by the client at n rows (see
+// Query.MaxResults). The default, 0, is unlimited.
+func WithMaxResults(n int) ClientOption {
+	return func(c *clientConfig) {
+		c.maxResults = n
+	}
+}
+
+// WithRequireFilter makes every query built by the client fail with
+// ErrFilterRequired unless it is narrowed (see Query.RequireFilter).
+func WithRequireFilter() ClientOption {
+	return func(c *clientConfig) {
+		c.requireFilter = true
+	}
 }
 
 // NewClient binds a Client[T] to conn.
-func NewClient[T any](conn modusgraph.Client) *Client[T] {
-	return &Client[T]{conn: conn}
+func NewClient[T any](conn modusgraph.Client, opts ...ClientOption) *Client[T] {
+	c := &Client[T]{conn: conn}
+	for _, opt := range opts {
+		opt(&c.cfg)
+	}
+	return c
 }
 
 // Get loads the T with the given UID.
@@ -57,6 +88,21 @@ func (c *Client[T]) Upsert(ctx context.Context, rec *T, predicates ...string) (e
 	return c.conn.Upsert(ctx, rec, predicates...)
 }
 
+// UpsertReturnOld upserts rec like Upsert and returns the matched record's
+// state from before the update, or created=true (and a nil old) when no record
+// matched and rec was inserted. With no predicates, the first field tagged
+// dgraph:"upsert" is used.
+func (c *Client[T]) UpsertReturnOld(ctx context.Context, rec *T, predicates ...string) (old *T, created bool, err error) {
+	ctx, span := currentTracer().StartSpan(ctx, "upsertReturnOld", entityName[T]())
+	defer func() { span.End(err) }()
+	var prev T
+	created, err = c.conn.UpsertReturnOld(ctx, rec, &prev, predicates...)
+	if err != nil || created {
+		return nil, created, err
+	}
+	return &prev, false, nil
+}
+
 // LoadOrStore stores rec only if no node matches the upsert predicates,
 // returning the resulting record and loaded=true when one already existed.
 // Insert-if-absent (compare sync.Map.LoadOrStore). With no predicates, the
@@ -95,9 +141,18 @@ func (c *Client[T]) Delete(ctx context.Context, uid string) (err error) {
 
 // Query returns a typed query builder for T. conn and ctx are carried so the
 // builder can run a WhereEdge pre-pass (see Query.WhereEdge) if one is needed.
+// The client's WithMaxResults and WithRequireFilter defaults are applied.
 func (c *Client[T]) Query(ctx context.Context) *Query[T] {
 	var z T
-	return &Query[T]{q: c.conn.Query(ctx, &z), conn: c.conn, ctx: ctx}
+	qctx := &queryContext{Context: ctx}
+	return &Query[T]{
+		q:             c.conn.Query(qctx, &z),
+		conn:          c.conn,
+		ctx:           qctx,
+		qctx:          qctx,
+		maxResults:    c.cfg.maxResults,
+		requireFilter: c.cfg.requireFilter,
+	}
 }
 
 // defaultPageSize is the page size IterNodes uses to page through results.
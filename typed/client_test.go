@@ -207,3 +207,27 @@ func TestClient_IterStopsOnConsumerBreak(t *testing.T) {
 		t.Fatalf("Iter yielded %d records after break at 10, want 10", seen)
 	}
 }
+
+func TestClient_UpsertReturnOld(t *testing.T) {
+	ctx := context.Background()
+	c := typed.NewClient[gadget](newConn(t))
+
+	old, created, err := c.UpsertReturnOld(ctx, &gadget{Label: "cog", Stock: 3})
+	if err != nil {
+		t.Fatalf("UpsertReturnOld (create): %v", err)
+	}
+	if !created || old != nil {
+		t.Fatalf("UpsertReturnOld (create) = (%+v, %v), want (nil, true)", old, created)
+	}
+
+	old, created, err = c.UpsertReturnOld(ctx, &gadget{Label: "cog", Stock: 7})
+	if err != nil {
+		t.Fatalf("UpsertReturnOld (update): %v", err)
+	}
+	if created {
+		t.Fatal("UpsertReturnOld (update) reported created")
+	}
+	if old == nil || old.Stock != 3 {
+		t.Fatalf("UpsertReturnOld (update) old = %+v, want the prior Stock 3", old)
+	}
+}
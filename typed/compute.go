@@ -0,0 +1,48 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed
+
+// valueVar is a value variable bound over one of T's predicates with Let.
+type valueVar struct {
+	name      string
+	predicate string
+}
+
+// computedField is an aliased expression added to the selection with Compute.
+type computedField struct {
+	alias string
+	expr  string
+}
+
+// Let binds the dgraph value variable name to predicate inside the query
+// block, so Compute expressions can refer to it:
+//
+//	q.Let("b", "budget").Compute("doubled", "math(b * 2)")
+//
+// Let accumulates; like Edge, it switches the query to an explicit selection
+// of T's fields (see Edge), and a later All discards it.
+func (qb *Query[T]) Let(name, predicate string) *Query[T] {
+	qb.lets = append(qb.lets, valueVar{name: name, predicate: predicate})
+	qb.pushSelection()
+	return qb
+}
+
+// Compute adds the expression expr to each result under alias. expr is any
+// dgraph value expression valid in the block — math() over variables bound
+// with Let, or val() of a variable. The value decodes into the field of T
+// whose json name is alias; declare that field with dgraph:"alias=computed"
+// so it is treated as a query-time value, not a stored predicate:
+//
+//	Doubled float64 `json:"doubled,omitempty" dgraph:"alias=computed"`
+//
+// Keep the field omitempty: mutations write every non-empty field, so a
+// computed value carried back into Update would be stored as a predicate.
+// Compute accumulates; a later All discards it.
+func (qb *Query[T]) Compute(alias, expr string) *Query[T] {
+	qb.computed = append(qb.computed, computedField{alias: alias, expr: expr})
+	qb.pushSelection()
+	return qb
+}
@@ -0,0 +1,80 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+// fund carries a computed alias field: Doubled is filled by a math()
+// expression at query time and is never stored.
+type fund struct {
+	UID     string   `json:"uid,omitempty"`
+	DType   []string `json:"dgraph.type,omitempty"`
+	Name    string   `json:"name,omitempty" dgraph:"index=exact"`
+	Budget  int      `json:"budget,omitempty"`
+	Doubled float64  `json:"doubled,omitempty" dgraph:"alias=computed"`
+}
+
+func TestQuery_ComputeMath(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+	funds := typed.NewClient[fund](conn)
+	for _, f := range []*fund{{Name: "a", Budget: 100}, {Name: "b", Budget: 35}} {
+		if err := funds.Add(ctx, f); err != nil {
+			t.Fatalf("Add %s: %v", f.Name, err)
+		}
+	}
+
+	rows, err := funds.Query(ctx).
+		Let("b", "budget").
+		Compute("doubled", "math(b * 2)").
+		OrderAsc("name").
+		Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	want := map[string]float64{"a": 200, "b": 70}
+	for _, r := range rows {
+		if r.Doubled != want[r.Name] {
+			t.Errorf("%s: Doubled = %v, want %v", r.Name, r.Doubled, want[r.Name])
+		}
+		if r.Budget == 0 {
+			t.Errorf("%s: Budget not decoded alongside the computed value", r.Name)
+		}
+	}
+
+	schema, err := conn.GetSchema(ctx)
+	if err != nil {
+		t.Fatalf("GetSchema: %v", err)
+	}
+	if strings.Contains(schema, "doubled") {
+		t.Errorf("computed field leaked into the schema:\n%s", schema)
+	}
+}
+
+func TestQuery_ComputeRendersSelection(t *testing.T) {
+	q := typed.NewClient[fund](newConn(t)).Query(context.Background()).
+		Let("b", "budget").
+		Let("n", "missing").
+		Compute("doubled", "math(b * 2)")
+	dql := q.String()
+	for _, want := range []string{"\tb as budget\n", "\tn as missing\n", "\tdoubled : math(b * 2)\n"} {
+		if !strings.Contains(dql, want) {
+			t.Errorf("DQL missing %q:\n%s", want, dql)
+		}
+	}
+	if strings.Count(dql, "budget") != 1 {
+		t.Errorf("budget should be selected once, through its variable:\n%s", dql)
+	}
+}
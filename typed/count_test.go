@@ -0,0 +1,89 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+// school and lesson exercise WhereCount: a lesson points at its school
+// through lesson_school, whose @reverse and @count indexes let a school be
+// filtered by how many lessons it holds.
+type school struct {
+	UID     string    `json:"uid,omitempty"`
+	DType   []string  `json:"dgraph.type,omitempty"`
+	Name    string    `json:"school_name,omitempty" dgraph:"index=exact"`
+	Lessons []*lesson `json:"~lesson_school,omitempty" dgraph:"reverse"`
+}
+
+type lesson struct {
+	UID    string   `json:"uid,omitempty"`
+	DType  []string `json:"dgraph.type,omitempty"`
+	Name   string   `json:"lesson_name,omitempty" dgraph:"index=exact"`
+	School *school  `json:"lesson_school,omitempty" dgraph:"reverse count"`
+}
+
+func TestQuery_WhereCount(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+	schools := typed.NewClient[school](conn)
+	lessons := typed.NewClient[lesson](conn)
+	for name, n := range map[string]int{"Engineering": 5, "Sciences": 4, "Arts": 3, "Law": 0} {
+		s := &school{Name: name}
+		if err := schools.Add(ctx, s); err != nil {
+			t.Fatalf("Add school %s: %v", name, err)
+		}
+		for i := range n {
+			l := &lesson{Name: fmt.Sprintf("%s-%d", name, i), School: &school{UID: s.UID}}
+			if err := lessons.Add(ctx, l); err != nil {
+				t.Fatalf("Add lesson %s-%d: %v", name, i, err)
+			}
+		}
+	}
+
+	names := func(q *typed.Query[school]) []string {
+		t.Helper()
+		rows, err := q.Nodes()
+		if err != nil {
+			t.Fatalf("Nodes: %v", err)
+		}
+		var out []string
+		for _, s := range rows {
+			out = append(out, s.Name)
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	if got, want := names(schools.Query(ctx).WhereCount("~lesson_school", "gt", 3)), []string{"Engineering", "Sciences"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("gt 3 = %v, want %v", got, want)
+	}
+	if got, want := names(schools.Query(ctx).WhereCount("~lesson_school", "eq", 3)), []string{"Arts"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("eq 3 = %v, want %v", got, want)
+	}
+
+	// The count filter ANDs with the others.
+	q := schools.Query(ctx).
+		WhereCount("~lesson_school", "ge", 3).
+		Filter(`NOT eq(school_name, $1)`, "Engineering")
+	if got, want := names(q), []string{"Arts", "Sciences"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("ge 3 without Engineering = %v, want %v", got, want)
+	}
+}
+
+func TestQuery_WhereCountRejectsUnknownComparator(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("WhereCount with comparator \"between\" did not panic")
+		}
+	}()
+	typed.NewDetachedQuery[school]().WhereCount("~lesson_school", "between", 3)
+}
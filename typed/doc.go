@@ -54,9 +54,51 @@
 //     a fragment containing OR keeps its precedence.
 //   - OrGroup ORs several sub-scopes into one parenthesized group.
 //   - WhereEdge constrains T by a predicate of a neighbouring node reached over
-//     an edge, resolved by a pre-pass and intersected with any root you set.
+//     an edge, resolved by a pre-pass and intersected with any root you set;
+//     WhereReverseEdge does the same over a managed reverse edge, and
+//     WhereCount filters T by how many edges of a predicate it has.
+//   - Edge paginates a nested edge (first/offset inside the edge block), so a
+//     node with many children can be read a page of children at a time;
+//     Filter and OrderAsc/OrderDesc keep and sort the targets by their own
+//     predicates without filtering the root, and Facets reads the edge's facets into a dgraph:"facets" sidecar map on
+//     each target; FacetOrderAsc, FacetOrderDesc, and FacetFilter order and
+//     filter the targets by those facets, and UseNumber keeps large integer
+//     facets exact.
+//   - Reverse reads the reverse of any forward edge declared @reverse into a
+//     slice of your own, so T needs no ~edge field for the query.
+//   - Let and Compute add aliased computed values (math(), val()) that decode
+//     into fields tagged dgraph:"alias=computed".
+//   - With prepends var blocks — another query made a var block with Var, or
+//     an Aggregate over a value variable — so filters and expressions can
+//     compare against values computed elsewhere through val().
+//   - IncludeIf keeps or drops named fields of T by a runtime flag, so one
+//     query can serve callers with different field sets.
+//   - LangFallback reads language-tagged fields in the first of a list of
+//     languages the node has a value in, such as name@en:de:.
+//   - Select replaces the selection set, and Normalize adds @normalize to
+//     flatten an aliased traversal into flat rows; NormalizeLimit lets one
+//     wide traversal exceed the engine's normalize-node limit.
+//   - Recurse adds @recurse to follow edges to whatever depth the graph
+//     reaches, such as a whole friend-of-a-friend chain; RecurseFilter
+//     prunes the traversal at the nodes that fail a filter.
+//   - OfType roots the query at a named type, so a projection of T can
+//     read and filter another type's nodes.
+//   - Untyped roots the query at has(predicate) and selects T's fields by
+//     name, for data loaded as RDF without a dgraph.type.
+//   - Expand selects only the predicates of named types through
+//     expand(Type), for nodes that carry more than one type.
+//   - Groups, a terminal, groups the matched nodes by one predicate after
+//     another and returns a tree of buckets whose aggregates decode into a
+//     struct of your own.
+//   - Project, a terminal, returns the matched nodes as flat rows of a
+//     struct of your own, whose project tags name root and edge predicates.
 //   - IterNodes streams arbitrarily large result sets one page at a time over a
 //     single read-only snapshot.
+//   - Page, a terminal, returns one relay-style page of results after a UID
+//     cursor, with HasNextPage and the EndCursor of the next page.
+//   - MaxResults, RequireFilter, and MaxBytes guard queries built from user
+//     input, capping the rows, requiring a filter, and refusing to decode an
+//     oversized response.
 //
 // # Composing larger requests
 //
@@ -72,13 +114,13 @@
 // untrusted values belong in parameters — pass a user-supplied name as
 // eq(name, $1) with the name in $1, never formatted into the expression string.
 //
-// The surrounding strings are not escaped. Filter expressions, RootFunc and UID
-// roots, WhereEdge predicates, order clauses, and MultiQuery block names are
-// interpolated into DQL verbatim, so they are a trust boundary: build them from
-// your own code or from validated identifiers, never from unsanitized external
-// input. MultiQuery.Add enforces this for block names by rejecting anything that
-// is not a plain identifier; guarding the other surfaces is the caller's
-// responsibility.
+// The surrounding strings are not escaped. Filter expressions, RootFunc, OfType,
+// Untyped, and UID roots, Groups predicates and aggregates, WhereEdge, WhereReverseEdge, WhereCount, and Edge predicates, order clauses,
+// Aggregate names and functions, and MultiQuery block names are interpolated into DQL verbatim, so they are a
+// trust boundary: build them from your own code or from validated identifiers,
+// never from unsanitized external input. MultiQuery.Add enforces this for block names by rejecting
+// anything that is not a plain identifier; guarding the other surfaces is the
+// caller's responsibility.
 //
 // # Tracing
 //
@@ -0,0 +1,147 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// EdgeQuery shapes how one edge of T is fetched inside the query's selection,
+// as opposed to Query's own builders, which shape the root function. It is
+// obtained from Query.Edge and mutates the parent query in place; Done returns
+// to the parent for further chaining.
+type EdgeQuery[T any] struct {
+	parent *Query[T]
+	page   *edgePage
+}
+
+// edgePage is the accumulated pagination for one edge predicate of T.
+type edgePage struct {
+	predicate string
+	first     int          // 0 = unbounded
+	offset    int          // 0 = none
+	orders    []string     // "orderasc: <predicate>" or "orderdesc: <predicate>", in call order
+	filters   []filterFrag // ANDed @filter fragments on the edge's targets
+	facets    bool
+	facetSort string // "orderasc: <facet>" or "orderdesc: <facet>"; "" = none
+	facetExpr string // facet filter expression; "" = none
+}
+
+// Edge returns a sub-builder for the edge predicate of T — a forward edge such
+// as "pets" or a managed reverse edge such as "~in_department" — so the edge
+// can be filtered, ordered, and paginated independently of the root:
+//
+//	q.Edge("~in_department").OrderAsc("course_name").First(5)
+//
+// Once any edge is shaped, the query selects T's fields explicitly instead of
+// expanding every predicate: scalars are fetched as-is and each edge is
+// expanded one level deep (uid, dgraph.type and the target's own predicates).
+// A later All call restores the default selection and drops the edge shaping.
+// Repeated calls for the same predicate return a builder over the same edge.
+func (qb *Query[T]) Edge(predicate string) *EdgeQuery[T] {
+	for _, p := range qb.edgePages {
+		if p.predicate == predicate {
+			return &EdgeQuery[T]{parent: qb, page: p}
+		}
+	}
+	p := &edgePage{predicate: predicate}
+	qb.edgePages = append(qb.edgePages, p)
+	return &EdgeQuery[T]{parent: qb, page: p}
+}
+
+// First returns at most n targets of the edge per node. Repeated calls
+// overwrite.
+func (e *EdgeQuery[T]) First(n int) *EdgeQuery[T] {
+	e.page.first = n
+	e.parent.pushSelection()
+	return e
+}
+
+// Offset skips the first n targets of the edge per node. Repeated calls
+// overwrite.
+func (e *EdgeQuery[T]) Offset(n int) *EdgeQuery[T] {
+	e.page.offset = n
+	e.parent.pushSelection()
+	return e
+}
+
+// Filter keeps only the edge's targets that satisfy the dgraph filter
+// expression, whose $1, $2, ... placeholders bind to params as in
+// Query.Filter — a department's courses whose code starts with "CS", say:
+//
+//	q.Edge("~in_department").Filter(`regexp(course_name, /^CS/)`)
+//
+// The filter applies to the edge alone; the root nodes are not filtered by
+// it (see WhereEdge for that). Repeated calls AND together.
+func (e *EdgeQuery[T]) Filter(expr string, params ...any) *EdgeQuery[T] {
+	if expr != "" {
+		e.page.filters = append(e.page.filters, filterFrag{expr: expr, params: params})
+		e.parent.pushSelection()
+	}
+	return e
+}
+
+// OrderAsc orders the edge's targets ascending by the target predicate.
+// Ordering composes with First and Offset, which then page through the
+// ordered targets. Repeated calls, and OrderDesc, add further sort keys.
+func (e *EdgeQuery[T]) OrderAsc(predicate string) *EdgeQuery[T] {
+	e.page.orders = append(e.page.orders, "orderasc: "+predicate)
+	e.parent.pushSelection()
+	return e
+}
+
+// OrderDesc orders the edge's targets descending by the target predicate.
+// See OrderAsc.
+func (e *EdgeQuery[T]) OrderDesc(predicate string) *EdgeQuery[T] {
+	e.page.orders = append(e.page.orders, "orderdesc: "+predicate)
+	e.parent.pushSelection()
+	return e
+}
+
+// Done returns the parent query.
+func (e *EdgeQuery[T]) Done() *Query[T] {
+	return e.parent
+}
+
+// args renders the edge's arguments and directives, or "" when it has none,
+// with the filter's placeholders numbered after the first paramBase params of
+// the selection. It returns the filter's params.
+func (p *edgePage) args(paramBase int) (string, []any) {
+	args := p.pagination()
+	expr, params := combineAnd(p.filters)
+	if expr != "" {
+		args += " @filter(" + shiftPlaceholders(expr, paramBase) + ")"
+	}
+	// dgraph takes one retrieving @facets per edge; an ordering one returns
+	// the facet it orders by.
+	if p.facetSort != "" {
+		args += " @facets(" + p.facetSort + ")"
+	} else if p.facets {
+		args += " @facets"
+	}
+	if p.facetExpr != "" {
+		args += " @facets(" + p.facetExpr + ")"
+	}
+	return args, params
+}
+
+// pagination renders the edge's ordering and pagination arguments, or "" when
+// it has none.
+func (p *edgePage) pagination() string {
+	parts := slices.Clone(p.orders)
+	if p.first != 0 {
+		parts = append(parts, "first: "+strconv.Itoa(p.first))
+	}
+	if p.offset != 0 {
+		parts = append(parts, "offset: "+strconv.Itoa(p.offset))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,219 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+// department and course exercise Query.Edge: a course points at its department
+// through in_department, and the department reads its courses back over the
+// managed reverse edge.
+type department struct {
+	UID     string    `json:"uid,omitempty"`
+	DType   []string  `json:"dgraph.type,omitempty"`
+	Name    string    `json:"dept_name,omitempty" dgraph:"index=exact"`
+	Courses []*course `json:"~in_department,omitempty" dgraph:"reverse"`
+}
+
+type course struct {
+	UID          string      `json:"uid,omitempty"`
+	DType        []string    `json:"dgraph.type,omitempty"`
+	Name         string      `json:"course_name,omitempty" dgraph:"index=exact"`
+	InDepartment *department `json:"in_department,omitempty" dgraph:"reverse"`
+}
+
+func seedDepartment(t *testing.T, ctx context.Context, courses int) (*typed.Client[department], string) {
+	t.Helper()
+	conn := newConn(t)
+	depts := typed.NewClient[department](conn)
+	dept := &department{Name: "Physics"}
+	if err := depts.Add(ctx, dept); err != nil {
+		t.Fatalf("Add department: %v", err)
+	}
+	cs := typed.NewClient[course](conn)
+	for i := range courses {
+		c := &course{Name: fmt.Sprintf("PHY%d", 100+i), InDepartment: &department{UID: dept.UID}}
+		if err := cs.Add(ctx, c); err != nil {
+			t.Fatalf("Add course %d: %v", i, err)
+		}
+	}
+	return depts, dept.UID
+}
+
+func TestQuery_EdgeFirst(t *testing.T) {
+	ctx := context.Background()
+	depts, uid := seedDepartment(t, ctx, 5)
+
+	all, err := depts.Get(ctx, uid)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(all.Courses) != 5 {
+		t.Fatalf("unpaginated department has %d courses, want 5", len(all.Courses))
+	}
+
+	got, err := depts.Query(ctx).UID(uid).Edge("~in_department").First(2).Done().First()
+	if err != nil {
+		t.Fatalf("First: %v", err)
+	}
+	if got == nil {
+		t.Fatal("First returned no department")
+	}
+	if got.Name != "Physics" {
+		t.Errorf("Name = %q, want Physics", got.Name)
+	}
+	if len(got.Courses) != 2 {
+		t.Fatalf("paginated department has %d courses, want 2", len(got.Courses))
+	}
+	for _, c := range got.Courses {
+		if !strings.HasPrefix(c.Name, "PHY") {
+			t.Errorf("course Name = %q, want a PHY course with its scalars expanded", c.Name)
+		}
+	}
+}
+
+func TestQuery_EdgeOffset(t *testing.T) {
+	ctx := context.Background()
+	depts, uid := seedDepartment(t, ctx, 5)
+
+	rows, err := depts.Query(ctx).UID(uid).Edge("~in_department").First(2).Offset(4).Done().Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d departments, want 1", len(rows))
+	}
+	if len(rows[0].Courses) != 1 {
+		t.Fatalf("offset 4 of 5 courses returned %d, want 1", len(rows[0].Courses))
+	}
+}
+
+func TestQuery_EdgeRendersPagination(t *testing.T) {
+	ctx := context.Background()
+	depts := typed.NewClient[department](newConn(t))
+
+	q := depts.Query(ctx)
+	q.Edge("~in_department").First(5)
+	q.Edge("~in_department").Offset(10)
+	dql := q.String()
+	if !strings.Contains(dql, "~in_department (first: 5, offset: 10) {") {
+		t.Errorf("edge pagination missing from DQL:\n%s", dql)
+	}
+	if !strings.Contains(dql, "\tdept_name\n") {
+		t.Errorf("scalar predicate missing from DQL:\n%s", dql)
+	}
+
+	if dql := q.All(1).String(); strings.Contains(dql, "first: 5") {
+		t.Errorf("All should discard edge pagination, got:\n%s", dql)
+	}
+}
+
+func TestQuery_WhereReverseEdge(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+	depts := typed.NewClient[department](conn)
+	courses := typed.NewClient[course](conn)
+	for dept, codes := range map[string][]string{
+		"Computer Science": {"CS101", "CS102"},
+		"Mathematics":      {"MATH101"},
+		"History":          nil,
+	} {
+		d := &department{Name: dept}
+		if err := depts.Add(ctx, d); err != nil {
+			t.Fatalf("Add department %s: %v", dept, err)
+		}
+		for _, code := range codes {
+			c := &course{Name: code, InDepartment: &department{UID: d.UID}}
+			if err := courses.Add(ctx, c); err != nil {
+				t.Fatalf("Add course %s: %v", code, err)
+			}
+		}
+	}
+
+	got, err := depts.Query(ctx).WhereReverseEdge("in_department", `eq(course_name, $1)`, "CS101").Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "Computer Science" {
+		t.Fatalf("got %+v, want only Computer Science", got)
+	}
+
+	// The "~" form is accepted as is, and constraints AND together.
+	got, err = depts.Query(ctx).
+		WhereReverseEdge("~in_department", `eq(course_name, $1)`, "CS101").
+		WhereReverseEdge("in_department", `eq(course_name, $1)`, "MATH101").
+		Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("got %+v, want no department with both courses", got)
+	}
+}
+
+func TestQuery_EdgeFilterAndOrder(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+	depts := typed.NewClient[department](conn)
+	courses := typed.NewClient[course](conn)
+	dept := &department{Name: "Computer Science"}
+	if err := depts.Add(ctx, dept); err != nil {
+		t.Fatalf("Add department: %v", err)
+	}
+	for _, code := range []string{"CS201", "MATH101", "CS101", "CS301", "PHIL101"} {
+		c := &course{Name: code, InDepartment: &department{UID: dept.UID}}
+		if err := courses.Add(ctx, c); err != nil {
+			t.Fatalf("Add course %s: %v", code, err)
+		}
+	}
+
+	got, err := depts.Query(ctx).UID(dept.UID).
+		Edge("~in_department").
+		Filter(`regexp(course_name, /^CS/)`).
+		Filter(`NOT eq(course_name, $1)`, "CS301").
+		OrderAsc("course_name").
+		Done().
+		First()
+	if err != nil {
+		t.Fatalf("First: %v", err)
+	}
+	if got == nil {
+		t.Fatal("First returned no department")
+	}
+	var names []string
+	for _, c := range got.Courses {
+		names = append(names, c.Name)
+	}
+	if want := []string{"CS101", "CS201"}; fmt.Sprint(names) != fmt.Sprint(want) {
+		t.Fatalf("courses = %v, want %v", names, want)
+	}
+
+	// The edge filter leaves the root alone, and a descending order composes
+	// with pagination.
+	rows, err := depts.Query(ctx).
+		Filter(`eq(dept_name, $1)`, "Computer Science").
+		Edge("~in_department").Filter(`eq(course_name, $1)`, "NONE").Done().
+		Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	if len(rows) != 1 || len(rows[0].Courses) != 0 {
+		t.Fatalf("got %+v, want the department with no matching courses", rows)
+	}
+	got, err = depts.Query(ctx).UID(dept.UID).Edge("~in_department").OrderDesc("course_name").First(1).Done().First()
+	if err != nil {
+		t.Fatalf("First: %v", err)
+	}
+	if len(got.Courses) != 1 || got.Courses[0].Name != "PHIL101" {
+		t.Fatalf("courses = %+v, want only PHIL101", got.Courses)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+// courseListing reads nodes typed both Course and Archived, whose schema types
+// each declare one of its predicates.
+type courseListing struct {
+	UID    string   `json:"uid,omitempty"`
+	DType  []string `json:"dgraph.type,omitempty" dgraph:"Course"`
+	Title  string   `json:"listing_title,omitempty" dgraph:"index=exact"`
+	Reason string   `json:"archive_reason,omitempty"`
+}
+
+// seedArchivedCourse stores one node carrying both the Course and the Archived
+// type, against a hand-written schema so that each type declares only its own
+// predicate.
+func seedArchivedCourse(t *testing.T) modusgraph.Client {
+	t.Helper()
+	ctx := context.Background()
+	conn, err := modusgraph.NewClient("file://" + t.TempDir())
+	if err != nil {
+		t.Fatalf("modusgraph.NewClient: %v", err)
+	}
+	t.Cleanup(conn.Close)
+
+	err = conn.ApplySchemaString(ctx, `
+		listing_title: string @index(exact) .
+		archive_reason: string .
+		type Course {
+			listing_title
+		}
+		type Archived {
+			archive_reason
+		}
+	`)
+	if err != nil {
+		t.Fatalf("ApplySchemaString: %v", err)
+	}
+	node := &courseListing{
+		DType:  []string{"Course", "Archived"},
+		Title:  "Graph Theory",
+		Reason: "superseded",
+	}
+	if err := conn.Insert(ctx, node); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	return conn
+}
+
+func TestQuery_ExpandSelectsOnlyNamedType(t *testing.T) {
+	ctx := context.Background()
+	c := typed.NewClient[courseListing](seedArchivedCourse(t))
+
+	got, err := c.Query(ctx).Expand("Course").First()
+	if err != nil {
+		t.Fatalf("First: %v", err)
+	}
+	if got == nil {
+		t.Fatal("course not found")
+	}
+	if got.Title != "Graph Theory" {
+		t.Errorf("Title = %q, want %q", got.Title, "Graph Theory")
+	}
+	if got.Reason != "" {
+		t.Errorf("Reason = %q, want it left out by expand(Course)", got.Reason)
+	}
+	if got.UID == "" || len(got.DType) != 2 {
+		t.Errorf("uid and dgraph.type should still be selected, got %+v", got)
+	}
+
+	got, err = c.Query(ctx).Expand("Course", "Archived").First()
+	if err != nil {
+		t.Fatalf("First: %v", err)
+	}
+	if got.Title != "Graph Theory" || got.Reason != "superseded" {
+		t.Errorf("expand(Course, Archived) = %+v, want both types' predicates", got)
+	}
+
+	got, err = c.Query(ctx).Expand("Course").All(1).First()
+	if err != nil {
+		t.Fatalf("First: %v", err)
+	}
+	if got.Reason != "superseded" {
+		t.Errorf("All should discard Expand and select every predicate, got %+v", got)
+	}
+}
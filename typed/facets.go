@@ -0,0 +1,242 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// facetsTag is the dgraph tag token that marks a struct's facet sidecar: a
+// map[string]any field, conventionally tagged `json:"-" dgraph:"facets"`.
+const facetsTag = "facets"
+
+// Facets requests the facets stored on the edge. Each target node reached over
+// it receives the edge's facets, keyed by facet name, in its facet sidecar
+// field — a map[string]any tagged dgraph:"facets":
+//
+//	type Person struct {
+//		UID     string         `json:"uid,omitempty"`
+//		Name    string         `json:"name,omitempty"`
+//		Friends []*Person      `json:"friends,omitempty"`
+//		Facets  map[string]any `json:"-" dgraph:"facets"`
+//	}
+//
+//	q.Edge("friends").Facets()  // friend.Facets["since"]
+//
+// A target type without a sidecar field decodes as usual and the facets are
+// dropped. Numeric facets decode as float64, which holds integers exactly only
+// up to 2^53; see Query.UseNumber.
+func (e *EdgeQuery[T]) Facets() *EdgeQuery[T] {
+	e.page.facets = true
+	e.parent.pushSelection()
+	return e
+}
+
+// FacetOrderAsc orders the edge's targets by the named facet of the edge,
+// ascending — a person's friends by the year they met, say:
+//
+//	q.Edge("friends").FacetOrderAsc("since")
+//
+// Ordering composes with First and Offset, which then page through the
+// ordered targets. dgraph returns only the ordering facet on an ordered edge,
+// so with Facets that one facet is read back. Repeated calls, and
+// FacetOrderDesc, overwrite.
+func (e *EdgeQuery[T]) FacetOrderAsc(facet string) *EdgeQuery[T] {
+	e.page.facetSort = "orderasc: " + facet
+	e.parent.pushSelection()
+	return e
+}
+
+// FacetOrderDesc orders the edge's targets by the named facet of the edge,
+// descending. See FacetOrderAsc.
+func (e *EdgeQuery[T]) FacetOrderDesc(facet string) *EdgeQuery[T] {
+	e.page.facetSort = "orderdesc: " + facet
+	e.parent.pushSelection()
+	return e
+}
+
+// FacetFilter keeps only the edge's targets whose edge facets satisfy expr, a
+// dgraph facet filter such as `ge(since, 2020)` or `eq(close, true)`. expr is
+// interpolated verbatim, like a Filter expression without parameters, so it
+// must not carry untrusted input. Repeated calls overwrite.
+func (e *EdgeQuery[T]) FacetFilter(expr string) *EdgeQuery[T] {
+	e.page.facetExpr = expr
+	e.parent.pushSelection()
+	return e
+}
+
+// UseNumber makes numeric facet values decode into facet sidecars as
+// json.Number instead of float64, so an integer facet beyond 2^53 keeps every
+// digit; read it with Int64 or String. Fields of T are unaffected: they
+// decode into their declared Go types, so int64 and uint64 fields are always
+// exact.
+func (qb *Query[T]) UseNumber() *Query[T] {
+	qb.useNumber = true
+	return qb
+}
+
+// wantsFacets reports whether any edge of the query requested facets.
+func (qb *Query[T]) wantsFacets() bool {
+	for _, p := range qb.edgePages {
+		if p.facets {
+			return true
+		}
+	}
+	return false
+}
+
+// decode runs a terminal's dgman call, which decodes the result block into
+// the destination it is given, and stores the rows in out. When an edge
+// requested facets, the block is captured raw first so the facets dgraph
+// returns alongside each edge target can be copied into the sidecar fields
+// json decoding leaves empty; under MaxBytes it is captured raw too, to be
+// measured before it is decoded, and under Reverse, to read the reverse edges
+// T does not declare. The rows of a flipped query (see flipped) are put back
+// in the declared order.
+func (qb *Query[T]) decode(out *[]T, run func(dst any) error) error {
+	if !qb.wantsFacets() && qb.maxBytes == 0 && len(qb.reverses) == 0 {
+		if err := run(out); err != nil {
+			return err
+		}
+	} else {
+		var raw json.RawMessage
+		if err := run(&raw); err != nil {
+			return err
+		}
+		if err := qb.checkSize(len(raw)); err != nil {
+			return err
+		}
+		if err := qb.collectReverses(raw); err != nil {
+			return err
+		}
+		if qb.wantsFacets() {
+			if err := decodeWithFacets(raw, out, qb.useNumber); err != nil {
+				return err
+			}
+		} else if len(raw) > 0 {
+			remapped, err := remapPredicateKeys(raw, reflect.TypeFor[T]())
+			if err != nil {
+				return fmt.Errorf("typed: remapping rows: %w", err)
+			}
+			if err := json.Unmarshal(remapped, out); err != nil {
+				return err
+			}
+		}
+	}
+	if qb.flipped() {
+		slices.Reverse(*out)
+	}
+	return nil
+}
+
+// decodeWithFacets decodes a raw result block into out and fills its facet
+// sidecars, with numeric facets as json.Number when useNumber is set.
+func decodeWithFacets[T any](raw json.RawMessage, out *[]T, useNumber bool) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	remapped, err := remapPredicateKeys(raw, reflect.TypeFor[T]())
+	if err != nil {
+		return fmt.Errorf("typed: remapping rows: %w", err)
+	}
+	if err := json.Unmarshal(remapped, out); err != nil {
+		return err
+	}
+	var generic []any
+	dec := json.NewDecoder(bytes.NewReader(remapped))
+	if useNumber {
+		dec.UseNumber()
+	}
+	if err := dec.Decode(&generic); err != nil {
+		return fmt.Errorf("typed: decoding facets: %w", err)
+	}
+	rows := reflect.ValueOf(out).Elem()
+	for i := 0; i < rows.Len() && i < len(generic); i++ {
+		if obj, ok := generic[i].(map[string]any); ok {
+			fillFacets(rows.Index(i), obj, nil)
+		}
+	}
+	return nil
+}
+
+// fillFacets walks v, a decoded node, in step with obj, the same node as
+// generic JSON. facets, when non-empty, are those of the edge v was reached
+// over and go into v's sidecar field. Every edge field of v is then followed,
+// lifting the "<predicate>|<facet>" keys dgraph writes into each target.
+func fillFacets(v reflect.Value, obj map[string]any, facets map[string]any) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if len(facets) > 0 && isFacetsField(field) && v.Field(i).CanSet() {
+			v.Field(i).Set(reflect.ValueOf(facets))
+			continue
+		}
+		if !isEdgeType(field.Type) {
+			continue
+		}
+		key := strings.Split(field.Tag.Get("json"), ",")[0]
+		prefix := fieldPredicate(field) + "|"
+		fv := v.Field(i)
+		switch child := obj[key].(type) {
+		case []any:
+			if fv.Kind() != reflect.Slice {
+				if len(child) > 0 {
+					fillTarget(fv, child[0], prefix)
+				}
+				continue
+			}
+			for j := 0; j < fv.Len() && j < len(child); j++ {
+				fillTarget(fv.Index(j), child[j], prefix)
+			}
+		case map[string]any:
+			fillTarget(fv, child, prefix)
+		}
+	}
+}
+
+// fillTarget fills the sidecars of one edge target, whose facets are the keys
+// of raw carrying prefix.
+func fillTarget(v reflect.Value, raw any, prefix string) {
+	obj, ok := raw.(map[string]any)
+	if !ok {
+		return
+	}
+	facets := make(map[string]any)
+	for k, val := range obj {
+		if name, ok := strings.CutPrefix(k, prefix); ok {
+			facets[name] = val
+		}
+	}
+	fillFacets(v, obj, facets)
+}
+
+// isFacetsField reports whether field is a facet sidecar: a map[string]any
+// tagged dgraph:"facets".
+func isFacetsField(field reflect.StructField) bool {
+	if field.Type != reflect.TypeFor[map[string]any]() {
+		return false
+	}
+	for part := range strings.FieldsSeq(field.Tag.Get("dgraph")) {
+		if part == facetsTag {
+			return true
+		}
+	}
+	return false
+}
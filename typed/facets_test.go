@@ -0,0 +1,220 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+// buddy carries a facet sidecar: the facets of the buddies edge it was reached
+// over land in Facets.
+type buddy struct {
+	UID     string         `json:"uid,omitempty"`
+	DType   []string       `json:"dgraph.type,omitempty"`
+	Name    string         `json:"buddy_name,omitempty" dgraph:"index=exact"`
+	Buddies []*buddy       `json:"buddies,omitempty"`
+	Facets  map[string]any `json:"-" dgraph:"facets"`
+}
+
+func TestQuery_EdgeFacets(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+	buddies := typed.NewClient[buddy](conn)
+
+	alice := &buddy{Name: "Alice"}
+	if err := buddies.Add(ctx, alice); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	since := map[string]int{"Bob": 2015, "Carol": 2021}
+	var targets []string
+	for name, year := range since {
+		b := &buddy{Name: name}
+		if err := buddies.Add(ctx, b); err != nil {
+			t.Fatalf("Add %s: %v", name, err)
+		}
+		targets = append(targets, fmt.Sprintf(`{"uid": %q, "buddies|since": %d}`, b.UID, year))
+	}
+
+	dg, cleanup, err := conn.DgraphClient()
+	if err != nil {
+		t.Fatalf("DgraphClient: %v", err)
+	}
+	defer cleanup()
+	_, err = dg.NewTxn().Mutate(ctx, &api.Mutation{
+		SetJson:   []byte(fmt.Sprintf(`{"uid": %q, "buddies": [%s]}`, alice.UID, strings.Join(targets, ","))),
+		CommitNow: true,
+	})
+	if err != nil {
+		t.Fatalf("write facets: %v", err)
+	}
+
+	got, err := buddies.Query(ctx).UID(alice.UID).Edge("buddies").First(5).Facets().Done().First()
+	if err != nil {
+		t.Fatalf("First: %v", err)
+	}
+	if got == nil || len(got.Buddies) != 2 {
+		t.Fatalf("got %+v, want Alice with 2 buddies", got)
+	}
+	if got.Facets != nil {
+		t.Errorf("root Facets = %v, want nil: Alice was not reached over an edge", got.Facets)
+	}
+	for _, b := range got.Buddies {
+		want, ok := since[b.Name]
+		if !ok {
+			t.Fatalf("unexpected buddy %q", b.Name)
+		}
+		if s, _ := b.Facets["since"].(float64); int(s) != want {
+			t.Errorf("%s Facets = %v, want since=%d", b.Name, b.Facets, want)
+		}
+	}
+
+	plain, err := buddies.Query(ctx).UID(alice.UID).Edge("buddies").First(5).Done().First()
+	if err != nil {
+		t.Fatalf("First without facets: %v", err)
+	}
+	for _, b := range plain.Buddies {
+		if b.Facets != nil {
+			t.Errorf("%s Facets = %v without Facets(), want nil", b.Name, b.Facets)
+		}
+	}
+}
+
+func TestQuery_EdgeFacetsRender(t *testing.T) {
+	q := typed.NewClient[buddy](newConn(t)).Query(context.Background())
+	q.Edge("buddies").First(3).Facets()
+	if dql := q.String(); !strings.Contains(dql, "buddies (first: 3) @facets {") {
+		t.Errorf("edge facets missing from DQL:\n%s", dql)
+	}
+}
+
+func TestQuery_EdgeFacetOrder(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+	buddies := typed.NewClient[buddy](conn)
+
+	alice := &buddy{Name: "Alice"}
+	if err := buddies.Add(ctx, alice); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	since := map[string]int{"Bob": 2015, "Carol": 2021, "Dan": 2009, "Erin": 2018}
+	var targets []string
+	for name, year := range since {
+		b := &buddy{Name: name}
+		if err := buddies.Add(ctx, b); err != nil {
+			t.Fatalf("Add %s: %v", name, err)
+		}
+		targets = append(targets, fmt.Sprintf(`{"uid": %q, "buddies|since": %d}`, b.UID, year))
+	}
+	dg, cleanup, err := conn.DgraphClient()
+	if err != nil {
+		t.Fatalf("DgraphClient: %v", err)
+	}
+	defer cleanup()
+	_, err = dg.NewTxn().Mutate(ctx, &api.Mutation{
+		SetJson:   []byte(fmt.Sprintf(`{"uid": %q, "buddies": [%s]}`, alice.UID, strings.Join(targets, ","))),
+		CommitNow: true,
+	})
+	if err != nil {
+		t.Fatalf("write facets: %v", err)
+	}
+
+	names := func(b *buddy) string {
+		var out []string
+		for _, f := range b.Buddies {
+			out = append(out, f.Name)
+		}
+		return strings.Join(out, ",")
+	}
+	for _, tc := range []struct {
+		name  string
+		shape func(*typed.EdgeQuery[buddy])
+		want  string
+	}{
+		{"asc", func(e *typed.EdgeQuery[buddy]) { e.FacetOrderAsc("since") }, "Dan,Bob,Erin,Carol"},
+		{"desc", func(e *typed.EdgeQuery[buddy]) { e.FacetOrderDesc("since") }, "Carol,Erin,Bob,Dan"},
+		{"paged", func(e *typed.EdgeQuery[buddy]) { e.FacetOrderAsc("since").First(2).Offset(1) }, "Bob,Erin"},
+		{"filtered", func(e *typed.EdgeQuery[buddy]) { e.FacetOrderAsc("since").FacetFilter("ge(since, 2015)") }, "Bob,Erin,Carol"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			q := buddies.Query(ctx).UID(alice.UID)
+			e := q.Edge("buddies").Facets()
+			tc.shape(e)
+			got, err := e.Done().First()
+			if err != nil {
+				t.Fatalf("First: %v", err)
+			}
+			if got == nil {
+				t.Fatal("Alice not found")
+			}
+			if names(got) != tc.want {
+				t.Errorf("buddies = %s, want %s", names(got), tc.want)
+			}
+			for _, b := range got.Buddies {
+				if s, _ := b.Facets["since"].(float64); int(s) != since[b.Name] {
+					t.Errorf("%s Facets = %v, want since=%d", b.Name, b.Facets, since[b.Name])
+				}
+			}
+		})
+	}
+}
+
+func TestQuery_EdgeFacetOrderRender(t *testing.T) {
+	q := typed.NewClient[buddy](newConn(t)).Query(context.Background())
+	q.Edge("buddies").First(3).FacetOrderDesc("since").FacetFilter("eq(close, true)")
+	want := "buddies (first: 3) @facets(orderdesc: since) @facets(eq(close, true)) {"
+	if dql := q.String(); !strings.Contains(dql, want) {
+		t.Errorf("want %q in DQL:\n%s", want, dql)
+	}
+}
+
+func TestQuery_EdgeFacetsUseNumber(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+	buddies := typed.NewClient[buddy](conn)
+
+	alice, bob := &buddy{Name: "Alice"}, &buddy{Name: "Bob"}
+	for _, b := range []*buddy{alice, bob} {
+		if err := buddies.Add(ctx, b); err != nil {
+			t.Fatalf("Add %s: %v", b.Name, err)
+		}
+	}
+	const token = int64(1<<53 + 1) // the first integer float64 cannot hold
+	dg, cleanup, err := conn.DgraphClient()
+	if err != nil {
+		t.Fatalf("DgraphClient: %v", err)
+	}
+	defer cleanup()
+	_, err = dg.NewTxn().Mutate(ctx, &api.Mutation{
+		SetJson: []byte(fmt.Sprintf(`{"uid": %q, "buddies": [{"uid": %q, "buddies|token": %d}]}`,
+			alice.UID, bob.UID, token)),
+		CommitNow: true,
+	})
+	if err != nil {
+		t.Fatalf("write facets: %v", err)
+	}
+
+	got, err := buddies.Query(ctx).UID(alice.UID).UseNumber().Edge("buddies").Facets().Done().First()
+	if err != nil {
+		t.Fatalf("First: %v", err)
+	}
+	if got == nil || len(got.Buddies) != 1 {
+		t.Fatalf("got %+v, want Alice with 1 buddy", got)
+	}
+	n, ok := got.Buddies[0].Facets["token"].(json.Number)
+	if !ok {
+		t.Fatalf("token facet = %T, want json.Number", got.Buddies[0].Facets["token"])
+	}
+	if v, err := n.Int64(); err != nil || v != token {
+		t.Errorf("token facet = %s, want %d", n, token)
+	}
+}
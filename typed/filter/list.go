@@ -0,0 +1,30 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package filter
+
+import "fmt"
+
+// InList adds a membership group: eq(predicate, [v1, v2, ...]), matching
+// nodes whose predicate equals any of values or, for a list predicate, holds
+// any of them. The values bind as a single param, so they are never
+// interpolated into the expression. The predicate needs an index eq can use;
+// no values is a no-op.
+func (b *Builder) InList(predicate string, values ...any) {
+	if len(values) == 0 {
+		return
+	}
+	b.groups = append(b.groups, fmt.Sprintf("eq(%s, %s)", predicate, b.param(values)))
+}
+
+// UIDIn adds an edge membership group: uid_in(edge, [uid1, uid2, ...]),
+// matching nodes with an edge to any of uids. Like InList it binds uids as a
+// single param; no uids is a no-op.
+func (b *Builder) UIDIn(edge string, uids ...string) {
+	if len(uids) == 0 {
+		return
+	}
+	b.groups = append(b.groups, fmt.Sprintf("uid_in(%s, %s)", edge, b.param(uids)))
+}
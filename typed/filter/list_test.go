@@ -0,0 +1,48 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package filter_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph/typed/filter"
+)
+
+func TestInListEmitsEqOnListAndBindsOneParam(t *testing.T) {
+	b := &filter.Builder{}
+	b.RequiredEq("kind", "order")
+	b.InList("status", "open", "pending")
+	expr, params := b.Build()
+	if want := "eq(kind, $1) AND eq(status, $2)"; expr != want {
+		t.Fatalf("expr = %q, want %q", expr, want)
+	}
+	if len(params) != 2 || !slices.Equal(params[1].([]any), []any{"open", "pending"}) {
+		t.Fatalf("expected params [order [open pending]], got %v", params)
+	}
+}
+
+func TestUIDInEmitsUIDInAndBindsOneParam(t *testing.T) {
+	b := &filter.Builder{}
+	b.UIDIn("in_department", "0x1", "0x2")
+	expr, params := b.Build()
+	if want := "uid_in(in_department, $1)"; expr != want {
+		t.Fatalf("expr = %q, want %q", expr, want)
+	}
+	if len(params) != 1 || !slices.Equal(params[0].([]string), []string{"0x1", "0x2"}) {
+		t.Fatalf("expected params [[0x1 0x2]], got %v", params)
+	}
+}
+
+func TestInListAndUIDInEmptyAreNoops(t *testing.T) {
+	b := &filter.Builder{}
+	b.InList("status")
+	b.UIDIn("in_department")
+	expr, params := b.Build()
+	if expr != "" || params != nil {
+		t.Fatalf("expected empty expr/params for no values, got %q / %v", expr, params)
+	}
+}
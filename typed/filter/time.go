@@ -0,0 +1,39 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package filter
+
+import (
+	"fmt"
+	"time"
+)
+
+// BetweenTime adds an inclusive range group on a datetime predicate:
+// (ge(predicate, from) AND le(predicate, to)). Both bounds are bound as
+// RFC 3339 strings in UTC, so callers never quote or format timestamps
+// themselves. A zero bound leaves that side of the range open; when both are
+// zero the call is a no-op.
+func (b *Builder) BetweenTime(predicate string, from, to time.Time) {
+	var group string
+	if !from.IsZero() {
+		group = fmt.Sprintf("ge(%s, %s)", predicate, b.param(formatTime(from)))
+	}
+	if !to.IsZero() {
+		le := fmt.Sprintf("le(%s, %s)", predicate, b.param(formatTime(to)))
+		if group == "" {
+			group = le
+		} else {
+			group = "(" + group + " AND " + le + ")"
+		}
+	}
+	if group != "" {
+		b.groups = append(b.groups, group)
+	}
+}
+
+// formatTime renders t the way dgraph parses datetime values.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,49 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package filter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matthewmcneely/modusgraph/typed/filter"
+)
+
+func TestBetweenTimeEmitsRangeAndFormatsBounds(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 23, 59, 59, 500, time.FixedZone("EST", -5*3600))
+	var b filter.Builder
+	b.BetweenTime("createdAt", from, to)
+	expr, params := b.Build()
+	if want := "(ge(createdAt, $1) AND le(createdAt, $2))"; expr != want {
+		t.Fatalf("expr = %q, want %q", expr, want)
+	}
+	if len(params) != 2 || params[0] != "2024-01-01T00:00:00Z" || params[1] != "2024-02-01T04:59:59.0000005Z" {
+		t.Fatalf("params = %v, want UTC RFC 3339 bounds", params)
+	}
+}
+
+func TestBetweenTimeOpenBounds(t *testing.T) {
+	ts := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     string
+	}{
+		{"from only", ts, time.Time{}, "ge(createdAt, $1)"},
+		{"to only", time.Time{}, ts, "le(createdAt, $1)"},
+		{"neither", time.Time{}, time.Time{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b filter.Builder
+			b.BetweenTime("createdAt", tt.from, tt.to)
+			if expr, _ := b.Build(); expr != tt.want {
+				t.Errorf("expr = %q, want %q", expr, tt.want)
+			}
+		})
+	}
+}
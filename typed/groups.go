@@ -0,0 +1,150 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// groupBlockPrefix names the blocks of a Groups request: mgGroup0 groups by
+// the first predicate, mgGroup1 by the first two, and so on.
+const groupBlockPrefix = "mgGroup"
+
+// Bucket is one group of a Groups result: the nodes sharing Key as their
+// value of Predicate (and the keys of every enclosing bucket). Aggregates
+// holds the group's aggregates, decoded into A by their json names; Buckets
+// splits the group by the next predicate, and is empty at the last level.
+type Bucket[A any] struct {
+	Predicate  string
+	Key        any
+	Aggregates A
+	Buckets    []Bucket[A]
+}
+
+// Groups runs qb grouped level by level by the predicates in by and returns
+// the groups as a tree of buckets: one bucket per value of by[0], each split
+// into one bucket per value of by[1], and so on. aggregates are the fields of
+// every group block — count(uid), or min, max, sum, or avg of a predicate —
+// optionally aliased so they decode into A:
+//
+//	type totals struct {
+//		Count  int     `json:"count"`
+//		Budget float64 `json:"budget"`
+//	}
+//	regions, err := typed.Groups[totals](depts.Query(ctx),
+//		[]string{"region", "division"}, "count(uid)", "budget : sum(budget)")
+//
+// Each level is its own @groupby block over the nodes qb matches, all run in
+// one request, so a bucket's aggregates cover every node in it rather than
+// being folded from the buckets below; avg is exact at every level. A node
+// without a value for a level's predicate counts in the buckets above that
+// level only.
+//
+// Groups is a terminal: it consumes qb, whose filters, root, and Vars apply.
+// Like FormatBlock it cannot run a query carrying WhereEdge constraints or
+// With blocks.
+func Groups[A, T any](qb *Query[T], by []string, aggregates ...string) (buckets []Bucket[A], err error) {
+	if qb.q == nil {
+		return nil, ErrDetachedQuery
+	}
+	if len(by) == 0 {
+		return nil, errors.New("typed: Groups needs at least one predicate to group by")
+	}
+	if len(aggregates) == 0 {
+		return nil, errors.New("typed: Groups needs at least one aggregate")
+	}
+	_, span := currentTracer().StartSpan(qb.ctx, "groups", entityName[T]())
+	defer func() { span.End(err) }()
+	if err = qb.guard(); err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	if qb.varsMap != nil {
+		b.WriteString("query ")
+		b.WriteString(qb.varsFuncDef)
+	}
+	b.WriteString("{\n")
+	body := "{\n\t" + strings.Join(aggregates, "\n\t") + "\n}"
+	for level := range by {
+		qb.q.GroupBy(strings.Join(by[:level+1], ", ")).Query(body)
+		block, err := qb.FormatBlock(groupBlockPrefix + strconv.Itoa(level))
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString(block)
+	}
+	b.WriteString("}")
+
+	raw, err := qb.conn.QueryRaw(qb.ctx, b.String(), qb.varsMap)
+	if err != nil {
+		return nil, fmt.Errorf("typed: Groups query: %w", err)
+	}
+	var perBlock map[string][]struct {
+		Groups []json.RawMessage `json:"@groupby"`
+	}
+	if err := json.Unmarshal(raw, &perBlock); err != nil {
+		return nil, fmt.Errorf("typed: decoding Groups response: %w", err)
+	}
+
+	// parents maps the keys of each bucket of the previous level to the
+	// bucket, so each group of the next level is attached beneath the bucket
+	// its leading keys match.
+	parents := map[string]*[]Bucket[A]{"": &buckets}
+	for level, pred := range by {
+		var groups []json.RawMessage
+		if rows := perBlock[groupBlockPrefix+strconv.Itoa(level)]; len(rows) > 0 {
+			groups = rows[0].Groups
+		}
+		type placed struct {
+			key  string
+			list *[]Bucket[A]
+			idx  int
+		}
+		var next []placed
+		for _, group := range groups {
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(group, &fields); err != nil {
+				return nil, fmt.Errorf("typed: decoding %s group: %w", pred, err)
+			}
+			parentKey := groupKey(fields, by[:level])
+			list, ok := parents[parentKey]
+			if !ok {
+				continue
+			}
+			bucket := Bucket[A]{Predicate: pred}
+			if err := json.Unmarshal(fields[pred], &bucket.Key); err != nil {
+				return nil, fmt.Errorf("typed: decoding %s group key: %w", pred, err)
+			}
+			if err := json.Unmarshal(group, &bucket.Aggregates); err != nil {
+				return nil, fmt.Errorf("typed: decoding %s group aggregates: %w", pred, err)
+			}
+			*list = append(*list, bucket)
+			next = append(next, placed{key: groupKey(fields, by[:level+1]), list: list, idx: len(*list) - 1})
+		}
+		// Index the new buckets only once the level is complete: appending
+		// may move a list, invalidating pointers taken into it.
+		parents = make(map[string]*[]Bucket[A], len(next))
+		for _, p := range next {
+			parents[p.key] = &(*p.list)[p.idx].Buckets
+		}
+	}
+	return buckets, nil
+}
+
+// groupKey identifies a group by its raw JSON values of preds.
+func groupKey(fields map[string]json.RawMessage, preds []string) string {
+	var b strings.Builder
+	for _, pred := range preds {
+		b.Write(fields[pred])
+		b.WriteByte(0)
+	}
+	return b.String()
+}
@@ -0,0 +1,119 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+type unit struct {
+	UID      string   `json:"uid,omitempty"`
+	DType    []string `json:"dgraph.type,omitempty"`
+	Name     string   `json:"name,omitempty" dgraph:"index=exact"`
+	Region   string   `json:"region,omitempty" dgraph:"index=exact"`
+	Division string   `json:"division,omitempty" dgraph:"index=exact"`
+	Budget   int      `json:"budget,omitempty"`
+}
+
+type deptTotals struct {
+	Count  int     `json:"count"`
+	Budget float64 `json:"budget"`
+	Avg    float64 `json:"avg"`
+}
+
+func TestGroups_TwoLevels(t *testing.T) {
+	ctx := context.Background()
+	depts := typed.NewClient[unit](newConn(t))
+	for _, d := range []*unit{
+		{Name: "a", Region: "eu", Division: "ops", Budget: 100},
+		{Name: "b", Region: "eu", Division: "ops", Budget: 50},
+		{Name: "c", Region: "eu", Division: "rnd", Budget: 300},
+		{Name: "d", Region: "us", Division: "rnd", Budget: 40},
+		{Name: "e", Region: "us", Budget: 10},
+	} {
+		if err := depts.Add(ctx, d); err != nil {
+			t.Fatalf("Add %s: %v", d.Name, err)
+		}
+	}
+
+	regions, err := typed.Groups[deptTotals](depts.Query(ctx),
+		[]string{"region", "division"},
+		"count(uid)", "budget : sum(budget)", "avg : avg(budget)")
+	if err != nil {
+		t.Fatalf("Groups: %v", err)
+	}
+
+	type leaf struct {
+		count  int
+		budget float64
+	}
+	want := map[string]struct {
+		totals    deptTotals
+		divisions map[string]leaf
+	}{
+		"eu": {deptTotals{Count: 3, Budget: 450, Avg: 150}, map[string]leaf{"ops": {2, 150}, "rnd": {1, 300}}},
+		// e has no division: it counts toward us, but toward no division.
+		"us": {deptTotals{Count: 2, Budget: 50, Avg: 25}, map[string]leaf{"rnd": {1, 40}}},
+	}
+	if len(regions) != len(want) {
+		t.Fatalf("got %d regions, want %d: %+v", len(regions), len(want), regions)
+	}
+	for _, r := range regions {
+		w, ok := want[r.Key.(string)]
+		if !ok {
+			t.Fatalf("unexpected region %v", r.Key)
+		}
+		if r.Predicate != "region" {
+			t.Errorf("region bucket Predicate = %q, want region", r.Predicate)
+		}
+		if r.Aggregates != w.totals {
+			t.Errorf("%v: Aggregates = %+v, want %+v", r.Key, r.Aggregates, w.totals)
+		}
+		if len(r.Buckets) != len(w.divisions) {
+			t.Fatalf("%v: got %d divisions, want %d: %+v", r.Key, len(r.Buckets), len(w.divisions), r.Buckets)
+		}
+		for _, d := range r.Buckets {
+			wd, ok := w.divisions[d.Key.(string)]
+			if !ok {
+				t.Fatalf("%v: unexpected division %v", r.Key, d.Key)
+			}
+			if d.Predicate != "division" {
+				t.Errorf("division bucket Predicate = %q, want division", d.Predicate)
+			}
+			if d.Aggregates.Count != wd.count || d.Aggregates.Budget != wd.budget {
+				t.Errorf("%v/%v: Aggregates = %+v, want count %d budget %v",
+					r.Key, d.Key, d.Aggregates, wd.count, wd.budget)
+			}
+			if len(d.Buckets) != 0 {
+				t.Errorf("%v/%v: leaf bucket has %d buckets", r.Key, d.Key, len(d.Buckets))
+			}
+		}
+	}
+}
+
+func TestGroups_FilterApplies(t *testing.T) {
+	ctx := context.Background()
+	depts := typed.NewClient[unit](newConn(t))
+	for _, d := range []*unit{
+		{Name: "a", Region: "eu", Division: "ops", Budget: 100},
+		{Name: "b", Region: "us", Division: "ops", Budget: 50},
+	} {
+		if err := depts.Add(ctx, d); err != nil {
+			t.Fatalf("Add %s: %v", d.Name, err)
+		}
+	}
+	regions, err := typed.Groups[deptTotals](
+		depts.Query(ctx).Filter(`eq(region, "eu")`), []string{"region"}, "count(uid)")
+	if err != nil {
+		t.Fatalf("Groups: %v", err)
+	}
+	if len(regions) != 1 || regions[0].Key != "eu" || regions[0].Aggregates.Count != 1 {
+		t.Fatalf("got %+v, want one eu bucket of 1", regions)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrFilterRequired is returned by a terminal on a query that requires a
+// filter (see RequireFilter and WithRequireFilter) but was never narrowed.
+var ErrFilterRequired = errors.New(
+	"typed: query requires a filter; add Filter, WhereEdge, UID, or RootFunc, or drop RequireFilter",
+)
+
+// ErrResponseTooLarge is returned by a terminal when the response to a query
+// exceeds the size MaxBytes allows.
+var ErrResponseTooLarge = errors.New("typed: query response exceeds the MaxBytes limit")
+
+// MaxResults caps the rows any terminal returns at n, protecting against
+// accidentally reading every node of a type. Unlike Limit it is a ceiling,
+// not a page size: a smaller Limit still applies, a larger one is clamped.
+// NodesAndCount still reports the uncapped total. n <= 0 removes the cap.
+// Repeated calls overwrite.
+func (qb *Query[T]) MaxResults(n int) *Query[T] {
+	qb.maxResults = max(n, 0)
+	return qb
+}
+
+// MaxBytes makes a terminal fail with ErrResponseTooLarge, rather than decode
+// the rows, when the response to the query is larger than n bytes of JSON. It
+// bounds the memory a query with a user-supplied filter can make the caller
+// spend on decoded records, which take several times the space of the JSON.
+// The engine still produces the response in full, so pair it with MaxResults
+// to bound that too. IterNodes applies it to each page. n <= 0 removes the
+// limit. Repeated calls overwrite.
+func (qb *Query[T]) MaxBytes(n int) *Query[T] {
+	qb.maxBytes = max(n, 0)
+	return qb
+}
+
+// checkSize enforces MaxBytes on a response of n bytes.
+func (qb *Query[T]) checkSize(n int) error {
+	if qb.maxBytes > 0 && n > qb.maxBytes {
+		return fmt.Errorf("%w: %d bytes, limit %d", ErrResponseTooLarge, n, qb.maxBytes)
+	}
+	return nil
+}
+
+// RequireFilter makes every terminal fail with ErrFilterRequired unless the
+// query is narrowed by Filter (or a helper built on it), WhereEdge, UID, or
+// RootFunc — a guard for production paths where an unfiltered query would
+// scan every node of the type.
+func (qb *Query[T]) RequireFilter() *Query[T] {
+	qb.requireFilter = true
+	return qb
+}
+
+// guard enforces RequireFilter and applies the MaxResults ceiling to the
+// query's row cap. Terminals call it before executing.
+func (qb *Query[T]) guard() error {
+	if qb.requireFilter && len(qb.filters) == 0 && len(qb.edges) == 0 &&
+		(qb.customRootExpr == "" || qb.typeRoot) {
+		return ErrFilterRequired
+	}
+	if qb.maxResults > 0 && (qb.limit == 0 || qb.limit > qb.maxResults) {
+		qb.q.First(qb.maxResults)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+func seedWidgets(t *testing.T, conn modusgraph.Client, n int) {
+	t.Helper()
+	c := typed.NewClient[widget](conn)
+	for i := range n {
+		if err := c.Add(context.Background(), &widget{Name: fmt.Sprintf("w%02d", i), Qty: i}); err != nil {
+			t.Fatalf("Add %d: %v", i, err)
+		}
+	}
+}
+
+func TestQuery_UnguardedReturnsAll(t *testing.T) {
+	conn := newConn(t)
+	seedWidgets(t, conn, 6)
+
+	rows, err := typed.NewClient[widget](conn).Query(context.Background()).Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	if len(rows) != 6 {
+		t.Fatalf("got %d rows, want all 6", len(rows))
+	}
+}
+
+func TestQuery_MaxResultsCaps(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+	seedWidgets(t, conn, 6)
+	c := typed.NewClient[widget](conn)
+
+	rows, err := c.Query(ctx).MaxResults(4).Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Errorf("MaxResults(4) returned %d rows, want 4", len(rows))
+	}
+
+	rows, err = c.Query(ctx).MaxResults(4).Limit(2).Nodes()
+	if err != nil {
+		t.Fatalf("Nodes with smaller Limit: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Errorf("Limit(2) under MaxResults(4) returned %d rows, want 2", len(rows))
+	}
+
+	rows, count, err := c.Query(ctx).MaxResults(3).Limit(5).NodesAndCount()
+	if err != nil {
+		t.Fatalf("NodesAndCount: %v", err)
+	}
+	if len(rows) != 3 || count != 6 {
+		t.Errorf("NodesAndCount = %d rows, count %d; want 3 rows, count 6", len(rows), count)
+	}
+
+	streamed := 0
+	for _, err := range c.Query(ctx).MaxResults(5).IterNodes() {
+		if err != nil {
+			t.Fatalf("IterNodes: %v", err)
+		}
+		streamed++
+	}
+	if streamed != 5 {
+		t.Errorf("IterNodes under MaxResults(5) streamed %d rows, want 5", streamed)
+	}
+
+	capped := typed.NewClient[widget](conn, typed.WithMaxResults(2))
+	rows, err = capped.Query(ctx).Nodes()
+	if err != nil {
+		t.Fatalf("Nodes with client default: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Errorf("WithMaxResults(2) returned %d rows, want 2", len(rows))
+	}
+}
+
+func TestQuery_RequireFilter(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+	seedWidgets(t, conn, 3)
+	c := typed.NewClient[widget](conn)
+
+	if _, err := c.Query(ctx).RequireFilter().Nodes(); !errors.Is(err, typed.ErrFilterRequired) {
+		t.Errorf("Nodes() error = %v, want ErrFilterRequired", err)
+	}
+	if _, err := c.Query(ctx).RequireFilter().First(); !errors.Is(err, typed.ErrFilterRequired) {
+		t.Errorf("First() error = %v, want ErrFilterRequired", err)
+	}
+	for _, err := range c.Query(ctx).RequireFilter().IterNodes() {
+		if !errors.Is(err, typed.ErrFilterRequired) {
+			t.Errorf("IterNodes() error = %v, want ErrFilterRequired", err)
+		}
+	}
+
+	rows, err := c.Query(ctx).RequireFilter().Filter("eq(name, $1)", "w01").Nodes()
+	if err != nil {
+		t.Fatalf("filtered Nodes: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Name != "w01" {
+		t.Errorf("filtered Nodes = %+v, want just w01", rows)
+	}
+
+	guarded := typed.NewClient[widget](conn, typed.WithRequireFilter())
+	if _, _, err := guarded.Query(ctx).NodesAndCount(); !errors.Is(err, typed.ErrFilterRequired) {
+		t.Errorf("WithRequireFilter NodesAndCount() error = %v, want ErrFilterRequired", err)
+	}
+	if _, err := guarded.Query(ctx).RootFunc(`eq(name, "w02")`).First(); err != nil {
+		t.Errorf("RootFunc should satisfy RequireFilter, got %v", err)
+	}
+}
+
+func TestQuery_MaxBytes(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+	seedWidgets(t, conn, 20)
+	c := typed.NewClient[widget](conn)
+
+	if _, err := c.Query(ctx).MaxBytes(200).Nodes(); !errors.Is(err, typed.ErrResponseTooLarge) {
+		t.Fatalf("20 widgets under MaxBytes(200): err = %v, want ErrResponseTooLarge", err)
+	}
+	if _, _, err := c.Query(ctx).MaxBytes(200).NodesAndCount(); !errors.Is(err, typed.ErrResponseTooLarge) {
+		t.Errorf("NodesAndCount under MaxBytes(200): err = %v, want ErrResponseTooLarge", err)
+	}
+	// Untyped runs as a multi-block request, which is measured whole.
+	if _, err := c.Query(ctx).MaxBytes(200).Untyped("qty").Nodes(); !errors.Is(err, typed.ErrResponseTooLarge) {
+		t.Errorf("Untyped under MaxBytes(200): err = %v, want ErrResponseTooLarge", err)
+	}
+
+	// A narrower query fits.
+	rows, err := c.Query(ctx).MaxBytes(200).Filter("eq(name, $1)", "w03").Nodes()
+	if err != nil {
+		t.Fatalf("one widget under MaxBytes(200): %v", err)
+	}
+	if len(rows) != 1 || rows[0].Qty != 3 {
+		t.Errorf("got %+v, want w03", rows)
+	}
+	rows, err = c.Query(ctx).MaxBytes(200).Limit(2).Nodes()
+	if err != nil || len(rows) != 2 {
+		t.Errorf("two widgets under MaxBytes(200) = %d rows, %v; want 2", len(rows), err)
+	}
+	if rows, err := c.Query(ctx).MaxBytes(0).Nodes(); err != nil || len(rows) != 20 {
+		t.Errorf("MaxBytes(0) = %d rows, %v; want all 20", len(rows), err)
+	}
+}
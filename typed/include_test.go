@@ -0,0 +1,57 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+// staffMember has a field only some callers may see.
+type staffMember struct {
+	UID    string   `json:"uid,omitempty"`
+	DType  []string `json:"dgraph.type,omitempty"`
+	Name   string   `json:"staff_name,omitempty" dgraph:"index=exact"`
+	Salary int      `json:"salary,omitempty"`
+}
+
+func TestQuery_IncludeIf(t *testing.T) {
+	ctx := context.Background()
+	staff := typed.NewClient[staffMember](newConn(t))
+	if err := staff.Add(ctx, &staffMember{Name: "Ada", Salary: 120000}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	// query is the one query every caller runs; only admins see salaries.
+	query := func(admin bool) *typed.Query[staffMember] {
+		return staff.Query(ctx).IncludeIf(admin, "salary")
+	}
+
+	for _, admin := range []bool{true, false} {
+		q := query(admin)
+		if got := strings.Contains(q.String(), "salary"); got != admin {
+			t.Errorf("admin=%v: salary selected = %v:\n%s", admin, got, q.String())
+		}
+		rows, err := q.Nodes()
+		if err != nil {
+			t.Fatalf("admin=%v: Nodes: %v", admin, err)
+		}
+		if len(rows) != 1 || rows[0].Name != "Ada" {
+			t.Fatalf("admin=%v: got %+v, want Ada", admin, rows)
+		}
+		if want := map[bool]int{true: 120000, false: 0}[admin]; rows[0].Salary != want {
+			t.Errorf("admin=%v: Salary = %d, want %d", admin, rows[0].Salary, want)
+		}
+	}
+
+	q := staff.Query(ctx).IncludeIf(false, "salary").IncludeIf(true, "salary")
+	if !strings.Contains(q.String(), "salary") {
+		t.Errorf("a later IncludeIf(true) should restore the field:\n%s", q.String())
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph/typed"
+	"github.com/matthewmcneely/modusgraph/typed/filter"
+)
+
+func TestQuery_FilterInList(t *testing.T) {
+	ctx := context.Background()
+	logs := typed.NewClient[logEntry](newConn(t))
+	for _, msg := range []string{"open", "pending", "closed", "archived"} {
+		if err := logs.Add(ctx, &logEntry{Message: msg}); err != nil {
+			t.Fatalf("Add %s: %v", msg, err)
+		}
+	}
+
+	var b filter.Builder
+	b.InList("log_message", "open", "pending", "missing")
+	expr, params := b.Build()
+	rows, err := logs.Query(ctx).Filter(expr, params...).OrderAsc("log_message").Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Message != "open" || rows[1].Message != "pending" {
+		t.Fatalf("got %+v, want open and pending", rows)
+	}
+}
+
+func TestQuery_FilterUIDIn(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+	depts := typed.NewClient[department](conn)
+	courses := typed.NewClient[course](conn)
+	deptUIDs := make(map[string]string)
+	for _, name := range []string{"Physics", "Chemistry", "Biology"} {
+		d := &department{Name: name}
+		if err := depts.Add(ctx, d); err != nil {
+			t.Fatalf("Add department %s: %v", name, err)
+		}
+		deptUIDs[name] = d.UID
+		c := &course{Name: name + "101", InDepartment: &department{UID: d.UID}}
+		if err := courses.Add(ctx, c); err != nil {
+			t.Fatalf("Add course %s: %v", c.Name, err)
+		}
+	}
+
+	var b filter.Builder
+	b.UIDIn("in_department", deptUIDs["Physics"], deptUIDs["Biology"])
+	expr, params := b.Build()
+	rows, err := courses.Query(ctx).Filter(expr, params...).Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	var names []string
+	for _, c := range rows {
+		names = append(names, c.Name)
+	}
+	slices.Sort(names)
+	if !slices.Equal(names, []string{"Biology101", "Physics101"}) {
+		t.Fatalf("got %v, want Biology101 and Physics101", names)
+	}
+}
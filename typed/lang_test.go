@@ -0,0 +1,71 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+// greeting holds a language-tagged name.
+type greeting struct {
+	UID   string   `json:"uid,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+	Name  string   `json:"greeting_name,omitempty" dgraph:"lang"`
+	Code  string   `json:"greeting_code,omitempty" dgraph:"index=exact"`
+}
+
+// germanGreeting writes a greeting whose name is tagged German only.
+type germanGreeting struct {
+	UID   string   `json:"uid,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+	Name  string   `json:"greeting_name@de,omitempty"`
+	Code  string   `json:"greeting_code,omitempty"`
+}
+
+func TestQuery_LangFallback(t *testing.T) {
+	ctx := context.Background()
+	conn, err := modusgraph.NewClient("file://" + t.TempDir())
+	if err != nil {
+		t.Fatalf("modusgraph.NewClient: %v", err)
+	}
+	t.Cleanup(conn.Close)
+	if err := conn.UpdateSchema(ctx, &greeting{}); err != nil {
+		t.Fatalf("UpdateSchema: %v", err)
+	}
+	if err := conn.Insert(ctx, &germanGreeting{
+		DType: []string{"greeting"},
+		Name:  "Guten Tag",
+		Code:  "de-only",
+	}); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+
+	greetings := typed.NewClient[greeting](conn)
+	plain, err := greetings.Query(ctx).Filter(`eq(greeting_code, "de-only")`).First()
+	if err != nil {
+		t.Fatalf("First without LangFallback: %v", err)
+	}
+	if plain.Name != "" {
+		t.Fatalf("untagged read of a German-only name = %q, want empty", plain.Name)
+	}
+
+	q := greetings.Query(ctx).Filter(`eq(greeting_code, "de-only")`).LangFallback("en", "de", ".")
+	if s := q.Raw().String(); !strings.Contains(s, "greeting_name : greeting_name@en:de:.") {
+		t.Fatalf("LangFallback should alias the fallback chain onto the field; got:\n%s", s)
+	}
+	got, err := q.First()
+	if err != nil {
+		t.Fatalf("First with LangFallback: %v", err)
+	}
+	if got.Name != "Guten Tag" || got.Code != "de-only" {
+		t.Fatalf("LangFallback(en, de, .) = %+v, want the German name", got)
+	}
+}
@@ -87,7 +87,8 @@ func (mq *MultiQuery[T]) Execute(ctx context.Context) (map[string][]T, error) {
 		return map[string][]T{}, nil
 	}
 
-	rawBlocks := make([]*dg.Query, 0, len(mq.names))
+	var dql strings.Builder
+	dql.WriteString("{\n")
 	for _, name := range mq.names {
 		block := mq.blocks[name]
 		if len(block.edges) != 0 {
@@ -98,11 +99,18 @@ func (mq *MultiQuery[T]) Execute(ctx context.Context) (map[string][]T, error) {
 		// Name the underlying dgman query so blocks do not collide on the
 		// default "data" name and so the response JSON keys are predictable.
 		block.q.Name(name)
-		rawBlocks = append(rawBlocks, block.q)
+		// Each block renders on its own so an Untyped block can shed the
+		// type guard without the others losing theirs.
+		rendered := dg.NewQueryBlock(block.q).String()
+		rendered = strings.TrimSuffix(strings.TrimPrefix(rendered, "{\n"), "}")
+		if block.untyped {
+			rendered = dropTypeGuard(rendered)
+		}
+		dql.WriteString(rendered)
 	}
+	dql.WriteString("}")
 
-	dql := dg.NewQueryBlock(rawBlocks...).String()
-	raw, err := mq.conn.QueryRaw(ctx, dql, nil)
+	raw, err := mq.conn.QueryRaw(ctx, dql.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("multi_query: dgraph: %w", err)
 	}
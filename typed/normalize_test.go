@@ -0,0 +1,147 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+// enrollment links a student to a course, completing the
+// enrollment -> course -> department chain used by the @normalize test.
+type enrollment struct {
+	UID      string   `json:"uid,omitempty"`
+	DType    []string `json:"dgraph.type,omitempty"`
+	Student  string   `json:"student_name,omitempty" dgraph:"index=exact"`
+	InCourse *course  `json:"in_course,omitempty"`
+}
+
+// enrollmentRow is the flat row a normalized traversal decodes into.
+type enrollmentRow struct {
+	Student string `json:"student"`
+	Course  string `json:"course"`
+	Dept    string `json:"dept"`
+}
+
+func TestQuery_NormalizeFlattensTraversal(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+
+	physics := &department{Name: "Physics"}
+	history := &department{Name: "History"}
+	for _, d := range []*department{physics, history} {
+		if err := typed.NewClient[department](conn).Add(ctx, d); err != nil {
+			t.Fatalf("Add department: %v", err)
+		}
+	}
+	mechanics := &course{Name: "Mechanics", InDepartment: &department{UID: physics.UID}}
+	rome := &course{Name: "Rome", InDepartment: &department{UID: history.UID}}
+	for _, c := range []*course{mechanics, rome} {
+		if err := typed.NewClient[course](conn).Add(ctx, c); err != nil {
+			t.Fatalf("Add course: %v", err)
+		}
+	}
+	enrollments := typed.NewClient[enrollment](conn)
+	for _, e := range []*enrollment{
+		{Student: "Ada", InCourse: &course{UID: mechanics.UID}},
+		{Student: "Ada", InCourse: &course{UID: rome.UID}},
+		{Student: "Grace", InCourse: &course{UID: mechanics.UID}},
+	} {
+		if err := enrollments.Add(ctx, e); err != nil {
+			t.Fatalf("Add enrollment: %v", err)
+		}
+	}
+
+	rows, err := typed.NewClient[enrollmentRow](conn).Query(ctx).
+		RootFunc("type(enrollment)").
+		Select(`{
+			student: student_name
+			in_course {
+				course: course_name
+				in_department { dept: dept_name }
+			}
+		}`).
+		Normalize().
+		Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+
+	got := make([]string, 0, len(rows))
+	for _, r := range rows {
+		got = append(got, r.Student+"/"+r.Course+"/"+r.Dept)
+	}
+	sort.Strings(got)
+	want := []string{"Ada/Mechanics/Physics", "Ada/Rome/History", "Grace/Mechanics/Physics"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("normalized rows = %v, want %v", got, want)
+	}
+}
+
+func TestQuery_NormalizeRendersDirective(t *testing.T) {
+	q := typed.NewClient[enrollmentRow](newConn(t)).Query(context.Background()).
+		Cascade().
+		Select(`{ student: student_name }`).
+		Normalize()
+	if dql := q.String(); !strings.Contains(dql, "@cascade @normalize { student: student_name }") {
+		t.Errorf("@normalize missing or misplaced:\n%s", dql)
+	}
+	if dql := q.All(0).String(); strings.Contains(dql, "@normalize") {
+		t.Errorf("All should discard Normalize, got:\n%s", dql)
+	}
+}
+
+// courseRow is the flat row of a department normalized over its courses.
+type courseRow struct {
+	Dept   string `json:"dept"`
+	Course string `json:"course"`
+}
+
+func TestQuery_NormalizeLimit(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+
+	dept := &department{Name: "Physics"}
+	if err := typed.NewClient[department](conn).Add(ctx, dept); err != nil {
+		t.Fatalf("Add department: %v", err)
+	}
+	// Each normalized row merges the department's alias with a course's, so
+	// 6000 courses carry the traversal past the default limit of 10000 nodes.
+	const courses = 6000
+	batch := make([]*course, courses)
+	for i := range batch {
+		batch[i] = &course{Name: fmt.Sprintf("PHY%d", i), InDepartment: &department{UID: dept.UID}}
+	}
+	if err := conn.Insert(ctx, batch); err != nil {
+		t.Fatalf("Insert courses: %v", err)
+	}
+
+	query := func() *typed.Query[courseRow] {
+		return typed.NewClient[courseRow](conn).Query(ctx).
+			UID(dept.UID).
+			Select(`{ dept: dept_name ~in_department { course: course_name } }`).
+			Normalize()
+	}
+	if _, err := query().Nodes(); err == nil || !strings.Contains(err.Error(), "too many results") {
+		t.Fatalf("default limit: err = %v, want a too-many-results rejection", err)
+	}
+	rows, err := query().NormalizeLimit(2 * modusgraph.DefaultLimitNormalizeNode).Nodes()
+	if err != nil {
+		t.Fatalf("raised limit: %v", err)
+	}
+	if len(rows) != courses {
+		t.Fatalf("got %d rows, want %d", len(rows), courses)
+	}
+	if rows[0].Dept != "Physics" || rows[0].Course == "" {
+		t.Errorf("row = %+v, want a Physics course", rows[0])
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	dg "github.com/dolan-in/dgman/v2"
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+// document carries an embedding for the OfType similarity test.
+type document struct {
+	UID       string            `json:"uid,omitempty"`
+	DType     []string          `json:"dgraph.type,omitempty"`
+	Title     string            `json:"doc_title,omitempty" dgraph:"index=exact"`
+	Embedding *dg.VectorFloat32 `json:"doc_embedding,omitempty" dgraph:"index=hnsw(metric:\"euclidean\")"`
+}
+
+// documentTitle is a projection of document; its own type name matches no
+// nodes, so reading it needs OfType.
+type documentTitle struct {
+	UID   string `json:"uid,omitempty"`
+	Title string `json:"doc_title,omitempty"`
+}
+
+func TestQuery_OfTypeWithSimilarityFilter(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+	docs := typed.NewClient[document](conn)
+	for _, d := range []*document{
+		{Title: "a", Embedding: &dg.VectorFloat32{Values: []float32{1, 0, 0}}},
+		{Title: "b", Embedding: &dg.VectorFloat32{Values: []float32{0.9, 0.1, 0}}},
+		{Title: "c", Embedding: &dg.VectorFloat32{Values: []float32{0.8, 0.2, 0}}},
+		{Title: "d", Embedding: &dg.VectorFloat32{Values: []float32{0, 0, 1}}},
+	} {
+		if err := docs.Add(ctx, d); err != nil {
+			t.Fatalf("Add %s: %v", d.Title, err)
+		}
+	}
+
+	titles := typed.NewClient[documentTitle](conn)
+	if got, err := titles.Query(ctx).Nodes(); err != nil || len(got) != 0 {
+		t.Fatalf("projection without OfType = %v, %v; want no records", got, err)
+	}
+
+	// The three nearest neighbours of the query vector, ordered by title
+	// descending and paged past the first.
+	q := titles.Query(ctx).
+		OfType("document").
+		Filter("similar_to(doc_embedding, 3, $1)", "[1, 0, 0]").
+		OrderDesc("doc_title").
+		Offset(1).
+		Limit(2)
+	if s := q.Raw().String(); !strings.Contains(s, "func: type(document), first: 2, offset: 1, orderdesc: doc_title) @filter(") {
+		t.Fatalf("OfType should root at the type with the filter applied after; got:\n%s", s)
+	}
+	got, err := q.Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	var names []string
+	for _, d := range got {
+		names = append(names, d.Title)
+	}
+	if strings.Join(names, ",") != "b,a" {
+		t.Fatalf("OfType similarity page = %v, want [b a]", names)
+	}
+
+	// OfType alone still reads the whole type, so it does not satisfy
+	// RequireFilter.
+	if _, err := titles.Query(ctx).RequireFilter().OfType("document").Nodes(); !errors.Is(err, typed.ErrFilterRequired) {
+		t.Fatalf("OfType without a filter under RequireFilter: err = %v, want ErrFilterRequired", err)
+	}
+}
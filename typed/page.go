@@ -0,0 +1,128 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrPageUnordered is returned by Page on a query with an order (OrderAsc,
+// OrderDesc), an Offset, or a Last: a UID cursor pages in UID order only.
+var ErrPageUnordered = errors.New(
+	"typed: Page pages in UID order; it cannot be combined with OrderAsc, OrderDesc, Offset, or Last",
+)
+
+// ErrInvalidCursor is returned by Page when the cursor is not an EndCursor
+// Page returned: a 0x-prefixed hex UID.
+var ErrInvalidCursor = errors.New("typed: page cursor must be a 0x-prefixed hex UID")
+
+// PageResult is one page of a relay-style paginated query: the records, in
+// UID order, whether any follow, and the cursor to pass to Page for the next
+// page.
+type PageResult[T any] struct {
+	Items []T
+
+	// HasNextPage reports whether records follow the last of Items.
+	HasNextPage bool
+
+	// EndCursor is the UID of the last of Items, or the cursor the page was
+	// read after if Items is empty. Passing it back to Page reads the next
+	// page.
+	EndCursor string
+}
+
+// Page executes the query and returns up to pageSize records whose UIDs
+// follow cursor, in UID order; an empty cursor starts from the first record.
+// It reads one record past the page to tell whether another page follows, so
+// HasNextPage is false on the last page without an extra, empty request.
+//
+// Cursors are UIDs, so they stay valid as records are added or deleted:
+// a page never repeats a record, and skips only records deleted since. Page
+// is a terminal; an order, an Offset, or a Last fail with ErrPageUnordered.
+// MaxResults caps pageSize.
+func (qb *Query[T]) Page(pageSize int, cursor string) (page PageResult[T], err error) {
+	if qb.q == nil {
+		return page, ErrDetachedQuery
+	}
+	if pageSize <= 0 {
+		return page, fmt.Errorf("typed: page size must be positive, got %d", pageSize)
+	}
+	if len(qb.orders) > 0 || qb.offset > 0 || qb.last {
+		return page, ErrPageUnordered
+	}
+	if cursor != "" && !isHexUID(cursor) {
+		return page, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+	}
+	_, span := currentTracer().StartSpan(qb.ctx, "query", entityName[T]())
+	defer func() { span.End(err) }()
+	if err = qb.guard(); err != nil {
+		return page, err
+	}
+	if qb.maxResults > 0 && pageSize > qb.maxResults {
+		pageSize = qb.maxResults
+	}
+
+	if cursor != "" {
+		qb.q.After(cursor)
+	}
+	qb.q.First(pageSize + 1)
+	var rows []T
+	if qb.multiBlock() {
+		rows, _, err = qb.runEdge(false)
+	} else {
+		err = qb.decode(&rows, func(dst any) error { return qb.q.Nodes(dst) })
+	}
+	if err != nil {
+		return page, err
+	}
+
+	page.EndCursor = cursor
+	if len(rows) > pageSize {
+		rows, page.HasNextPage = rows[:pageSize], true
+	}
+	if len(rows) > 0 {
+		page.EndCursor = recordUID(&rows[len(rows)-1])
+	}
+	page.Items = rows
+	return page, nil
+}
+
+// Page returns up to pageSize records of T whose UIDs follow cursor; see
+// Query.Page.
+func (c *Client[T]) Page(ctx context.Context, pageSize int, cursor string) (PageResult[T], error) {
+	return c.Query(ctx).Page(pageSize, cursor)
+}
+
+// isHexUID reports whether s is a 0x-prefixed hex UID.
+func isHexUID(s string) bool {
+	hex, ok := strings.CutPrefix(s, "0x")
+	if !ok || hex == "" {
+		return false
+	}
+	_, err := strconv.ParseUint(hex, 16, 64)
+	return err == nil
+}
+
+// recordUID returns the value of rec's uid field, or "" if T has none.
+func recordUID[T any](rec *T) string {
+	v := reflect.ValueOf(rec).Elem()
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0] == "uid" {
+			if s, ok := v.Field(i).Interface().(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,135 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+func TestClient_PageThroughDataset(t *testing.T) {
+	ctx := context.Background()
+	c := typed.NewClient[widget](newConn(t))
+	var want []string
+	for i := range 7 {
+		w := &widget{Name: fmt.Sprintf("w%d", i), Qty: i}
+		if err := c.Add(ctx, w); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+		want = append(want, w.UID)
+	}
+
+	var got []string
+	var pages []typed.PageResult[widget]
+	cursor := ""
+	for {
+		page, err := c.Page(ctx, 3, cursor)
+		if err != nil {
+			t.Fatalf("Page after %q: %v", cursor, err)
+		}
+		pages = append(pages, page)
+		for _, w := range page.Items {
+			got = append(got, w.UID)
+		}
+		if !page.HasNextPage {
+			break
+		}
+		if page.EndCursor != page.Items[len(page.Items)-1].UID {
+			t.Fatalf("EndCursor %q is not the last item's UID %q", page.EndCursor, page.Items[len(page.Items)-1].UID)
+		}
+		cursor = page.EndCursor
+	}
+
+	if len(pages) != 3 || len(pages[0].Items) != 3 || len(pages[1].Items) != 3 || len(pages[2].Items) != 1 {
+		t.Fatalf("got pages of %d, want 3, 3, 1", len(pages))
+	}
+	if !pages[0].HasNextPage || !pages[1].HasNextPage || pages[2].HasNextPage {
+		t.Fatalf("HasNextPage = %v, %v, %v; want true, true, false",
+			pages[0].HasNextPage, pages[1].HasNextPage, pages[2].HasNextPage)
+	}
+	slices.SortFunc(want, compareUIDs)
+	if !slices.Equal(got, want) {
+		t.Fatalf("paged UIDs %v, want every record once in UID order %v", got, want)
+	}
+
+	// The cursor round-trips: passing a page's EndCursor back reads the same
+	// next page again.
+	again, err := c.Page(ctx, 3, pages[0].EndCursor)
+	if err != nil {
+		t.Fatalf("Page: %v", err)
+	}
+	if len(again.Items) != 3 || again.Items[0].UID != pages[1].Items[0].UID || again.EndCursor != pages[1].EndCursor {
+		t.Fatalf("re-reading page 2 gave %+v, want %+v", again, pages[1])
+	}
+
+	// Past the last record the page is empty and keeps its cursor.
+	end, err := c.Page(ctx, 3, pages[2].EndCursor)
+	if err != nil {
+		t.Fatalf("Page: %v", err)
+	}
+	if len(end.Items) != 0 || end.HasNextPage || end.EndCursor != pages[2].EndCursor {
+		t.Fatalf("page past the end = %+v", end)
+	}
+}
+
+func TestQuery_PageFiltered(t *testing.T) {
+	ctx := context.Background()
+	c := typed.NewClient[widget](newConn(t))
+	for i := range 5 {
+		if err := c.Add(ctx, &widget{Name: fmt.Sprintf("w%d", i), Qty: i}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	page, err := c.Query(ctx).Filter(`ge(qty, 2)`).Page(2, "")
+	if err != nil {
+		t.Fatalf("Page: %v", err)
+	}
+	if len(page.Items) != 2 || !page.HasNextPage {
+		t.Fatalf("first page = %+v, want 2 items and a next page", page)
+	}
+	page, err = c.Query(ctx).Filter(`ge(qty, 2)`).Page(2, page.EndCursor)
+	if err != nil {
+		t.Fatalf("Page: %v", err)
+	}
+	if len(page.Items) != 1 || page.HasNextPage {
+		t.Fatalf("last page = %+v, want 1 item and no next page", page)
+	}
+}
+
+func TestQuery_PageRejectsOrderAndBadCursor(t *testing.T) {
+	ctx := context.Background()
+	c := typed.NewClient[widget](newConn(t))
+
+	if _, err := c.Query(ctx).OrderAsc("name").Page(2, ""); !errors.Is(err, typed.ErrPageUnordered) {
+		t.Fatalf("ordered Page err = %v, want ErrPageUnordered", err)
+	}
+	if _, err := c.Page(ctx, 2, "w3"); !errors.Is(err, typed.ErrInvalidCursor) {
+		t.Fatalf("Page with a bad cursor err = %v, want ErrInvalidCursor", err)
+	}
+	if _, err := c.Page(ctx, 0, ""); err == nil {
+		t.Fatal("Page with a zero page size succeeded")
+	}
+}
+
+// compareUIDs orders 0x-prefixed hex UIDs numerically.
+func compareUIDs(a, b string) int {
+	var x, y uint64
+	fmt.Sscanf(a, "0x%x", &x)
+	fmt.Sscanf(b, "0x%x", &y)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
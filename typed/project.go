@@ -0,0 +1,148 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// projectBlock names the block of a Project request.
+const projectBlock = "mgProject"
+
+// Project runs qb and returns its nodes as flat rows of R, each field of R
+// read from a predicate of the node or of a node its edges reach. A field
+// names its source in a project tag, edges and the predicate joined by dots,
+// and the json name is both the alias and the key the value decodes from; a
+// field without a project tag reads the root predicate of its json name:
+//
+//	type enrollmentRow struct {
+//		Student string `json:"student" project:"student_name"`
+//		Course  string `json:"course" project:"in_course.course_name"`
+//	}
+//	rows, err := typed.Project[enrollmentRow](enrollments.Query(ctx))
+//
+// It is Select and Normalize without the hand-written selection: the fields
+// are rendered as an aliased @normalize selection, which yields one row per
+// path through the edges, so a node with two courses gives two rows and a
+// node with none gives one without the course. A "uid" source reads the
+// node's UID, and "in_course.uid" its course's.
+//
+// Project is a terminal: it consumes qb, whose root, filters, ordering,
+// pagination, and Vars apply to the root nodes. Like FormatBlock it cannot
+// run a query carrying WhereEdge constraints or With blocks.
+func Project[R, T any](qb *Query[T]) (rows []R, err error) {
+	if qb.q == nil {
+		return nil, ErrDetachedQuery
+	}
+	_, span := currentTracer().StartSpan(qb.ctx, "project", entityName[T]())
+	defer func() { span.End(err) }()
+	if err = qb.guard(); err != nil {
+		return nil, err
+	}
+	body, err := projectSelection(reflect.TypeFor[R]())
+	if err != nil {
+		return nil, err
+	}
+
+	qb.Select(body).Normalize()
+	block, err := qb.FormatBlock(projectBlock)
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	if qb.varsMap != nil {
+		b.WriteString("query ")
+		b.WriteString(qb.varsFuncDef)
+	}
+	b.WriteString("{\n")
+	b.WriteString(block)
+	b.WriteString("}")
+
+	raw, err := qb.conn.QueryRaw(qb.ctx, b.String(), qb.varsMap)
+	if err != nil {
+		return nil, fmt.Errorf("typed: Project query: %w", err)
+	}
+	if err := qb.checkSize(len(raw)); err != nil {
+		return nil, err
+	}
+	var resp map[string][]R
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("typed: decoding Project response: %w", err)
+	}
+	rows = resp[projectBlock]
+	if rows == nil {
+		rows = []R{}
+	}
+	return rows, nil
+}
+
+// projectNode is one level of a Project selection: the aliased predicates
+// read at that level and the edges followed from it, in field order.
+type projectNode struct {
+	fields []string // "alias : predicate"
+	edges  []string
+	next   map[string]*projectNode
+}
+
+// projectSelection renders the aliased selection set the fields of the row
+// type t read through their project tags.
+func projectSelection(t reflect.Type) (string, error) {
+	t = getElemType(t)
+	if t == nil || t.Kind() != reflect.Struct {
+		return "", fmt.Errorf("typed: Project needs a struct row type, not %v", t)
+	}
+	root := &projectNode{next: map[string]*projectNode{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		alias := strings.Split(field.Tag.Get("json"), ",")[0]
+		if !field.IsExported() || alias == "-" {
+			continue
+		}
+		if alias == "" {
+			alias = field.Name
+		}
+		source := field.Tag.Get("project")
+		if source == "" {
+			source = alias
+		}
+		path := strings.Split(source, ".")
+		node := root
+		for _, edge := range path[:len(path)-1] {
+			next, ok := node.next[edge]
+			if !ok {
+				next = &projectNode{next: map[string]*projectNode{}}
+				node.next[edge] = next
+				node.edges = append(node.edges, edge)
+			}
+			node = next
+		}
+		node.fields = append(node.fields, alias+" : "+path[len(path)-1])
+	}
+	if len(root.fields) == 0 && len(root.edges) == 0 {
+		return "", fmt.Errorf("typed: Project row type %v has no fields", t)
+	}
+	var b strings.Builder
+	root.write(&b, 1)
+	return b.String(), nil
+}
+
+// write renders n as a braced block indented depth tabs.
+func (n *projectNode) write(b *strings.Builder, depth int) {
+	indent := strings.Repeat("\t", depth)
+	b.WriteString("{\n")
+	for _, f := range n.fields {
+		b.WriteString(indent + f + "\n")
+	}
+	for _, edge := range n.edges {
+		b.WriteString(indent + edge + " ")
+		n.next[edge].write(b, depth+1)
+		b.WriteString("\n")
+	}
+	b.WriteString(strings.Repeat("\t", depth-1) + "}")
+}
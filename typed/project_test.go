@@ -0,0 +1,88 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+// enrollmentProjection is an enrollment with its course and department
+// flattened into one row.
+type enrollmentProjection struct {
+	ID      string `json:"id" project:"uid"`
+	Student string `json:"student" project:"student_name"`
+	Course  string `json:"course" project:"in_course.course_name"`
+	Dept    string `json:"dept" project:"in_course.in_department.dept_name"`
+}
+
+func TestProject_FlattensEdges(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+
+	physics := &department{Name: "Physics"}
+	if err := typed.NewClient[department](conn).Add(ctx, physics); err != nil {
+		t.Fatalf("Add department: %v", err)
+	}
+	mechanics := &course{Name: "Mechanics", InDepartment: &department{UID: physics.UID}}
+	optics := &course{Name: "Optics", InDepartment: &department{UID: physics.UID}}
+	for _, c := range []*course{mechanics, optics} {
+		if err := typed.NewClient[course](conn).Add(ctx, c); err != nil {
+			t.Fatalf("Add course: %v", err)
+		}
+	}
+	enrollments := typed.NewClient[enrollment](conn)
+	ada := &enrollment{Student: "Ada", InCourse: &course{UID: mechanics.UID}}
+	for _, e := range []*enrollment{
+		ada,
+		{Student: "Grace", InCourse: &course{UID: optics.UID}},
+		{Student: "Linus"},
+	} {
+		if err := enrollments.Add(ctx, e); err != nil {
+			t.Fatalf("Add enrollment: %v", err)
+		}
+	}
+
+	rows, err := typed.Project[enrollmentProjection](enrollments.Query(ctx))
+	if err != nil {
+		t.Fatalf("Project: %v", err)
+	}
+	got := make([]string, 0, len(rows))
+	for _, r := range rows {
+		got = append(got, fmt.Sprintf("%s/%s/%s", r.Student, r.Course, r.Dept))
+	}
+	sort.Strings(got)
+	if want := "Ada/Mechanics/Physics,Grace/Optics/Physics,Linus//"; strings.Join(got, ",") != want {
+		t.Errorf("projected rows = %v, want %s", got, want)
+	}
+
+	// The query's own filter narrows the root nodes.
+	rows, err = typed.Project[enrollmentProjection](enrollments.Query(ctx).
+		Filter(`eq(student_name, $1)`, "Ada"))
+	if err != nil {
+		t.Fatalf("Project with Filter: %v", err)
+	}
+	if len(rows) != 1 || rows[0].ID != ada.UID || rows[0].Course != "Mechanics" {
+		t.Errorf("Ada's row = %+v, want her UID and Mechanics", rows)
+	}
+
+	// Untagged fields read the root predicate of their json name.
+	type studentOnly struct {
+		Name string `json:"student_name"`
+	}
+	names, err := typed.Project[studentOnly](enrollments.Query(ctx).OrderAsc("student_name"))
+	if err != nil {
+		t.Fatalf("Project untagged: %v", err)
+	}
+	if len(names) != 3 || names[0].Name != "Ada" || names[2].Name != "Linus" {
+		t.Errorf("student names = %+v, want Ada, Grace, Linus", names)
+	}
+}
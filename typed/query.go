@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"iter"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -50,10 +51,11 @@ const (
 // variable and branch it into independent queries: every branch shares — and
 // keeps mutating — the same underlying query.
 //
-// Repeated builder calls do not all behave the same way. Limit, Offset, After,
-// Cascade, Name, RootFunc, and Vars overwrite: the last call wins. Filter,
-// OrderAsc, OrderDesc, and WhereEdge accumulate: each call adds to the query.
-// Accumulated Filter fragments AND together (see CombinedFilter, OrGroup).
+// Repeated builder calls do not all behave the same way. Limit, Last, Offset, After,
+// Cascade, Name, RootFunc, Vars, Select, Expand, and MaxResults overwrite: the last
+// call wins. Filter, OrderAsc, OrderDesc, and WhereEdge accumulate: each call
+// adds to the query. Accumulated Filter fragments AND together (see
+// CombinedFilter, OrGroup).
 //
 // Limit and Offset additionally record the bounds that IterNodes pages
 // within — a Limit caps the rows it streams, an Offset is its start.
@@ -61,16 +63,55 @@ type Query[T any] struct {
 	q       *dg.Query
 	conn    modusgraph.Client // runs the WhereEdge pre-pass; set by Client.Query
 	ctx     context.Context   // carried for the WhereEdge pre-pass query
+	qctx    *queryContext     // ctx as dgman holds it; see NormalizeLimit
 	limit   int               // caller-set row cap; 0 = unbounded
+	last    bool              // limit counts from the end of the results (Last)
+	orders  []orderClause     // accumulated sort keys, in call order
+	ordered bool              // orders have been handed to q (pushOrder)
 	offset  int               // caller-set starting offset; 0 = none
 	edges   []edgeFilter      // accumulated WhereEdge constraints; empty = none
+	with    []VarBlock        // var blocks prepended to the request (With); empty = none
 	filters []filterFrag      // accumulated @filter fragments, ANDed; empty = none
 
+	// maxResults, maxBytes, and requireFilter guard against unbounded scans;
+	// see MaxResults, MaxBytes, and RequireFilter.
+	maxResults    int
+	maxBytes      int
+	requireFilter bool
+
+	// edgePages, lets, computed, omitted, langs, and reverses hold the
+	// selection shaping set through Edge, Let, Compute, IncludeIf,
+	// LangFallback, and Reverse. When any is set the selection is rendered
+	// explicitly from T's fields (see selection).
+	edgePages []*edgePage
+	lets      []valueVar
+	computed  []computedField
+	omitted   []string // predicates IncludeIf left out
+	langs     []string // language preference for lang fields (LangFallback)
+	reverses  []*reverseEdge
+
+	// selectBody and selectParams hold a caller-supplied selection (Select);
+	// normalize adds @normalize to the block (Normalize), recurse holds
+	// the rendered @recurse directive, or "" if none (Recurse),
+	// recurseFilters prune its traversal (RecurseFilter), and expandTypes
+	// holds the types whose predicates are selected (Expand).
+	selectBody     string
+	selectParams   []any
+	normalize      bool
+	recurse        string
+	recurseFilters []filterFrag
+	expandTypes    []string
+
+	// useNumber decodes numeric facets as json.Number (UseNumber).
+	useNumber bool
+
 	// customRootExpr is the caller's root narrowing (set by UID or RootFunc), or
 	// "" if none. The WhereEdge var block roots at it, so the matched UIDs are the
 	// intersection of the caller's root and the edge constraints rather than
 	// overwriting the caller's root (see edgeVarBlock).
 	customRootExpr string
+	typeRoot       bool // customRootExpr was set by OfType or Untyped and narrows by type or predicate only
+	untyped        bool // the query reads nodes without a dgraph.type (Untyped)
 
 	// varsFuncDef and varsMap hold GraphQL named variables set via Vars. The
 	// WhereEdge path renders its own multi-block request, so runEdge forwards
@@ -88,6 +129,12 @@ type edgeFilter struct {
 	params    []any
 }
 
+// orderClause is one accumulated OrderAsc or OrderDesc sort key.
+type orderClause struct {
+	clause string
+	desc   bool
+}
+
 // filterFrag is one accumulated @filter fragment. Fragments join with AND.
 type filterFrag struct {
 	expr   string
@@ -184,24 +231,87 @@ func (qb *Query[T]) OrGroup(subs ...*Query[T]) *Query[T] {
 
 // OrderAsc orders results ascending by clause.
 func (qb *Query[T]) OrderAsc(clause string) *Query[T] {
-	qb.q.OrderAsc(clause)
-	return qb
+	return qb.addOrder(orderClause{clause: clause})
 }
 
 // OrderDesc orders results descending by clause.
 func (qb *Query[T]) OrderDesc(clause string) *Query[T] {
-	qb.q.OrderDesc(clause)
+	return qb.addOrder(orderClause{clause: clause, desc: true})
+}
+
+// addOrder accumulates a sort key. Keys reach dgman only when the query is
+// rendered (see pushOrder), because a later Last may need them reversed; a
+// key added after that is pushed straight away.
+func (qb *Query[T]) addOrder(o orderClause) *Query[T] {
+	qb.orders = append(qb.orders, o)
+	if qb.ordered {
+		qb.pushOrderClause(o)
+	}
 	return qb
 }
 
+// pushOrder hands the accumulated sort keys and any Last bound to dgman,
+// once, before the query renders. dgraph rejects a negative first on a sorted
+// query, so an ordered Last query is pushed with every key reversed and a
+// plain first: n, and its rows are reversed back as they are decoded (see
+// flipped).
+func (qb *Query[T]) pushOrder() {
+	if qb.q == nil || qb.ordered {
+		return
+	}
+	qb.ordered = true
+	for _, o := range qb.orders {
+		qb.pushOrderClause(o)
+	}
+	if qb.last {
+		qb.q.First(qb.firstFor(qb.limit))
+	}
+}
+
+// firstFor returns the dgman first bound that reads n rows: -n for an
+// unordered Last query, which counts from the end, and n otherwise.
+func (qb *Query[T]) firstFor(n int) int {
+	if qb.last && !qb.flipped() {
+		return -n
+	}
+	return n
+}
+
+func (qb *Query[T]) pushOrderClause(o orderClause) {
+	if o.desc != qb.flipped() {
+		qb.q.OrderDesc(o.clause)
+	} else {
+		qb.q.OrderAsc(o.clause)
+	}
+}
+
+// flipped reports whether the query runs in reverse of its declared order:
+// an ordered Last query, whose rows must be reversed once read.
+func (qb *Query[T]) flipped() bool {
+	return qb.last && len(qb.orders) > 0
+}
+
 // Limit caps the number of results. dgman names this First; it is renamed
 // here so it does not collide with the First terminal.
 func (qb *Query[T]) Limit(n int) *Query[T] {
 	qb.limit = n
+	qb.last = false
 	qb.q.First(n)
 	return qb
 }
 
+// Last caps the results to the last n in the query's order. The rows still
+// come back in that order, so OrderAsc("created").Last(3) returns the three
+// most recent, oldest first. Unordered, it renders as dgraph's first: -n;
+// ordered, dgraph does not support that, so the query runs with its order
+// reversed and first: n, and the rows are reversed back. Last and Limit
+// overwrite each other.
+func (qb *Query[T]) Last(n int) *Query[T] {
+	qb.limit = n
+	qb.last = true
+	return qb
+}
+
 // Offset skips the first n results.
 func (qb *Query[T]) Offset(n int) *Query[T] {
 	qb.offset = n
@@ -226,10 +336,25 @@ func (qb *Query[T]) Cascade(predicates ...string) *Query[T] {
 // eq(name, "Alice") or has(email). Repeated calls overwrite.
 func (qb *Query[T]) RootFunc(rootFunc string) *Query[T] {
 	qb.customRootExpr = rootFunc
+	qb.typeRoot = false
 	qb.q.RootFunc(rootFunc)
 	return qb
 }
 
+// OfType roots the query at type(typeName), the nodes of the named dgraph
+// type, instead of the type dgman derives from T. Filter then post-filters
+// within that type, and ordering and pagination apply as usual, so
+// OfType("Document").Filter("similar_to(embedding, 5, $1)", vec) replaces a
+// hand-written type(Document) @filter(...) root. It is useful when T is a
+// projection, such as a Select or Normalize row, whose name is not the type
+// being read. Like RootFunc it overwrites any earlier root, but it does not
+// satisfy RequireFilter, since every node of the type still matches.
+func (qb *Query[T]) OfType(typeName string) *Query[T] {
+	qb.RootFunc("type(" + typeName + ")")
+	qb.typeRoot = true
+	return qb
+}
+
 // Name sets the query block name. It defaults to "data"; dgman uses the name
 // to both generate and decode the query, so a renamed block still decodes
 // into []T. Repeated calls overwrite.
@@ -270,6 +395,41 @@ func (qb *Query[T]) WhereEdge(predicate, filter string, params ...any) *Query[T]
 	return qb
 }
 
+// WhereReverseEdge is WhereEdge over the managed reverse of predicate: it
+// constrains results to records that at least one node points at through a
+// `predicate` edge satisfying filter. predicate must carry dgraph's @reverse
+// index, and may be given with or without its leading "~":
+//
+//	depts.Query(ctx).WhereReverseEdge("in_department", `eq(course_name, $1)`, "CS101")
+//
+// finds the departments holding a course named CS101. It accumulates and ANDs
+// with WhereEdge constraints.
+func (qb *Query[T]) WhereReverseEdge(predicate, filter string, params ...any) *Query[T] {
+	if !strings.HasPrefix(predicate, "~") {
+		predicate = "~" + predicate
+	}
+	return qb.WhereEdge(predicate, filter, params...)
+}
+
+// WhereCount adds an @filter(op(count(predicate), n)) clause, keeping only the
+// records whose number of predicate edges compares to n under op, one of "eq",
+// "lt", "le", "gt", or "ge". predicate may be a managed reverse edge given with
+// its leading "~", and must carry dgraph's @count index:
+//
+//	depts.Query(ctx).WhereCount("~in_department", "gt", 3)
+//
+// finds the departments holding more than three courses. It accumulates and
+// ANDs with other filters like Filter. An unknown op panics.
+func (qb *Query[T]) WhereCount(predicate, op string, n int) *Query[T] {
+	switch op {
+	case "eq", "lt", "le", "gt", "ge":
+	default:
+		panic(fmt.Sprintf("typed: WhereCount comparator %q is not one of eq, lt, le, gt, ge", op))
+	}
+	qb.addFilter(fmt.Sprintf("%s(count(%s), %d)", op, predicate, n), nil)
+	return qb
+}
+
 // WhereAnyOfText adds an @filter(anyoftext(predicate, $1)) clause. It
 // accumulates and ANDs with other filters like Filter.
 func (qb *Query[T]) WhereAnyOfText(predicate, term string) *Query[T] {
@@ -289,6 +449,7 @@ func (qb *Query[T]) WhereAllOfText(predicate, term string) *Query[T] {
 // cannot do, so As transitions out of the typed query: it returns a *RawQuery,
 // which exposes no node terminal.
 func (qb *Query[T]) As(varName string) *RawQuery {
+	qb.pushOrder()
 	qb.q.As(varName)
 	return &RawQuery{q: qb.q}
 }
@@ -297,6 +458,7 @@ func (qb *Query[T]) As(varName string) *RawQuery {
 // variables and returns no data of its own, so Var transitions out of the
 // typed query: it returns a *RawQuery, which exposes no node terminal.
 func (qb *Query[T]) Var() *RawQuery {
+	qb.pushOrder()
 	qb.q.Var()
 	return &RawQuery{q: qb.q}
 }
@@ -305,6 +467,7 @@ func (qb *Query[T]) Var() *RawQuery {
 // aggregation groups rather than a slice of T, so GroupBy transitions out of
 // the typed query: it returns a *RawQuery, which exposes no node terminal.
 func (qb *Query[T]) GroupBy(predicate string) *RawQuery {
+	qb.pushOrder()
 	qb.q.GroupBy(predicate)
 	return &RawQuery{q: qb.q}
 }
@@ -314,13 +477,17 @@ func (qb *Query[T]) Nodes() (out []T, err error) {
 	if qb.q == nil {
 		return nil, ErrDetachedQuery
 	}
+	qb.pushOrder()
 	_, span := currentTracer().StartSpan(qb.ctx, "query", entityName[T]())
 	defer func() { span.End(err) }()
-	if len(qb.edges) > 0 {
+	if err = qb.guard(); err != nil {
+		return nil, err
+	}
+	if qb.multiBlock() {
 		out, _, err = qb.runEdge(false)
 		return out, err
 	}
-	if err = qb.q.Nodes(&out); err != nil {
+	if err = qb.decode(&out, func(dst any) error { return qb.q.Nodes(dst) }); err != nil {
 		return nil, err
 	}
 	return out, nil
@@ -332,14 +499,23 @@ func (qb *Query[T]) First() (rec *T, err error) {
 	if qb.q == nil {
 		return nil, ErrDetachedQuery
 	}
+	qb.pushOrder()
 	_, span := currentTracer().StartSpan(qb.ctx, "query", entityName[T]())
 	defer func() { span.End(err) }()
+	if err = qb.guard(); err != nil {
+		return nil, err
+	}
+	// After Last, the first record is the first of the last n.
+	first := 1
+	if qb.last {
+		first = qb.firstFor(qb.limit)
+	}
 	var out []T
-	if len(qb.edges) > 0 {
-		qb.q.First(1)
+	if qb.multiBlock() {
+		qb.q.First(first)
 		out, _, err = qb.runEdge(false)
 	} else {
-		err = qb.q.First(1).Nodes(&out)
+		err = qb.decode(&out, func(dst any) error { return qb.q.First(first).Nodes(dst) })
 	}
 	if err != nil {
 		return nil, err
@@ -356,13 +532,15 @@ func (qb *Query[T]) First() (rec *T, err error) {
 // IterNodes is a terminal operation: it drives Offset/Limit internally as it
 // pages and leaves the builder spent — do not call another terminal on the
 // same Query afterward. A Limit set on the query caps the total number of
-// rows streamed; an Offset is the starting point.
+// rows streamed; an Offset is the starting point. MaxResults caps it the
+// same way. The rows of a Last query are read in a single page, since dgraph
+// cannot page back from the end.
 //
-// With no WhereEdge constraints, every page executes against one read-only
-// transaction, so the iteration reads a single consistent snapshot: a
-// concurrent writer cannot make it skip or repeat rows. With WhereEdge
-// constraints, each page is its own request that re-resolves the server-side
-// match var — keeping memory bounded, at the cost of reading each page from a
+// With no WhereEdge constraints or With blocks, every page executes against
+// one read-only transaction, so the iteration reads a single consistent
+// snapshot: a concurrent writer cannot make it skip or repeat rows. With
+// either, each page is its own request that re-resolves the server-side
+// variables — keeping memory bounded, at the cost of reading each page from a
 // fresh snapshot. On error it yields a final (nil, err) and stops.
 func (qb *Query[T]) IterNodes() iter.Seq2[*T, error] {
 	return func(yield func(*T, error) bool) {
@@ -370,24 +548,33 @@ func (qb *Query[T]) IterNodes() iter.Seq2[*T, error] {
 			yield(nil, ErrDetachedQuery)
 			return
 		}
+		qb.pushOrder()
 		_, span := currentTracer().StartSpan(qb.ctx, "query", entityName[T]())
 		var ferr error
 		defer func() { span.End(ferr) }()
+		if ferr = qb.guard(); ferr != nil {
+			yield(nil, ferr)
+			return
+		}
 		remaining := qb.limit // 0 = unbounded
+		if qb.maxResults > 0 && (remaining == 0 || remaining > qb.maxResults) {
+			remaining = qb.maxResults
+		}
 		for off := qb.offset; ; off += defaultPageSize {
 			size := defaultPageSize
-			if remaining > 0 && remaining < size {
+			if remaining > 0 && (remaining < size || qb.last) {
 				size = remaining // shrink the last page so it can't overshoot the cap
 			}
+			first := qb.firstFor(size)
 			var page []T
 			var err error
-			if len(qb.edges) > 0 {
+			if qb.multiBlock() {
 				// Each page re-resolves the WhereEdge var server-side, so no page
 				// materializes the full matched-UID set.
-				qb.q.Offset(off).First(size)
+				qb.q.Offset(off).First(first)
 				page, _, err = qb.runEdge(false)
 			} else {
-				err = qb.q.Offset(off).First(size).Nodes(&page)
+				err = qb.decode(&page, func(dst any) error { return qb.q.Offset(off).First(first).Nodes(dst) })
 			}
 			if err != nil {
 				ferr = err
@@ -399,6 +586,9 @@ func (qb *Query[T]) IterNodes() iter.Seq2[*T, error] {
 					return // consumer broke out
 				}
 			}
+			if qb.last {
+				return // a Last query is a single page
+			}
 			if remaining > 0 {
 				if remaining -= len(page); remaining <= 0 {
 					return // hit the caller's Limit
@@ -415,20 +605,27 @@ func (qb *Query[T]) IterNodes() iter.Seq2[*T, error] {
 // (for example the raw-selection Query method). Raw does not carry WhereEdge
 // constraints — those are resolved only when a terminal runs.
 func (qb *Query[T]) Raw() *dg.Query {
+	qb.pushOrder()
 	return qb.q
 }
 
 // UID roots the query at a specific node UID. Results still decode into []T.
 func (qb *Query[T]) UID(uid string) *Query[T] {
 	qb.customRootExpr = "uid(" + uid + ")"
+	qb.typeRoot = false
 	qb.q.UID(uid)
 	return qb
 }
 
 // All sets the edge-traversal depth for this query, overriding the client's
 // default maxEdgeTraversal. Use a small depth to stay under Dgraph's 4MB gRPC
-// limit on highly-connected entities.
+// limit on highly-connected entities. All restores the expanded selection, so
+// it discards any shaping set through Edge, Let, Compute, IncludeIf, Select,
+// Normalize, Recurse, RecurseFilter, Expand, LangFallback, or Reverse.
 func (qb *Query[T]) All(depth int) *Query[T] {
+	qb.edgePages, qb.lets, qb.computed, qb.omitted, qb.reverses = nil, nil, nil, nil, nil
+	qb.selectBody, qb.selectParams, qb.normalize, qb.recurse = "", nil, false, ""
+	qb.expandTypes, qb.langs, qb.recurseFilters = nil, nil, nil
 	qb.q.All(depth)
 	return qb
 }
@@ -440,12 +637,19 @@ func (qb *Query[T]) NodesAndCount() (out []T, count int, err error) {
 	if qb.q == nil {
 		return nil, 0, ErrDetachedQuery
 	}
+	qb.pushOrder()
 	_, span := currentTracer().StartSpan(qb.ctx, "query", entityName[T]())
 	defer func() { span.End(err) }()
-	if len(qb.edges) > 0 {
+	if err = qb.guard(); err != nil {
+		return nil, 0, err
+	}
+	if qb.multiBlock() {
 		return qb.runEdge(true)
 	}
-	count, err = qb.q.NodesAndCount(&out)
+	err = qb.decode(&out, func(dst any) (err error) {
+		count, err = qb.q.NodesAndCount(dst)
+		return err
+	})
 	if err != nil {
 		return nil, 0, err
 	}
@@ -453,8 +657,10 @@ func (qb *Query[T]) NodesAndCount() (out []T, count int, err error) {
 }
 
 // String renders the generated DQL without executing it. WhereEdge constraints
-// are not reflected — they are resolved only when a terminal runs.
+// and With blocks are not reflected — they are added only when a terminal
+// runs.
 func (qb *Query[T]) String() string {
+	qb.pushOrder()
 	return qb.q.String()
 }
 
@@ -473,12 +679,19 @@ func (qb *Query[T]) FormatBlock(name string) (string, error) {
 	if len(qb.edges) != 0 {
 		return "", fmt.Errorf("typed: FormatBlock cannot render a Query carrying WhereEdge constraints")
 	}
+	if len(qb.with) != 0 {
+		return "", fmt.Errorf("typed: FormatBlock cannot render a Query carrying With var blocks")
+	}
+	qb.pushOrder()
 	qb.q.Name(name)
 	wrapped := dg.NewQueryBlock(qb.q).String()
 	// QueryBlock.String() wraps the block in "{\n ... }" — strip the wrapper so
 	// the caller can compose blocks inside their own braces.
 	inner := strings.TrimPrefix(wrapped, "{\n")
 	inner = strings.TrimSuffix(inner, "}")
+	if qb.untyped {
+		inner = dropTypeGuard(inner)
+	}
 	return inner, nil
 }
 
@@ -524,6 +737,10 @@ func (r *RawQuery) GroupBy(predicate string) *RawQuery {
 // are never materialized into the client or inlined into a uid(...) literal — so
 // memory and DQL size stay bounded regardless of how many roots match.
 //
+// Any With blocks are prepended to the request, which is how a query carrying
+// them but no WhereEdge constraint runs too: its var block then binds every
+// root.
+//
 // runEdge is idempotent in qb: edgeBlocks pushes the data-block filter
 // last-write-wins onto qb.q and never mutates the accumulated filters, so
 // IterNodes can call runEdge once per page (each page re-resolves the var
@@ -536,22 +753,40 @@ func (qb *Query[T]) runEdge(withCount bool) (rows []T, count int, err error) {
 	if qb.varsMap != nil {
 		block.Vars(qb.varsFuncDef, qb.varsMap)
 	}
-	raw, err := qb.conn.QueryRaw(qb.ctx, block.String(), qb.varsMap)
+	request := qb.withBlocks(block.String())
+	if qb.untyped {
+		request = dropTypeGuard(request)
+	}
+	raw, err := qb.conn.QueryRaw(qb.ctx, request, qb.varsMap)
 	if err != nil {
 		return nil, 0, fmt.Errorf("typed: WhereEdge query: %w", err)
 	}
+	if err := qb.checkSize(len(raw)); err != nil {
+		return nil, 0, err
+	}
 	var perBlock map[string]json.RawMessage
 	if err := json.Unmarshal(raw, &perBlock); err != nil {
 		return nil, 0, fmt.Errorf("typed: decoding WhereEdge response: %w", err)
 	}
 	if body, ok := perBlock[edgeDataBlock]; ok {
+		if err := qb.collectReverses(body); err != nil {
+			return nil, 0, err
+		}
 		remapped, rerr := remapPredicateKeys(body, reflect.TypeFor[T]())
 		if rerr != nil {
 			return nil, 0, fmt.Errorf("typed: remapping WhereEdge rows: %w", rerr)
 		}
-		if err := json.Unmarshal(remapped, &rows); err != nil {
+		if qb.wantsFacets() {
+			err = decodeWithFacets(remapped, &rows, qb.useNumber)
+		} else {
+			err = json.Unmarshal(remapped, &rows)
+		}
+		if err != nil {
 			return nil, 0, fmt.Errorf("typed: decoding WhereEdge rows: %w", err)
 		}
+		if qb.flipped() {
+			slices.Reverse(rows)
+		}
 	}
 	if withCount {
 		count, err = decodeCount(perBlock[edgeCountBlock])
@@ -8,6 +8,7 @@ package typed_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"testing"
 
@@ -108,6 +109,74 @@ func TestQuery_LimitCapsResults(t *testing.T) {
 	}
 }
 
+func TestQuery_LastTakesTheEndOfTheOrder(t *testing.T) {
+	ctx := context.Background()
+	c := typed.NewClient[widget](newConn(t))
+	for _, q := range []int{40, 10, 60, 50, 20, 30} {
+		if err := c.Add(ctx, &widget{Name: "w", Qty: q}); err != nil {
+			t.Fatalf("Add %d: %v", q, err)
+		}
+	}
+
+	// Unordered, Last counts from the end with a negative first.
+	unordered := c.Query(ctx).Last(3)
+	if s := unordered.String(); !strings.Contains(s, "first: -3") {
+		t.Fatalf("Last(3) did not render first: -3; got:\n%s", s)
+	}
+	got, err := unordered.Nodes()
+	if err != nil {
+		t.Fatalf("unordered Nodes: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("unordered Last(3) returned %d records, want 3", len(got))
+	}
+
+	// Ordered, dgraph rejects a negative first: the order runs reversed.
+	q := c.Query(ctx).OrderAsc("qty").Last(3)
+	if s := q.String(); !strings.Contains(s, "first: 3, orderdesc: qty") {
+		t.Fatalf("OrderAsc(qty).Last(3) did not render a reversed order; got:\n%s", s)
+	}
+	got, err = q.Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	var qtys []int
+	for _, w := range got {
+		qtys = append(qtys, w.Qty)
+	}
+	if want := []int{40, 50, 60}; !slices.Equal(qtys, want) {
+		t.Fatalf("OrderAsc(qty).Last(3) = %v, want %v", qtys, want)
+	}
+
+	first, err := c.Query(ctx).OrderAsc("qty").Last(2).First()
+	if err != nil {
+		t.Fatalf("First: %v", err)
+	}
+	if first == nil || first.Qty != 50 {
+		t.Fatalf("Last(2).First() = %+v, want Qty 50", first)
+	}
+
+	qtys = qtys[:0]
+	for w, err := range c.Query(ctx).OrderAsc("qty").Last(4).IterNodes() {
+		if err != nil {
+			t.Fatalf("IterNodes: %v", err)
+		}
+		qtys = append(qtys, w.Qty)
+	}
+	if want := []int{30, 40, 50, 60}; !slices.Equal(qtys, want) {
+		t.Fatalf("Last(4).IterNodes() = %v, want %v", qtys, want)
+	}
+
+	// Limit after Last counts from the start again.
+	got, err = c.Query(ctx).OrderAsc("qty").Last(3).Limit(1).Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	if len(got) != 1 || got[0].Qty != 10 {
+		t.Fatalf("Last(3).Limit(1) = %+v, want Qty 10", got)
+	}
+}
+
 func TestQuery_FirstReturnsAMatch(t *testing.T) {
 	ctx := context.Background()
 	c := typed.NewClient[widget](newConn(t))
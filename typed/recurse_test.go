@@ -0,0 +1,183 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+// foafPerson is a node in a friend-of-a-friend chain, traversed by @recurse.
+type foafPerson struct {
+	UID     string        `json:"uid,omitempty"`
+	DType   []string      `json:"dgraph.type,omitempty"`
+	Name    string        `json:"foaf_name,omitempty" dgraph:"index=exact"`
+	Friends []*foafPerson `json:"foaf_friends,omitempty"`
+}
+
+// seedFoafChain links Alice -> Bob -> Carol -> Dave over foaf_friends.
+func seedFoafChain(t *testing.T, c *typed.Client[foafPerson]) {
+	t.Helper()
+	ctx := context.Background()
+	var next *foafPerson
+	for _, name := range []string{"Dave", "Carol", "Bob", "Alice"} {
+		p := &foafPerson{Name: name}
+		if next != nil {
+			p.Friends = []*foafPerson{{UID: next.UID}}
+		}
+		if err := c.Add(ctx, p); err != nil {
+			t.Fatalf("Add %s: %v", name, err)
+		}
+		next = p
+	}
+}
+
+// chainNames walks the first friend of each level from p.
+func chainNames(p *foafPerson) []string {
+	var names []string
+	for ; p != nil; p = firstFriend(p) {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func firstFriend(p *foafPerson) *foafPerson {
+	if len(p.Friends) == 0 {
+		return nil
+	}
+	return p.Friends[0]
+}
+
+func TestQuery_RecurseFollowsWholeChain(t *testing.T) {
+	ctx := context.Background()
+	c := typed.NewClient[foafPerson](newConn(t))
+	seedFoafChain(t, c)
+
+	alice, err := c.Query(ctx).
+		RootFunc(`eq(foaf_name, "Alice")`).
+		Recurse(0, false).
+		First()
+	if err != nil {
+		t.Fatalf("First: %v", err)
+	}
+	if alice == nil {
+		t.Fatal("Alice not found")
+	}
+	if got, want := strings.Join(chainNames(alice), ","), "Alice,Bob,Carol,Dave"; got != want {
+		t.Errorf("recursed chain = %s, want %s", got, want)
+	}
+
+	alice, err = c.Query(ctx).
+		RootFunc(`eq(foaf_name, "Alice")`).
+		Recurse(2, false).
+		First()
+	if err != nil {
+		t.Fatalf("First with depth: %v", err)
+	}
+	if got, want := strings.Join(chainNames(alice), ","), "Alice,Bob"; got != want {
+		t.Errorf("depth-limited chain = %s, want %s", got, want)
+	}
+}
+
+func TestQuery_RecurseRendersDirective(t *testing.T) {
+	q := typed.NewClient[foafPerson](newConn(t)).Query(context.Background()).
+		Recurse(3, true)
+	dql := q.String()
+	if !strings.Contains(dql, "@recurse(depth: 3, loop: true) {") {
+		t.Errorf("@recurse missing or misplaced:\n%s", dql)
+	}
+	if strings.Contains(dql, "expand(_all_)") {
+		t.Errorf("@recurse selection should list edges flat, got:\n%s", dql)
+	}
+	if dql := q.All(0).String(); strings.Contains(dql, "@recurse") {
+		t.Errorf("All should discard Recurse, got:\n%s", dql)
+	}
+}
+
+// orgUnit is a department in an org chart, some of them no longer active.
+type orgUnit struct {
+	UID      string     `json:"uid,omitempty"`
+	DType    []string   `json:"dgraph.type,omitempty"`
+	Name     string     `json:"org_name,omitempty" dgraph:"index=exact"`
+	Active   bool       `json:"org_active,omitempty" dgraph:"index=bool"`
+	Children []*orgUnit `json:"org_children,omitempty"`
+}
+
+// unitNames lists the names of u and everything below it, depth first.
+func unitNames(u *orgUnit) []string {
+	names := []string{u.Name}
+	for _, c := range u.Children {
+		names = append(names, unitNames(c)...)
+	}
+	return names
+}
+
+func TestQuery_RecurseFilterPrunesBranches(t *testing.T) {
+	ctx := context.Background()
+	c := typed.NewClient[orgUnit](newConn(t))
+
+	// HQ
+	// ├── Eng
+	// │   ├── Platform
+	// │   └── Legacy (inactive)
+	// │       └── Archive
+	// ├── Sales (inactive)
+	// │   └── EMEA
+	// └── Legal
+	hq := &orgUnit{Name: "HQ", Active: true, Children: []*orgUnit{
+		{Name: "Eng", Active: true, Children: []*orgUnit{
+			{Name: "Platform", Active: true},
+			{Name: "Legacy", Children: []*orgUnit{{Name: "Archive", Active: true}}},
+		}},
+		{Name: "Sales", Children: []*orgUnit{{Name: "EMEA", Active: true}}},
+		{Name: "Legal", Active: true},
+	}}
+	if err := c.Add(ctx, hq); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	all, err := c.Query(ctx).UID(hq.UID).Recurse(0, false).First()
+	if err != nil || all == nil {
+		t.Fatalf("First: %v, %v", all, err)
+	}
+	if got := len(unitNames(all)); got != 8 {
+		t.Fatalf("unfiltered traversal read %d units, want 8: %v", got, unitNames(all))
+	}
+
+	q := c.Query(ctx).UID(hq.UID).Recurse(0, false).RecurseFilter(`eq(org_active, $1)`, true)
+	active, err := q.First()
+	if err != nil || active == nil {
+		t.Fatalf("First with RecurseFilter: %v, %v", active, err)
+	}
+	got := unitNames(active)
+	slices.Sort(got)
+	if want := "Eng,HQ,Legal,Platform"; strings.Join(got, ",") != want {
+		t.Errorf("active traversal = %v, want %s; Archive and EMEA sit below inactive units", got, want)
+	}
+
+	// Filters AND together and bind their own params.
+	q.RecurseFilter(`NOT eq(org_name, $1)`, "Legal")
+	if dql := q.String(); !strings.Contains(dql, `org_children @filter((eq(org_active, true)) AND (NOT eq(org_name, "Legal")))`) {
+		t.Errorf("RecurseFilter should filter the edge, got:\n%s", dql)
+	}
+	active, err = q.First()
+	if err != nil || active == nil {
+		t.Fatalf("First with two RecurseFilters: %v, %v", active, err)
+	}
+	got = unitNames(active)
+	slices.Sort(got)
+	if want := "Eng,HQ,Platform"; strings.Join(got, ",") != want {
+		t.Errorf("active traversal without Legal = %v, want %s", got, want)
+	}
+
+	if dql := q.All(0).String(); strings.Contains(dql, "@filter((eq(org_active") {
+		t.Errorf("All should discard RecurseFilter, got:\n%s", dql)
+	}
+}
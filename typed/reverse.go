@@ -0,0 +1,133 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// reverseEdge is a reverse edge selected with Reverse and the slice its
+// targets decode into.
+type reverseEdge struct {
+	predicate string        // "~" and the forward edge
+	into      reflect.Value // the caller's slice
+	seen      map[string]bool
+}
+
+// Reverse selects the reverse of forwardEdge, "~" + forwardEdge, on every
+// node of the query, and appends the nodes it reaches to into, a pointer to a
+// slice of structs, when a terminal runs. It serves query shapes T does not
+// declare a ~forwardEdge field for: the students taking a set of classes,
+// say, where only the student type declares takes_class:
+//
+//	var students []student
+//	_, err := classes.Query(ctx).
+//		Filter(`eq(class_name, "Physics")`).
+//		Reverse("takes_class", &students).
+//		Nodes()
+//
+// forwardEdge must be declared with @reverse (dgraph:"reverse" on the forward
+// field). A node reached from several nodes of the query is appended once,
+// so into holds the union of their reverse edges; query one node, as with
+// UID, to read a single node's. Edge("~"+forwardEdge) filters, orders, and
+// paginates the edge as for any other. Each target reads its own predicates,
+// one level deep.
+//
+// Like Edge, Reverse switches the query to an explicit selection of T's
+// fields; Select, Expand, and Recurse replace that selection, leaving into
+// empty, and a later All discards it. It panics when into is not a pointer to
+// a slice of structs, or pointers to them.
+func (qb *Query[T]) Reverse(forwardEdge string, into any) *Query[T] {
+	v := reflect.ValueOf(into)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Slice ||
+		getElemType(v.Elem().Type()).Kind() != reflect.Struct {
+		panic(fmt.Sprintf("typed: Reverse needs a pointer to a slice of structs, not %T", into))
+	}
+	qb.reverses = append(qb.reverses, &reverseEdge{
+		predicate: "~" + strings.TrimPrefix(forwardEdge, "~"),
+		into:      v.Elem(),
+		seen:      make(map[string]bool),
+	})
+	qb.pushSelection()
+	return qb
+}
+
+// collectReverses appends the targets of each reverse edge selected with
+// Reverse to its slice, from raw, the JSON array of a query's rows.
+func (qb *Query[T]) collectReverses(raw json.RawMessage) error {
+	if len(qb.reverses) == 0 || len(raw) == 0 {
+		return nil
+	}
+	var rows []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &rows); err != nil {
+		return fmt.Errorf("typed: decoding reverse edges: %w", err)
+	}
+	for _, r := range qb.reverses {
+		elem := r.into.Type().Elem()
+		for _, row := range rows {
+			targets, ok := row[r.predicate]
+			if !ok {
+				continue
+			}
+			var items []json.RawMessage
+			if err := json.Unmarshal(targets, &items); err != nil {
+				return fmt.Errorf("typed: decoding %s: %w", r.predicate, err)
+			}
+			for _, item := range items {
+				var id struct {
+					UID string `json:"uid"`
+				}
+				_ = json.Unmarshal(item, &id)
+				if id.UID != "" && r.seen[id.UID] {
+					continue
+				}
+				r.seen[id.UID] = true
+				remapped, err := remapPredicateKeys(item, elem)
+				if err != nil {
+					return fmt.Errorf("typed: remapping %s: %w", r.predicate, err)
+				}
+				target := reflect.New(elem)
+				if err := json.Unmarshal(remapped, target.Interface()); err != nil {
+					return fmt.Errorf("typed: decoding %s: %w", r.predicate, err)
+				}
+				r.into.Set(reflect.Append(r.into, target.Elem()))
+			}
+		}
+	}
+	return nil
+}
+
+// reverseSelection renders the blocks of the reverse edges selected with
+// Reverse that T does not declare itself, with the params their edge filters
+// bind numbered after the first paramBase params of the selection.
+func (qb *Query[T]) reverseSelection(declared map[string]bool, paramBase int) (string, []any) {
+	var b strings.Builder
+	var params []any
+	for _, r := range qb.reverses {
+		if declared[r.predicate] {
+			continue
+		}
+		b.WriteString("\t")
+		b.WriteString(r.predicate)
+		for _, p := range qb.edgePages {
+			if p.predicate == r.predicate {
+				args, edgeParams := p.args(paramBase + len(params))
+				b.WriteString(args)
+				params = append(params, edgeParams...)
+				break
+			}
+		}
+		if qb.untyped {
+			b.WriteString(untypedEdgeSelection(r.into.Type()))
+			continue
+		}
+		b.WriteString(" {\n\t\tuid\n\t\tdgraph.type\n\t\texpand(_all_)\n\t}\n")
+	}
+	return b.String(), params
+}
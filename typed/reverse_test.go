@@ -0,0 +1,111 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+// pupil points at its classes over takes_class; classroom declares no field
+// for the reverse edge, so Query.Reverse reads it.
+type pupil struct {
+	UID     string       `json:"uid,omitempty"`
+	DType   []string     `json:"dgraph.type,omitempty"`
+	Name    string       `json:"pupil_name,omitempty" dgraph:"index=exact"`
+	Classes []*classroom `json:"takes_class,omitempty" dgraph:"reverse"`
+}
+
+type classroom struct {
+	UID   string   `json:"uid,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+	Name  string   `json:"class_name,omitempty" dgraph:"index=exact"`
+}
+
+func pupilNames(ps []pupil) []string {
+	var names []string
+	for _, p := range ps {
+		names = append(names, p.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestQuery_Reverse(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+	classes := typed.NewClient[classroom](conn)
+	physics, chemistry := &classroom{Name: "Physics"}, &classroom{Name: "Chemistry"}
+	for _, c := range []*classroom{physics, chemistry} {
+		if err := classes.Add(ctx, c); err != nil {
+			t.Fatalf("Add classroom: %v", err)
+		}
+	}
+	pupils := typed.NewClient[pupil](conn)
+	for _, p := range []*pupil{
+		{Name: "Ann", Classes: []*classroom{{UID: physics.UID}}},
+		{Name: "Ben", Classes: []*classroom{{UID: physics.UID}, {UID: chemistry.UID}}},
+		{Name: "Cy", Classes: []*classroom{{UID: chemistry.UID}}},
+	} {
+		if err := pupils.Add(ctx, p); err != nil {
+			t.Fatalf("Add pupil: %v", err)
+		}
+	}
+
+	var taking []pupil
+	q := classes.Query(ctx).Filter(`eq(class_name, "Physics")`).Reverse("takes_class", &taking)
+	if dql := q.String(); !strings.Contains(dql, "~takes_class {") {
+		t.Fatalf("Reverse should select ~takes_class; got:\n%s", dql)
+	}
+	got, err := q.Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "Physics" {
+		t.Fatalf("Nodes = %+v, want Physics", got)
+	}
+	if names := pupilNames(taking); !slices.Equal(names, []string{"Ann", "Ben"}) {
+		t.Fatalf("Physics pupils = %v, want [Ann Ben]", names)
+	}
+	if len(taking[0].Classes) != 0 || taking[0].UID == "" {
+		t.Fatalf("pupil decoded as %+v; want its UID and no edges", taking[0])
+	}
+
+	// Across several nodes, a pupil taking both classes is read once
+	var all []*pupil
+	if _, err := classes.Query(ctx).Reverse("takes_class", &all).Nodes(); err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("pupils of every class = %d, want 3", len(all))
+	}
+
+	// The edge can be shaped like any other
+	var bens []pupil
+	_, err = classes.Query(ctx).
+		Filter(`eq(class_name, "Chemistry")`).
+		Reverse("takes_class", &bens).
+		Edge("~takes_class").Filter(`eq(pupil_name, $1)`, "Ben").Done().
+		Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	if names := pupilNames(bens); !slices.Equal(names, []string{"Ben"}) {
+		t.Fatalf("filtered Chemistry pupils = %v, want [Ben]", names)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Reverse into a non-slice should panic")
+		}
+	}()
+	var one pupil
+	classes.Query(ctx).Reverse("takes_class", &one)
+}
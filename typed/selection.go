@@ -0,0 +1,390 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/matthewmcneely/modusgraph"
+)
+
+// Select replaces the query's selection set with body, a braced DQL
+// selection such as "{ uid name pets { name } }". params bind to $N
+// placeholders within body, exactly as Filter binds them. The result still
+// decodes into []T, so body should produce the shape T's json tags expect.
+// Select takes precedence over the selection Edge, Let, and Compute render; a
+// later All discards it. Repeated calls overwrite.
+func (qb *Query[T]) Select(body string, params ...any) *Query[T] {
+	qb.selectBody = body
+	qb.selectParams = params
+	qb.pushSelection()
+	return qb
+}
+
+// IncludeIf keeps the predicates of T named by fields in the selection when
+// include is true and leaves them out when it is false, so one query can
+// serve callers that may see different field sets:
+//
+//	q.IncludeIf(caller.IsAdmin(), "salary", "ssn")
+//
+// fields are predicate names as T's json tags (or predicate=) spell them. A
+// left-out field decodes as its zero value. IncludeIf accumulates, a later
+// call for the same field overriding an earlier one; like Edge, it switches
+// the query to an explicit selection of T's fields (see Edge) whichever way
+// include goes, so the result has the same shape for every caller. Select
+// and Expand take precedence over it, and a later All discards it.
+func (qb *Query[T]) IncludeIf(include bool, fields ...string) *Query[T] {
+	qb.omitted = slices.DeleteFunc(qb.omitted, func(pred string) bool {
+		return slices.Contains(fields, pred)
+	})
+	if !include {
+		qb.omitted = append(qb.omitted, fields...)
+	}
+	qb.pushSelection()
+	return qb
+}
+
+// LangFallback reads each of T's language-tagged fields (dgraph:"lang") in
+// the first of langs the node holds a value for, "." standing for any
+// language, untagged values included:
+//
+//	q.LangFallback("en", "de", ".")
+//
+// selects name@en:de:. into the name field, so a node with only a German name
+// returns that. A field with no value in any of langs decodes as its zero
+// value. Like IncludeIf, it switches the query to an explicit selection of
+// T's fields (see Edge); Select, Expand, and Recurse take precedence over it,
+// and a field bound by Let keeps its untagged value. A later All discards it.
+// Repeated calls overwrite.
+func (qb *Query[T]) LangFallback(langs ...string) *Query[T] {
+	qb.langs = langs
+	qb.pushSelection()
+	return qb
+}
+
+// Normalize adds dgraph's @normalize directive to the query block. A
+// normalized block returns only aliased predicates, and flattens the aliased
+// values found along nested edges into the parent row — one flat row per path
+// through the graph, which suits tabular output from a traversal:
+//
+//	q.Select(`{
+//		student: student_name
+//		in_course { course: course_name in_department { dept: dept_name } }
+//	}`).Normalize()
+//
+// T should be the flat row type keyed by those aliases. Normalize is meant to
+// be used with Select (or Compute aliases); unaliased fields of the default
+// selection are dropped by dgraph. A later All discards it.
+func (qb *Query[T]) Normalize() *Query[T] {
+	qb.normalize = true
+	qb.pushSelection()
+	return qb
+}
+
+// NormalizeLimit lets the query produce up to n nodes through @normalize in
+// place of the engine's configured limit (modusgraph.DefaultLimitNormalizeNode
+// unless the Config sets another), beyond which dgraph rejects the query. Use
+// it for the odd wide traversal rather than raising the limit for every query;
+// see modusgraph.ContextWithNormalizeLimit. It applies on embedded (file://)
+// databases only. Repeated calls overwrite.
+func (qb *Query[T]) NormalizeLimit(n int) *Query[T] {
+	if qb.qctx != nil {
+		qb.qctx.Context = modusgraph.ContextWithNormalizeLimit(qb.qctx.Context, n)
+	}
+	return qb
+}
+
+// queryContext is the context a Query hands dgman when it is built. dgman
+// keeps that context for the query's lifetime, so options that travel on the
+// context (NormalizeLimit) swap the wrapped Context instead.
+type queryContext struct {
+	context.Context
+}
+
+// Recurse adds dgraph's @recurse directive to the query block, following
+// T's edges from each root node to whatever depth the graph reaches rather
+// than to the fixed depth of the expanded selection:
+//
+//	q.RootFunc(`eq(name, "Alice")`).Recurse(0, false)
+//
+// depth caps the number of levels returned, counting the root; 0 leaves it
+// unbounded. loop lets the traversal revisit nodes it has already reached,
+// and dgraph then requires a depth. Inside @recurse every predicate applies
+// at every level, so the selection lists T's scalar predicates and its edges
+// flat rather than as nested blocks; Select supplies a different one. A
+// later All discards it. Repeated calls overwrite.
+func (qb *Query[T]) Recurse(depth int, loop bool) *Query[T] {
+	var args []string
+	if depth > 0 {
+		args = append(args, "depth: "+strconv.Itoa(depth))
+	}
+	if loop {
+		args = append(args, "loop: true")
+	}
+	qb.recurse = "@recurse"
+	if len(args) > 0 {
+		qb.recurse += "(" + strings.Join(args, ", ") + ")"
+	}
+	qb.pushSelection()
+	return qb
+}
+
+// RecurseFilter prunes a Recurse traversal at the nodes that do not satisfy
+// the dgraph filter expression, whose $1, $2, ... placeholders bind to params
+// as in Filter. A @filter on the block itself applies to the root nodes only,
+// so the expression goes on every edge of the recursed selection instead, and
+// the traversal neither returns a failing node nor continues below it — an
+// org chart read only through its active departments, say:
+//
+//	q.UID(rootUID).Recurse(0, false).RecurseFilter(`eq(active, true)`)
+//
+// The root nodes are not filtered by it; see Filter for that. It applies to
+// the selection Recurse renders, so Select and Expand, which take precedence,
+// leave the traversal unpruned. Repeated calls AND together; a later All
+// discards it.
+func (qb *Query[T]) RecurseFilter(expr string, params ...any) *Query[T] {
+	if expr != "" {
+		qb.recurseFilters = append(qb.recurseFilters, filterFrag{expr: expr, params: params})
+		qb.pushSelection()
+	}
+	return qb
+}
+
+// Expand selects only the predicates the named dgraph types declare, through
+// expand(Type), rather than everything a node holds. On a node that carries
+// several types this keeps the other types' predicates out of the result:
+//
+//	q.Expand("Course")
+//
+// Edges among those predicates are read one level deep with their own
+// expand(_all_). Expand takes precedence over the selection Edge, Let, and
+// Compute render, and Select takes precedence over Expand; under Recurse the
+// expansion applies at every level. A later All discards it. Repeated calls
+// overwrite.
+func (qb *Query[T]) Expand(typeNames ...string) *Query[T] {
+	qb.expandTypes = typeNames
+	qb.pushSelection()
+	return qb
+}
+
+// pushSelection replaces the dgman selection with the one Select set, or else
+// one rendered from Expand's types or from T's fields and the accumulated Edge,
+// Let, and Compute shaping, prefixed by any directive (Normalize, Recurse) that
+// dgman cannot render itself. A detached query has nowhere to push to.
+func (qb *Query[T]) pushSelection() {
+	if qb.q == nil {
+		return
+	}
+	body, params := qb.selectBody, qb.selectParams
+	if body == "" && len(qb.expandTypes) > 0 {
+		body, params = expandSelection(qb.expandTypes, qb.recurse == ""), nil
+	} else if body == "" && qb.recurse != "" {
+		body, params = recurseSelection[T](qb.omitted, qb.recurseFilters)
+	} else if body == "" {
+		body, params = qb.selection()
+	}
+	// dgman writes the selection straight after its own directives, so the
+	// leading space keeps ours apart from a preceding @cascade.
+	var directives string
+	if qb.normalize {
+		directives += " @normalize"
+	}
+	if qb.recurse != "" {
+		directives += " " + qb.recurse
+	}
+	if directives != "" {
+		body = directives + " " + body
+	}
+	qb.q.Query(body, params...)
+}
+
+// selection renders the explicit selection set for T, and the params its edge
+// filters bind: uid and dgraph.type, every scalar predicate (bound to its
+// value variable when Let names it, read in LangFallback's languages when
+// tagged lang), one block per edge carrying that edge's
+// arguments, one per Reverse edge T does not declare, and one aliased line
+// per Compute. dgraph's expand(_all_) cannot be combined with an explicit
+// block or variable for a predicate it also expands, so every field is listed.
+// Computed alias fields are not predicates and are left to Compute.
+func (qb *Query[T]) selection() (string, []any) {
+	t := getElemType(reflect.TypeFor[T]())
+	bound := make(map[string]bool, len(qb.lets))
+	declared := make(map[string]bool, t.NumField())
+	var params []any
+	var b strings.Builder
+	b.WriteString("{\n\tuid\n\tdgraph.type\n")
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		pred := fieldPredicate(field)
+		declared[pred] = true
+		if pred == "" || pred == "uid" || pred == "dgraph.type" || isComputedField(field) ||
+			slices.Contains(qb.omitted, pred) {
+			continue
+		}
+		b.WriteString("\t")
+		if !isEdgeType(field.Type) {
+			letBound := false
+			for _, l := range qb.lets {
+				if l.predicate == pred && !bound[l.name] {
+					bound[l.name] = true
+					letBound = true
+					b.WriteString(l.name)
+					b.WriteString(" as ")
+					break
+				}
+			}
+			b.WriteString(pred)
+			if len(qb.langs) > 0 && !letBound && isLangField(field) {
+				// The alias keeps the value under the field's own key.
+				b.WriteString(" : ")
+				b.WriteString(pred)
+				b.WriteString("@")
+				b.WriteString(strings.Join(qb.langs, ":"))
+			}
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString(pred)
+		for _, p := range qb.edgePages {
+			if p.predicate == pred {
+				args, edgeParams := p.args(len(params))
+				b.WriteString(args)
+				params = append(params, edgeParams...)
+				break
+			}
+		}
+		if qb.untyped {
+			b.WriteString(untypedEdgeSelection(field.Type))
+			continue
+		}
+		b.WriteString(" {\n\t\tuid\n\t\tdgraph.type\n\t\texpand(_all_)\n\t}\n")
+	}
+	// A variable over a predicate T does not declare still has to be bound.
+	for _, l := range qb.lets {
+		if !bound[l.name] {
+			b.WriteString("\t")
+			b.WriteString(l.name)
+			b.WriteString(" as ")
+			b.WriteString(l.predicate)
+			b.WriteString("\n")
+		}
+	}
+	reverses, reverseParams := qb.reverseSelection(declared, len(params))
+	b.WriteString(reverses)
+	params = append(params, reverseParams...)
+	for _, c := range qb.computed {
+		b.WriteString("\t")
+		b.WriteString(c.alias)
+		b.WriteString(" : ")
+		b.WriteString(c.expr)
+		b.WriteString("\n")
+	}
+	b.WriteString("}")
+	return b.String(), params
+}
+
+// recurseSelection renders the flat selection set a @recurse block applies at
+// every level: uid and dgraph.type, then each of T's scalar predicates and
+// edges by name alone, less the omitted ones. Each edge carries the ANDed
+// filters, with their placeholders numbered after the previous edge's, and
+// the params they bind are returned.
+func recurseSelection[T any](omitted []string, filters []filterFrag) (string, []any) {
+	t := getElemType(reflect.TypeFor[T]())
+	expr, exprParams := combineAnd(filters)
+	var params []any
+	var b strings.Builder
+	b.WriteString("{\n\tuid\n\tdgraph.type\n")
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		pred := fieldPredicate(field)
+		if pred == "" || pred == "uid" || pred == "dgraph.type" || isComputedField(field) ||
+			slices.Contains(omitted, pred) {
+			continue
+		}
+		b.WriteString("\t")
+		b.WriteString(pred)
+		if expr != "" && isEdgeType(field.Type) {
+			b.WriteString(" @filter(" + shiftPlaceholders(expr, len(params)) + ")")
+			params = append(params, exprParams...)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}")
+	return b.String(), params
+}
+
+// expandSelection renders a selection set of uid, dgraph.type, and
+// expand(typeNames...). With nested set, the expanded edges get a block of
+// their own; @recurse forbids nested blocks, so it renders flat there.
+func expandSelection(typeNames []string, nested bool) string {
+	var b strings.Builder
+	b.WriteString("{\n\tuid\n\tdgraph.type\n\texpand(")
+	b.WriteString(strings.Join(typeNames, ", "))
+	b.WriteString(")")
+	if nested {
+		b.WriteString(" {\n\t\tuid\n\t\tdgraph.type\n\t\texpand(_all_)\n\t}")
+	}
+	b.WriteString("\n}")
+	return b.String()
+}
+
+// fieldPredicate returns the dgraph predicate a struct field maps to: an
+// explicit predicate= in the dgraph tag, else the json tag name. It returns ""
+// for fields that are not persisted.
+func fieldPredicate(field reflect.StructField) string {
+	for part := range strings.FieldsSeq(field.Tag.Get("dgraph")) {
+		if p, ok := strings.CutPrefix(part, "predicate="); ok {
+			return p
+		}
+	}
+	jsonTag := field.Tag.Get("json")
+	if jsonTag == "-" {
+		return ""
+	}
+	return strings.Split(jsonTag, ",")[0]
+}
+
+// isComputedField reports whether field is tagged dgraph:"alias=...", a slot
+// for a value the query computes rather than a stored predicate.
+func isComputedField(field reflect.StructField) bool {
+	for part := range strings.FieldsSeq(field.Tag.Get("dgraph")) {
+		if strings.HasPrefix(part, "alias=") {
+			return true
+		}
+	}
+	return false
+}
+
+// isLangField reports whether field is tagged dgraph:"lang", a string
+// predicate that holds language-tagged values.
+func isLangField(field reflect.StructField) bool {
+	for part := range strings.FieldsSeq(field.Tag.Get("dgraph")) {
+		if part == "lang" {
+			return true
+		}
+	}
+	return false
+}
+
+// isEdgeType reports whether a field of type t holds node references: a
+// struct (or pointer/slice of one) that carries its own uid field. Value
+// structs such as time.Time do not.
+func isEdgeType(t reflect.Type) bool {
+	t = getElemType(t)
+	if t == nil || t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if strings.Split(t.Field(i).Tag.Get("json"), ",")[0] == "uid" {
+			return true
+		}
+	}
+	return false
+}
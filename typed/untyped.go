@@ -0,0 +1,74 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Untyped roots the query at has(predicate), the nodes that hold predicate,
+// for data written without a dgraph.type, such as RDF loaded in bulk. dgman
+// reads only typed nodes: its root is type(<NodeType>), it guards every
+// block with has(dgraph.type), and its expand(_all_) selection expands
+// nothing on a node without a type. Untyped drops the guard and selects T's
+// fields by name instead, so the rows still decode into []T:
+//
+//	type film struct {
+//		UID  string `json:"uid,omitempty"`
+//		Name string `json:"name,omitempty"`
+//	}
+//	films, err := typed.NewClient[film](conn).Query(ctx).
+//		Untyped("initial_release_date").
+//		Filter("ge(initial_release_date, $1)", "2000").
+//		Nodes()
+//
+// Edges read their target's fields by name too, one level deep. Filter,
+// ordering, pagination, WhereEdge, and the selection shaping apply as usual;
+// a later All restores the expanded selection, which reads nothing on
+// untyped nodes. Like RootFunc it overwrites any earlier root, and like
+// OfType it does not satisfy RequireFilter, since every node holding the
+// predicate still matches. predicate is interpolated into the DQL verbatim.
+func (qb *Query[T]) Untyped(predicate string) *Query[T] {
+	qb.RootFunc("has(" + predicate + ")")
+	qb.typeRoot = true
+	qb.untyped = true
+	qb.pushSelection()
+	return qb
+}
+
+// typeGuard is the filter dgman puts on every block it renders so that a
+// deleted node, which keeps no dgraph.type, is not returned.
+const typeGuard = "has(dgraph.type)"
+
+// dropTypeGuard removes dgman's type guard from request, the rendered DQL of
+// an Untyped query. The has(predicate) root already leaves out a deleted
+// node, which keeps no predicates.
+func dropTypeGuard(request string) string {
+	request = strings.ReplaceAll(request, "@filter("+typeGuard+") ", "")
+	return strings.ReplaceAll(request, "@filter("+typeGuard+" AND ", "@filter(")
+}
+
+// untypedEdgeSelection renders the selection of an edge to t for an Untyped
+// query: uid and every scalar predicate of t, by name, since expand(_all_)
+// reads nothing on a target without a type.
+func untypedEdgeSelection(t reflect.Type) string {
+	t = getElemType(t)
+	var b strings.Builder
+	b.WriteString(" {\n\t\tuid\n")
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		pred := fieldPredicate(field)
+		if pred == "" || pred == "uid" || isComputedField(field) || isEdgeType(field.Type) {
+			continue
+		}
+		b.WriteString("\t\t")
+		b.WriteString(pred)
+		b.WriteString("\n")
+	}
+	b.WriteString("\t}\n")
+	return b.String()
+}
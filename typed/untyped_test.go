@@ -0,0 +1,132 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+// film and director match RDF loaded without any dgraph.type.
+type film struct {
+	UID      string      `json:"uid,omitempty"`
+	Name     string      `json:"film_name,omitempty"`
+	Released string      `json:"film_released,omitempty"`
+	Director []*director `json:"film_director,omitempty"`
+}
+
+type director struct {
+	UID  string `json:"uid,omitempty"`
+	Name string `json:"director_name,omitempty"`
+}
+
+// loadUntypedFilms writes films and their directors as bare N-Quads, the way
+// a bulk RDF load leaves them.
+func loadUntypedFilms(t *testing.T, conn modusgraph.Client) {
+	t.Helper()
+	dgo, cleanup, err := conn.DgraphClient()
+	if err != nil {
+		t.Fatalf("DgraphClient: %v", err)
+	}
+	defer cleanup()
+	ctx := context.Background()
+	if err := dgo.Alter(ctx, &api.Operation{
+		Schema: "film_name: string @index(exact) .\nfilm_released: string @index(exact) .\n" +
+			"film_director: [uid] .\ndirector_name: string .",
+	}); err != nil {
+		t.Fatalf("Alter: %v", err)
+	}
+	_, err = dgo.NewTxn().Mutate(ctx, &api.Mutation{
+		SetNquads: []byte(`_:lynch <director_name> "David Lynch" .
+_:coen <director_name> "Joel Coen" .
+_:mulholland <film_name> "Mulholland Drive" .
+_:mulholland <film_released> "2001" .
+_:mulholland <film_director> _:lynch .
+_:eraserhead <film_name> "Eraserhead" .
+_:eraserhead <film_released> "1977" .
+_:eraserhead <film_director> _:lynch .
+_:fargo <film_name> "Fargo" .
+_:fargo <film_released> "1996" .
+_:fargo <film_director> _:coen .`),
+		CommitNow: true,
+	})
+	if err != nil {
+		t.Fatalf("Mutate: %v", err)
+	}
+}
+
+func TestQuery_Untyped(t *testing.T) {
+	ctx := context.Background()
+	conn := newConn(t)
+	loadUntypedFilms(t, conn)
+	films := typed.NewClient[film](conn)
+
+	if got, err := films.Query(ctx).Nodes(); err != nil || len(got) != 0 {
+		t.Fatalf("untyped data without Untyped = %v, %v; want no records", got, err)
+	}
+
+	got, err := films.Query(ctx).Untyped("film_name").OrderAsc("film_name").Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	var names []string
+	for _, f := range got {
+		names = append(names, f.Name)
+	}
+	if strings.Join(names, ",") != "Eraserhead,Fargo,Mulholland Drive" {
+		t.Fatalf("Untyped films = %v, want [Eraserhead Fargo Mulholland Drive]", names)
+	}
+	if got[1].Released != "1996" || len(got[1].Director) != 1 || got[1].Director[0].Name != "Joel Coen" {
+		t.Fatalf("Fargo decoded as %+v; want its release year and director", got[1])
+	}
+
+	// Filters, edge constraints, and counts apply as they do on typed data.
+	got, count, err := films.Query(ctx).
+		Untyped("film_name").
+		Filter("ge(film_released, $1)", "1990").
+		WhereEdge("film_director", `eq(director_name, "David Lynch")`).
+		NodesAndCount()
+	if err != nil {
+		t.Fatalf("NodesAndCount: %v", err)
+	}
+	if count != 1 || len(got) != 1 || got[0].Name != "Mulholland Drive" {
+		t.Fatalf("Lynch films since 1990 = %+v (count %d), want Mulholland Drive", got, count)
+	}
+
+	first, err := films.Query(ctx).Untyped("film_name").Filter(`eq(film_name, "Fargo")`).First()
+	if err != nil || first == nil || first.Released != "1996" {
+		t.Fatalf("First = %+v, %v; want Fargo", first, err)
+	}
+
+	directors := typed.NewClient[director](conn)
+	block, err := directors.Query(ctx).Untyped("director_name").FormatBlock("d")
+	if err != nil {
+		t.Fatalf("FormatBlock: %v", err)
+	}
+	if strings.Contains(block, "dgraph.type)") {
+		t.Fatalf("Untyped block should not require a type; got:\n%s", block)
+	}
+	res, err := typed.NewMultiQuery[director](conn).
+		Add("d", directors.Query(ctx).Untyped("director_name")).
+		Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if len(res["d"]) != 2 || res["d"][0].Name == "" {
+		t.Fatalf("MultiQuery directors = %+v, want David Lynch and Joel Coen", res["d"])
+	}
+
+	// Every node holding the predicate matches, so RequireFilter still wants a filter.
+	if _, err := films.Query(ctx).RequireFilter().Untyped("film_name").Nodes(); !errors.Is(err, typed.ErrFilterRequired) {
+		t.Fatalf("Untyped without a filter under RequireFilter: err = %v, want ErrFilterRequired", err)
+	}
+}
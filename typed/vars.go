@@ -0,0 +1,83 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed
+
+import (
+	"strings"
+
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// VarBlock is a DQL block that binds query variables for another query to
+// consume through val() or uid(): a typed query turned into a var block with
+// Query.Var, or a root-level aggregate built with Aggregate. Pass var blocks
+// to Query.With.
+type VarBlock interface {
+	varBlock() string
+}
+
+// varBlock renders the block without the request's outer braces.
+func (r *RawQuery) varBlock() string {
+	wrapped := dg.NewQueryBlock(r.q).String()
+	return strings.TrimSuffix(strings.TrimPrefix(wrapped, "{\n"), "}")
+}
+
+// aggregate is a root-level var block reducing one value variable.
+type aggregate struct {
+	name, fn, valueVar string
+}
+
+func (a aggregate) varBlock() string {
+	return "\tvar() { " + a.name + " as " + a.fn + "(val(" + a.valueVar + ")) }\n"
+}
+
+// Aggregate returns a var block binding name to fn — one of dgraph's min,
+// max, sum, or avg — over every value of the value variable valueVar, which
+// another var block binds (for example with Let). The result is a single
+// value, so any block of the request can compare against it with val(name):
+//
+//	budgets := depts.Query(ctx).Let("b", "budget").Var()
+//	courses.Query(ctx).
+//		With(budgets, typed.Aggregate("avgBudget", "avg", "b")).
+//		WhereEdge("in_department", "gt(budget, val(avgBudget))")
+func Aggregate(name, fn, valueVar string) VarBlock {
+	return aggregate{name: name, fn: fn, valueVar: valueVar}
+}
+
+// With prepends var blocks to the request the query runs in, so its Filter,
+// WhereEdge, and Compute expressions can refer to the variables they bind,
+// such as a value computed over another type. Blocks render in the order
+// given, and With accumulates.
+//
+// A query carrying With blocks runs as one multi-block request, as a
+// WhereEdge query does, so it cannot be composed by FormatBlock or MultiQuery.
+func (qb *Query[T]) With(blocks ...VarBlock) *Query[T] {
+	qb.with = append(qb.with, blocks...)
+	return qb
+}
+
+// multiBlock reports whether the query must run as a multi-block request
+// rather than through dgman's single-block path. An Untyped query runs as one
+// too, since dgman cannot render a block without its type guard.
+func (qb *Query[T]) multiBlock() bool {
+	return len(qb.edges) > 0 || len(qb.with) > 0 || qb.untyped
+}
+
+// withBlocks inserts the With blocks at the start of request, the rendered
+// multi-block request.
+func (qb *Query[T]) withBlocks(request string) string {
+	if len(qb.with) == 0 {
+		return request
+	}
+	i := strings.Index(request, "{\n") + len("{\n")
+	var b strings.Builder
+	b.WriteString(request[:i])
+	for _, block := range qb.with {
+		b.WriteString(block.varBlock())
+	}
+	b.WriteString(request[i:])
+	return b.String()
+}
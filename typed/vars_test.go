@@ -0,0 +1,91 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package typed_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph/typed"
+)
+
+type faculty struct {
+	UID    string   `json:"uid,omitempty"`
+	DType  []string `json:"dgraph.type,omitempty"`
+	Name   string   `json:"faculty_name,omitempty" dgraph:"index=exact"`
+	Budget int      `json:"faculty_budget,omitempty"`
+}
+
+type module struct {
+	UID     string   `json:"uid,omitempty"`
+	DType   []string `json:"dgraph.type,omitempty"`
+	Name    string   `json:"module_name,omitempty" dgraph:"index=exact"`
+	Faculty *faculty `json:"in_faculty,omitempty"`
+}
+
+// seedFaculties stores three faculties averaging a budget of 300, each
+// offering one module named after it.
+func seedFaculties(t *testing.T, ctx context.Context) (*typed.Client[faculty], *typed.Client[module]) {
+	t.Helper()
+	conn := newConn(t)
+	faculties := typed.NewClient[faculty](conn)
+	modules := typed.NewClient[module](conn)
+	for _, f := range []*faculty{{Name: "arts", Budget: 100}, {Name: "law", Budget: 300}, {Name: "science", Budget: 500}} {
+		if err := faculties.Add(ctx, f); err != nil {
+			t.Fatalf("Add faculty %s: %v", f.Name, err)
+		}
+		if err := modules.Add(ctx, &module{Name: f.Name + "-101", Faculty: &faculty{UID: f.UID}}); err != nil {
+			t.Fatalf("Add module: %v", err)
+		}
+	}
+	return faculties, modules
+}
+
+func TestQuery_WithAggregateFiltersEdge(t *testing.T) {
+	ctx := context.Background()
+	faculties, modules := seedFaculties(t, ctx)
+
+	budgets := faculties.Query(ctx).Let("b", "faculty_budget").Var()
+	rows, err := modules.Query(ctx).
+		With(budgets, typed.Aggregate("avgBudget", "avg", "b")).
+		WhereEdge("in_faculty", "gt(faculty_budget, val(avgBudget))").
+		Nodes()
+	if err != nil {
+		t.Fatalf("Nodes: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Name != "science-101" {
+		t.Fatalf("got %+v, want only science-101", rows)
+	}
+}
+
+func TestQuery_WithAggregateFiltersRoot(t *testing.T) {
+	ctx := context.Background()
+	faculties, _ := seedFaculties(t, ctx)
+
+	budgets := faculties.Query(ctx).Let("b", "faculty_budget").Var()
+	rows, count, err := faculties.Query(ctx).
+		With(budgets, typed.Aggregate("minBudget", "min", "b")).
+		Filter("gt(faculty_budget, val(minBudget))").
+		OrderAsc("faculty_name").
+		NodesAndCount()
+	if err != nil {
+		t.Fatalf("NodesAndCount: %v", err)
+	}
+	var names []string
+	for _, r := range rows {
+		names = append(names, r.Name)
+	}
+	if !slices.Equal(names, []string{"law", "science"}) || count != 2 {
+		t.Fatalf("got %v (count %d), want [law science] (count 2)", names, count)
+	}
+
+	if _, err := faculties.Query(ctx).With(budgets).FormatBlock("b"); err == nil ||
+		!strings.Contains(err.Error(), "With") {
+		t.Errorf("FormatBlock with var blocks: err = %v, want a With error", err)
+	}
+}
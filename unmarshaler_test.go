@@ -0,0 +1,125 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// moneyUnmarshals counts the calls to Money.UnmarshalJSON.
+var moneyUnmarshals atomic.Int64
+
+// Money is an amount stored as a single string, such as "12.34 EUR". It is a
+// struct, which dgman would otherwise take for an edge to another node, so it
+// declares its own schema type.
+type Money struct {
+	Cents    int64
+	Currency string
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("%d.%02d %s", m.Cents/100, m.Cents%100, m.Currency))
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	moneyUnmarshals.Add(1)
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	var units, cents int64
+	if _, err := fmt.Sscanf(s, "%d.%d %s", &units, &cents, &m.Currency); err != nil {
+		return fmt.Errorf("parsing money %q: %w", s, err)
+	}
+	m.Cents = units*100 + cents
+	return nil
+}
+
+func (Money) SchemaType() string {
+	return "string"
+}
+
+type Invoice struct {
+	UID    string   `json:"uid,omitempty"`
+	Number string   `json:"invoice_number,omitempty" dgraph:"index=exact"`
+	Total  Money    `json:"invoice_total,omitempty"`
+	Fee    *Money   `json:"invoice_fee,omitempty"`
+	DType  []string `json:"dgraph.type,omitempty"`
+}
+
+func TestCustomUnmarshalerDecoding(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "CustomUnmarshalerWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "CustomUnmarshalerWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			ctx := context.Background()
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			invoice := &Invoice{
+				Number: "INV-1",
+				Total:  Money{Cents: 1234, Currency: "EUR"},
+				Fee:    &Money{Cents: 50, Currency: "EUR"},
+			}
+			require.NoError(t, client.Insert(ctx, invoice), "Insert should succeed")
+
+			// The value is stored in the field's own encoding
+			data, err := client.QueryRaw(ctx,
+				fmt.Sprintf(`{ q(func: uid(%s)) { invoice_total invoice_fee } }`, invoice.UID), nil)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"q": [{"invoice_total": "12.34 EUR", "invoice_fee": "0.50 EUR"}]}`, string(data))
+
+			before := moneyUnmarshals.Load()
+			var got Invoice
+			require.NoError(t, client.Get(ctx, &got, invoice.UID), "Get should succeed")
+			require.Equal(t, int64(2), moneyUnmarshals.Load()-before, "Get should decode through UnmarshalJSON")
+			require.Equal(t, invoice.Total, got.Total)
+			require.Equal(t, invoice.Fee, got.Fee)
+
+			before = moneyUnmarshals.Load()
+			var all []Invoice
+			require.NoError(t, client.Query(ctx, Invoice{}).Nodes(&all), "Query should succeed")
+			require.Len(t, all, 1)
+			require.Equal(t, int64(2), moneyUnmarshals.Load()-before, "Query should decode through UnmarshalJSON")
+			require.Equal(t, invoice.Total, all[0].Total)
+
+			// GetMap and QueryInterface decode into factory values on their own path
+			byUID, err := client.GetMap(ctx, []string{invoice.UID}, func() any { return &Invoice{} })
+			require.NoError(t, err, "GetMap should succeed")
+			require.Equal(t, invoice.Total, byUID[invoice.UID].(*Invoice).Total)
+			nodes, err := client.QueryInterface(ctx, "Invoice", func() any { return &Invoice{} })
+			require.NoError(t, err, "QueryInterface should succeed")
+			require.Len(t, nodes, 1)
+			require.Equal(t, invoice.Fee, nodes[0].(*Invoice).Fee)
+		})
+	}
+}
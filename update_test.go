@@ -7,8 +7,10 @@ package modusgraph_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -176,6 +178,73 @@ func TestClientUpdateWithSlices(t *testing.T) {
 	}
 }
 
+func TestClientUpdateCount(t *testing.T) {
+
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "UpdateCountWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "UpdateCountWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			entities := []*TestEntity{
+				{Name: "Counted Entity 1"},
+				{Name: "Counted Entity 2"},
+				{Name: "Counted Entity 3"},
+			}
+			require.NoError(t, client.Insert(ctx, entities), "Batch insert should succeed")
+
+			entities[0].Description = "single"
+			n, err := client.UpdateCount(ctx, entities[0])
+			require.NoError(t, err, "Update should succeed")
+			require.Equal(t, 1, n, "A single struct should count as one node")
+
+			for _, entity := range entities {
+				entity.Description = "batch"
+			}
+			n, err = client.UpdateCount(ctx, entities)
+			require.NoError(t, err, "Batch update should succeed")
+			require.Equal(t, 3, n, "Every node of the batch should be counted")
+
+			n, err = client.UpdateCount(ctx, &entities)
+			require.NoError(t, err, "Batch update through a pointer should succeed")
+			require.Equal(t, 3, n)
+
+			var fetched TestEntity
+			require.NoError(t, client.Get(ctx, &fetched, entities[2].UID))
+			require.Equal(t, "batch", fetched.Description)
+
+			conflict := []*TestEntity{
+				{UID: entities[0].UID, Description: "valid"},
+				{UID: entities[1].UID, Name: "Counted Entity 3"},
+			}
+			n, err = client.UpdateCount(ctx, conflict)
+			require.Error(t, err, "Update should fail on the unique name")
+			require.Zero(t, n, "A failed update should count nothing")
+		})
+	}
+}
+
 type EmbeddedNodeType struct {
 	Name string `json:"node.name,omitempty" dgraph:"predicate=node.name"`
 
@@ -405,3 +474,82 @@ func TestClientUpdateWithUniqueEmbedded(t *testing.T) {
 		})
 	}
 }
+
+// ModifyCounter is a node whose Hits field is incremented through Modify.
+type ModifyCounter struct {
+	UID   string   `json:"uid,omitempty"`
+	Name  string   `json:"name,omitempty" dgraph:"index=exact"`
+	Hits  int      `json:"hits,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+func TestClientModify(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "ModifyWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "ModifyWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			counter := ModifyCounter{Name: "page-views"}
+			require.NoError(t, client.Insert(ctx, &counter))
+
+			const workers = 10
+			var wg sync.WaitGroup
+			errs := make(chan error, workers)
+			for range workers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					var c ModifyCounter
+					errs <- client.Modify(ctx, &c, counter.UID, func() error {
+						c.Hits++
+						return nil
+					})
+				}()
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				require.NoError(t, err, "Modify should succeed")
+			}
+
+			var got ModifyCounter
+			require.NoError(t, client.Get(ctx, &got, counter.UID))
+			require.Equal(t, workers, got.Hits, "No increment should be lost")
+			require.Equal(t, "page-views", got.Name, "Fields fn leaves alone should be kept")
+
+			errStop := errors.New("stop")
+			err := client.Modify(ctx, &got, counter.UID, func() error {
+				got.Hits = 0
+				return errStop
+			})
+			require.ErrorIs(t, err, errStop, "fn's error should be returned")
+			require.NoError(t, client.Get(ctx, &got, counter.UID))
+			require.Equal(t, workers, got.Hits, "A failed fn should write nothing")
+
+			require.Error(t, client.Modify(ctx, &got, "", func() error { return nil }),
+				"An empty UID should be rejected")
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dgraph-io/dgo/v250/protos/api"
+	dg "github.com/dolan-in/dgman/v2"
+)
+
+// UpdateWhere implements applying patch to every node of model's Dgraph type
+// that satisfies filter, a DQL filter expression such as
+// `eq(workspace_id, "w1")`, interpolated verbatim. patch maps predicates to
+// their new values, which are written as JSON; a nil value deletes the
+// predicate instead. As with DeleteBy, the matching nodes are read and
+// patched in one transaction, retried from a fresh read when a concurrent
+// writer aborts it, and the count is of the nodes this call patched. The
+// embedded engine binds an upsert block's variable to a single node, so the
+// patch is not sent as one. Fields the patch sets are not validated, and
+// version fields are not checked or bumped.
+func (c client) UpdateWhere(ctx context.Context, model any, filter string, patch map[string]any) (int, error) {
+	model = UnwrapSchema(model)
+	nodeType := getNodeType(model)
+	if !isValidPredicateName(nodeType) {
+		return 0, fmt.Errorf("UpdateWhere: invalid type name %q", nodeType)
+	}
+	if strings.TrimSpace(filter) == "" {
+		return 0, errors.New("UpdateWhere: filter must not be empty")
+	}
+	if len(patch) == 0 {
+		return 0, errors.New("UpdateWhere: patch must not be empty")
+	}
+	set, del := make(map[string]any), make(map[string]any)
+	for _, pred := range slices.Sorted(maps.Keys(patch)) {
+		if pred == "uid" || pred == "dgraph.type" || !isValidPredicateName(pred) {
+			return 0, fmt.Errorf("UpdateWhere: invalid predicate %q", pred)
+		}
+		if patch[pred] == nil {
+			del[pred] = nil
+		} else {
+			set[pred] = patch[pred]
+		}
+	}
+
+	query := "{ q(func: type(" + nodeType + ")) @filter(" + filter + ") { uid } }"
+	n, err := c.mutateMatched(ctx, query, nil, func(tx *dg.TxnContext, uids []string) error {
+		mu := &api.Mutation{}
+		var err error
+		if len(set) > 0 {
+			if mu.SetJson, err = patchJSON(uids, set); err != nil {
+				return err
+			}
+		}
+		if len(del) > 0 {
+			if mu.DeleteJson, err = patchJSON(uids, del); err != nil {
+				return err
+			}
+		}
+		_, err = tx.Txn().Mutate(tx.Context(), mu)
+		return err
+	})
+	if err == nil {
+		c.logger.V(2).Info("UpdateWhere successful", "type", nodeType, "count", n)
+	}
+	return n, err
+}
+
+// patchJSON renders fields once for each of uids, as the JSON array of node
+// objects a mutation takes.
+func patchJSON(uids []string, fields map[string]any) ([]byte, error) {
+	nodes := make([]map[string]any, len(uids))
+	for i, uid := range uids {
+		node := maps.Clone(fields)
+		node["uid"] = uid
+		nodes[i] = node
+	}
+	return json.Marshal(nodes)
+}
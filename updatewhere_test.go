@@ -0,0 +1,105 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type PatchThread struct {
+	UID       string   `json:"uid,omitempty"`
+	Title     string   `json:"patch_title,omitempty" dgraph:"index=exact"`
+	Workspace string   `json:"patch_workspace,omitempty" dgraph:"index=exact"`
+	Status    string   `json:"patch_status,omitempty"`
+	Priority  int      `json:"patch_priority,omitempty"`
+	DType     []string `json:"dgraph.type,omitempty"`
+}
+
+func TestUpdateWhere(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "UpdateWhereWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "UpdateWhereWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			ctx := context.Background()
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			threads := []*PatchThread{
+				{Title: "a", Workspace: "w1", Status: "open", Priority: 1},
+				{Title: "b", Workspace: "w1", Status: "open", Priority: 2},
+				{Title: "c", Workspace: "w1", Status: "closed", Priority: 3},
+				{Title: "d", Workspace: "w2", Status: "open", Priority: 4},
+				{Title: "e", Workspace: "w2", Status: "open", Priority: 5},
+			}
+			require.NoError(t, client.Insert(ctx, threads))
+
+			n, err := client.UpdateWhere(ctx, PatchThread{}, `eq(patch_workspace, "w1")`,
+				map[string]any{"patch_status": "archived"})
+			require.NoError(t, err)
+			require.Equal(t, 3, n, "Every thread in w1 should be patched")
+
+			var got []PatchThread
+			require.NoError(t, client.Query(ctx, PatchThread{}).OrderAsc("patch_title").Nodes(&got))
+			require.Len(t, got, 5)
+			for _, th := range got {
+				want := "open"
+				if th.Workspace == "w1" {
+					want = "archived"
+				}
+				require.Equal(t, want, th.Status, "thread %s", th.Title)
+			}
+			require.Equal(t, 1, got[0].Priority, "Predicates outside the patch are kept")
+
+			// A nil value deletes the predicate; several predicates change at once
+			n, err = client.UpdateWhere(ctx, &PatchThread{}, `eq(patch_workspace, "w2")`,
+				map[string]any{"patch_priority": nil, "patch_status": "stale"})
+			require.NoError(t, err)
+			require.Equal(t, 2, n)
+			var w2 []PatchThread
+			require.NoError(t, client.Query(ctx, PatchThread{}).
+				Filter(`eq(patch_workspace, "w2")`).Nodes(&w2))
+			require.Len(t, w2, 2)
+			for _, th := range w2 {
+				require.Equal(t, "stale", th.Status)
+				require.Zero(t, th.Priority)
+			}
+
+			n, err = client.UpdateWhere(ctx, PatchThread{}, `eq(patch_workspace, "w3")`,
+				map[string]any{"patch_status": "archived"})
+			require.NoError(t, err)
+			require.Zero(t, n, "Nothing matches w3")
+
+			_, err = client.UpdateWhere(ctx, PatchThread{}, "", map[string]any{"patch_status": "x"})
+			require.Error(t, err, "The filter must not be empty")
+			_, err = client.UpdateWhere(ctx, PatchThread{}, `eq(patch_workspace, "w1")`,
+				map[string]any{"uid": "0x1"})
+			require.Error(t, err, "The patch cannot change a node's UID")
+		})
+	}
+}
@@ -8,7 +8,6 @@ package modusgraph_test
 import (
 	"context"
 	"os"
-	"strings"
 	"testing"
 	"time"
 
@@ -140,6 +139,55 @@ func TestClientUpsert(t *testing.T) {
 	}
 }
 
+func TestClientUpsertMatchesKnownUID(t *testing.T) {
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "UpsertKnownUIDWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "UpsertKnownUIDWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			entity := UpsertTestEntity{Name: "Original Name", Description: "before"}
+			require.NoError(t, client.Upsert(ctx, &entity), "First upsert should insert")
+			require.NotEmpty(t, entity.UID)
+			uid := entity.UID
+
+			// The upsert predicate no longer matches the stored node, but the
+			// UID identifies it.
+			renamed := UpsertTestEntity{UID: uid, Name: "New Name", Description: "after"}
+			require.NoError(t, client.Upsert(ctx, &renamed), "Upsert with a known UID should succeed")
+			require.Equal(t, uid, renamed.UID, "The UID should be kept")
+
+			var entities []UpsertTestEntity
+			require.NoError(t, client.Query(ctx, UpsertTestEntity{}).Nodes(&entities))
+			require.Len(t, entities, 1, "No new node should be created")
+			require.Equal(t, uid, entities[0].UID)
+			require.Equal(t, "New Name", entities[0].Name)
+			require.Equal(t, "after", entities[0].Description)
+		})
+	}
+}
+
 func TestClientUpsertSlice(t *testing.T) {
 
 	testCases := []struct {
@@ -164,10 +212,6 @@ func TestClientUpsertSlice(t *testing.T) {
 				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
 				return
 			}
-			if strings.HasPrefix(tc.uri, "dgraph://") {
-				t.Skipf("Skipping %s: Dgraph URI not supported for upserting slices", tc.name)
-				return
-			}
 
 			client, cleanup := CreateTestClient(t, tc.uri)
 			defer cleanup()
@@ -238,6 +282,23 @@ func TestClientUpsertSlice(t *testing.T) {
 			require.NotNil(t, entity2, "Should find entity with name %s", entities[1].Name)
 			require.Equal(t, entities[1].Description, entity2.Description, "Description should match")
 			require.Equal(t, entities[1].CreatedAt, entity2.CreatedAt, "CreatedAt should match")
+
+			// A slice of struct values upserts the same way, matching the
+			// existing nodes and creating the new one
+			values := []UpsertTestEntity{
+				{Name: "Test Entity 1", Description: "From values"},
+				{Name: "Test Entity 3", Description: "From values"},
+			}
+			require.NoError(t, client.Upsert(ctx, values), "Upsert of values should succeed")
+			require.Equal(t, entities[0].UID, values[0].UID, "The matched node's UID should reach the element")
+			require.NotEmpty(t, values[1].UID, "The created node's UID should reach the element")
+
+			var entities4 []UpsertTestEntity
+			require.NoError(t, client.Query(ctx, UpsertTestEntity{}).Nodes(&entities4))
+			require.Len(t, entities4, 3, "Only the unmatched value should create a node")
+			entity1 = findMatchingEntity(entities4, "Test Entity 1")
+			require.NotNil(t, entity1)
+			require.Equal(t, "From values", entity1.Description)
 		})
 	}
 }
@@ -350,3 +411,137 @@ func TestEmbeddedUpsert(t *testing.T) {
 		})
 	}
 }
+
+func TestClientUpsertReturnOld(t *testing.T) {
+
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "UpsertReturnOldWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "UpsertReturnOldWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			original := UpsertTestEntity{
+				Name:        "Audited",
+				Description: "original description",
+				CreatedAt:   time.Date(2021, 6, 9, 17, 22, 33, 0, time.UTC),
+			}
+			var old UpsertTestEntity
+			created, err := client.UpsertReturnOld(ctx, &original, &old, "name")
+			require.NoError(t, err, "UpsertReturnOld should succeed")
+			require.True(t, created, "First upsert should create the node")
+			require.Empty(t, old.UID, "old should be zero when the node was created")
+			require.NotEmpty(t, original.UID, "UID should be assigned")
+
+			updated := UpsertTestEntity{
+				Name:        "Audited",
+				Description: "updated description",
+				CreatedAt:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			}
+			created, err = client.UpsertReturnOld(ctx, &updated, &old, "name")
+			require.NoError(t, err, "UpsertReturnOld should succeed")
+			require.False(t, created, "Second upsert should update the existing node")
+			require.Equal(t, original.UID, old.UID, "old should be the matched node")
+			require.Equal(t, "original description", old.Description, "old should hold the prior description")
+			require.Equal(t, original.CreatedAt, old.CreatedAt, "old should hold the prior timestamp")
+
+			var current UpsertTestEntity
+			require.NoError(t, client.Get(ctx, &current, original.UID), "Get should succeed")
+			require.Equal(t, "updated description", current.Description, "Node should carry the update")
+
+			var wrongType TestEntity
+			_, err = client.UpsertReturnOld(ctx, &updated, &wrongType, "name")
+			require.Error(t, err, "old of a different type should be rejected")
+		})
+	}
+}
+
+type BudgetEntity struct {
+	Name   string `json:"name,omitempty" dgraph:"index=exact upsert"`
+	Budget int    `json:"budget,omitempty" dgraph:"index=int"`
+
+	UID   string   `json:"uid,omitempty"`
+	DType []string `json:"dgraph.type,omitempty"`
+}
+
+func TestClientUpsertIf(t *testing.T) {
+
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "UpsertIfWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "UpsertIfWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			dept := BudgetEntity{Name: "Research", Budget: 100}
+			applied, err := client.UpsertIf(ctx, &dept, "name", "lt(budget, 100)")
+			require.NoError(t, err, "UpsertIf should succeed")
+			require.True(t, applied, "A missing node should be inserted regardless of the condition")
+			require.NotEmpty(t, dept.UID, "UID should be assigned")
+
+			lower := BudgetEntity{Name: "Research", Budget: 50}
+			applied, err = client.UpsertIf(ctx, &lower, "name", "lt(budget, 50)")
+			require.NoError(t, err, "UpsertIf should succeed")
+			require.False(t, applied, "The update should be skipped when the condition is false")
+
+			var current BudgetEntity
+			require.NoError(t, client.Get(ctx, &current, dept.UID), "Get should succeed")
+			require.Equal(t, 100, current.Budget, "A skipped update should leave the node unchanged")
+
+			higher := BudgetEntity{Name: "Research", Budget: 200}
+			applied, err = client.UpsertIf(ctx, &higher, "", "lt(budget, 200)")
+			require.NoError(t, err, "UpsertIf should succeed")
+			require.True(t, applied, "The update should apply when the condition is true")
+
+			require.NoError(t, client.Get(ctx, &current, dept.UID), "Get should succeed")
+			require.Equal(t, 200, current.Budget, "The applied update should be stored")
+
+			var all []BudgetEntity
+			require.NoError(t, client.Query(ctx, BudgetEntity{}).Nodes(&all), "Query should succeed")
+			require.Len(t, all, 1, "Conditional updates should not create duplicates")
+
+			_, err = client.UpsertIf(ctx, &higher, "name", "")
+			require.Error(t, err, "An empty condition should be rejected")
+		})
+	}
+}
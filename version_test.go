@@ -0,0 +1,118 @@
+/*
+ * SPDX-FileCopyrightText: © 2017-2026 Istari Digital, Inc.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package modusgraph_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/matthewmcneely/modusgraph"
+	"github.com/stretchr/testify/require"
+)
+
+type VersionedAccount struct {
+	UID     string   `json:"uid,omitempty"`
+	Owner   string   `json:"owner,omitempty" dgraph:"index=exact"`
+	Balance int      `json:"balance,omitempty"`
+	Version int      `json:"version,omitempty" dgraph:"version"`
+	DType   []string `json:"dgraph.type,omitempty"`
+}
+
+func TestVersionedUpdate(t *testing.T) {
+
+	testCases := []struct {
+		name string
+		uri  string
+		skip bool
+	}{
+		{
+			name: "VersionedUpdateWithFileURI",
+			uri:  "file://" + GetTempDir(t),
+		},
+		{
+			name: "VersionedUpdateWithDgraphURI",
+			uri:  "dgraph://" + os.Getenv("MODUSGRAPH_TEST_ADDR"),
+			skip: os.Getenv("MODUSGRAPH_TEST_ADDR") == "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skipf("Skipping %s: MODUSGRAPH_TEST_ADDR not set", tc.name)
+				return
+			}
+
+			client, cleanup := CreateTestClient(t, tc.uri)
+			defer cleanup()
+
+			ctx := context.Background()
+			account := VersionedAccount{Owner: "ada", Balance: 100}
+			require.NoError(t, client.Insert(ctx, &account), "Insert should succeed")
+
+			t.Run("sequential", func(t *testing.T) {
+				var fresh, stale VersionedAccount
+				require.NoError(t, client.Get(ctx, &fresh, account.UID))
+				require.NoError(t, client.Get(ctx, &stale, account.UID))
+
+				fresh.Balance += 10
+				require.NoError(t, client.Update(ctx, &fresh), "Update of the current version should succeed")
+				require.Equal(t, stale.Version+1, fresh.Version, "Update should increment the version")
+
+				stale.Balance += 20
+				before := stale.Version
+				err := client.Update(ctx, &stale)
+				require.ErrorIs(t, err, modusgraph.ErrVersionConflict, "Update of a stale copy should conflict")
+				require.Equal(t, before, stale.Version, "A conflicting update should leave the version untouched")
+
+				var stored VersionedAccount
+				require.NoError(t, client.Get(ctx, &stored, account.UID))
+				require.Equal(t, 110, stored.Balance, "The stale write must not be applied")
+				require.Equal(t, fresh.Version, stored.Version)
+			})
+
+			t.Run("concurrent", func(t *testing.T) {
+				var base VersionedAccount
+				require.NoError(t, client.Get(ctx, &base, account.UID))
+
+				const writers = 2
+				errs := make([]error, writers)
+				var wg sync.WaitGroup
+				for i := range writers {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						mine := base
+						mine.Balance = base.Balance + (i+1)*100
+						errs[i] = client.Update(ctx, &mine)
+					}()
+				}
+				wg.Wait()
+
+				succeeded, conflicted := 0, 0
+				for _, err := range errs {
+					switch {
+					case err == nil:
+						succeeded++
+					case errors.Is(err, modusgraph.ErrVersionConflict):
+						conflicted++
+					default:
+						t.Fatalf("unexpected update error: %v", err)
+					}
+				}
+				require.Equal(t, 1, succeeded, "Exactly one concurrent update should win")
+				require.Equal(t, 1, conflicted, "The other should get a version conflict")
+
+				var stored VersionedAccount
+				require.NoError(t, client.Get(ctx, &stored, account.UID))
+				require.Equal(t, base.Version+1, stored.Version)
+			})
+		})
+	}
+}
@@ -7,6 +7,8 @@ package modusgraph
 
 import (
 	"fmt"
+	"sync"
+	"sync/atomic"
 
 	"github.com/dgraph-io/badger/v4"
 	"github.com/dgraph-io/dgraph/v25/posting"
@@ -39,9 +41,18 @@ type zero struct {
 	minLeasedUID uint64
 	maxLeasedUID uint64
 
+	// tsMu guards the leased timestamp range: nextTs is reached both under
+	// the engine's write lock and, via CreateNamespace and the Zero hooks,
+	// under its read lock alongside other callers.
+	tsMu        sync.Mutex
 	minLeasedTs uint64
 	maxLeasedTs uint64
 
+	// committedTs is the latest timestamp whose writes are fully applied.
+	// Read-only queries read at it rather than at the latest timestamp
+	// handed out, which may belong to a transaction still in flight.
+	committedTs atomic.Uint64
+
 	lastNamespace uint64
 }
 
@@ -66,6 +77,13 @@ func newZero() (*zero, bool, error) {
 		z.maxLeasedTs = zs.MaxTxnTs
 		z.lastNamespace = zs.MaxNsID
 	}
+	// The oracle outlives engines closed earlier in this process, and schema
+	// updates are written at its MaxAssigned: never lease timestamps below it.
+	if maxAssigned := posting.Oracle().MaxAssigned(); z.minLeasedTs <= maxAssigned {
+		z.minLeasedTs = maxAssigned + 1
+		z.maxLeasedTs = z.minLeasedTs
+	}
+	z.committedTs.Store(z.minLeasedTs - 1)
 	posting.Oracle().ProcessDelta(&pb.OracleDelta{MaxAssigned: z.minLeasedTs - 1})
 	worker.SetMaxUID(z.minLeasedUID - 1)
 
@@ -79,7 +97,33 @@ func newZero() (*zero, bool, error) {
 	return z, restart, nil
 }
 
+// continueFrom moves z's UID and namespace leases past those prev handed
+// out. DropAll erases the zero state along with the data, so without it the
+// engine would issue the same UIDs again after a reset, and a UID a caller
+// still holds from before the drop would name an unrelated new node.
+// Timestamps need no carrying: newZero already starts past the oracle's.
+func (z *zero) continueFrom(prev *zero) error {
+	if prev.minLeasedUID > z.minLeasedUID {
+		z.minLeasedUID = prev.minLeasedUID
+		z.maxLeasedUID = prev.minLeasedUID
+		if err := z.leaseUIDs(); err != nil {
+			return err
+		}
+		worker.SetMaxUID(z.minLeasedUID - 1)
+	}
+	if prev.lastNamespace > z.lastNamespace {
+		z.lastNamespace = prev.lastNamespace
+		if err := z.writeZeroState(); err != nil {
+			return fmt.Errorf("error carrying namespace IDs: %w", err)
+		}
+	}
+	return nil
+}
+
 func (z *zero) nextTs() (uint64, error) {
+	z.tsMu.Lock()
+	defer z.tsMu.Unlock()
+
 	if z.minLeasedTs >= z.maxLeasedTs {
 		if err := z.leaseTs(); err != nil {
 			return 0, fmt.Errorf("error leasing timestamps: %w", err)
@@ -92,8 +136,21 @@ func (z *zero) nextTs() (uint64, error) {
 	return ts, nil
 }
 
+// readTs returns the timestamp read-only queries run at: the latest commit,
+// so a read issued after a mutation returns always observes it.
 func (z *zero) readTs() uint64 {
-	return z.minLeasedTs - 1
+	return z.committedTs.Load()
+}
+
+// markCommitted records that the writes at ts are applied and visible,
+// advancing readTs. Timestamps committed out of order never move it back.
+func (z *zero) markCommitted(ts uint64) {
+	for {
+		cur := z.committedTs.Load()
+		if ts <= cur || z.committedTs.CompareAndSwap(cur, ts) {
+			return
+		}
+	}
 }
 
 func (z *zero) nextUIDs(num *pb.Num) (*pb.AssignedIds, error) {